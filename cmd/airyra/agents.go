@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "Show the agents working on the project",
+	Long: `Show the agents seen in the project and the tasks each one holds.
+
+Every request to the server marks its agent as seen. An agent is:
+  active - seen recently and holding in-progress tasks
+  idle   - seen recently and holding nothing
+  stale  - not seen for longer than --stale-after (default 15m)
+
+A stale agent that still holds tasks is most likely a dead worker; free its
+tasks with 'airyra release <id> --force'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		state, _ := cmd.Flags().GetString("state")
+		staleAfter, _ := cmd.Flags().GetDuration("stale-after")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		agents, err := c.ListAgents(context.Background(), client.AgentFilter{
+			State:      state,
+			StaleAfter: staleAfter,
+		})
+		if err != nil {
+			handleError(err)
+		}
+
+		printAgents(os.Stdout, agents, jsonOutput)
+	},
+}
+
+var agentsRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Register this agent with a name and capabilities",
+	Long: `Register this agent, identified as in every request by its user, host and
+working directory, with a name, capabilities, metadata and its own WIP limit.
+Registering again replaces them.
+
+  airyra agents register --name builder --capability go --capability sql --meta host=ci-3`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		capabilities, _ := cmd.Flags().GetStringSlice("capability")
+		meta, _ := cmd.Flags().GetStringArray("meta")
+		wipLimit, _ := cmd.Flags().GetInt("wip-limit")
+
+		metadata, err := parseMetadata(meta)
+		if err != nil {
+			handleError(err)
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		agent, err := c.RegisterAgent(context.Background(), client.AgentRegistration{
+			Name:         name,
+			Capabilities: capabilities,
+			Metadata:     metadata,
+			WIPLimit:     wipLimit,
+		})
+		if err != nil {
+			handleError(err)
+		}
+
+		printAgent(os.Stdout, agent, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(agentsCmd)
+	agentsCmd.Flags().String("state", "", "Only show agents in a state (active, idle, stale)")
+	agentsCmd.Flags().Duration("stale-after", 0, "How long an agent may go unseen before it is stale (default 15m)")
+
+	agentsCmd.AddCommand(agentsRegisterCmd)
+	agentsRegisterCmd.Flags().String("name", "", "Display name of the agent")
+	agentsRegisterCmd.Flags().StringSlice("capability", nil, "Capability of the agent (repeatable)")
+	agentsRegisterCmd.Flags().StringArray("meta", nil, "Metadata as key=value (repeatable)")
+	agentsRegisterCmd.Flags().Int("wip-limit", 0, "Most in-progress tasks this agent may hold, below the project's agent_wip_limit")
+}
+
+// parseMetadata parses key=value pairs into a map
+func parseMetadata(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata %q: expected key=value", pair)
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
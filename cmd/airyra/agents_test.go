@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAgentsCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "agents" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have agents subcommand")
+	}
+}
+
+func TestAgentsCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"state", "stale-after"} {
+		if agentsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("agentsCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestAgentsCmd_HasRegisterSubcommand(t *testing.T) {
+	found := false
+	for _, cmd := range agentsCmd.Commands() {
+		if cmd.Name() == "register" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("agentsCmd should have register subcommand")
+	}
+	for _, name := range []string{"name", "capability", "meta", "wip-limit"} {
+		if agentsRegisterCmd.Flags().Lookup(name) == nil {
+			t.Errorf("agentsRegisterCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := parseMetadata([]string{"host=ci-3", "query=a=b", "empty="})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metadata) != 3 || metadata["host"] != "ci-3" || metadata["query"] != "a=b" || metadata["empty"] != "" {
+		t.Errorf("unexpected metadata: %v", metadata)
+	}
+
+	for _, invalid := range []string{"host", "=ci-3"} {
+		if _, err := parseMetadata([]string{invalid}); err == nil {
+			t.Errorf("parseMetadata(%q) should fail", invalid)
+		}
+	}
+
+	if metadata, err := parseMetadata(nil); err != nil || metadata != nil {
+		t.Errorf("parseMetadata(nil) = %v, %v; want nil", metadata, err)
+	}
+}
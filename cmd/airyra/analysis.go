@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var criticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show what gates delivery of the unfinished tasks",
+	Long: `Analyze the dependencies between unfinished tasks.
+
+Reports:
+  Critical path    The longest chain of unfinished dependencies. Tasks are
+                   weighted by their estimate when any task has one (tasks
+                   without an estimate count at the average), otherwise
+                   the chain with the most tasks is shown.
+  Blocking fan-out The tasks with the most unfinished work waiting on them.
+  Parallelism      How many unfinished tasks wait on nothing unfinished and
+                   can be worked on at the same time.
+
+Done and cancelled tasks are left out. Use --spec to analyze a single spec.
+Set estimates with 'airyra edit <id> --estimate 2h'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var filter client.AnalysisFilter
+		filter.SpecID, _ = cmd.Flags().GetString("spec")
+		filter.Limit, _ = cmd.Flags().GetInt("limit")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		analysis, err := c.GetAnalysis(context.Background(), filter)
+		if err != nil {
+			handleError(err)
+		}
+
+		printAnalysis(os.Stdout, analysis, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(criticalPathCmd)
+
+	criticalPathCmd.Flags().String("spec", "", "Only analyze the tasks of this spec")
+	criticalPathCmd.Flags().Int("limit", 10, "Maximum number of blocking tasks to list")
+}
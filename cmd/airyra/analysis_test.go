@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+func TestCriticalPathCmd_Flags(t *testing.T) {
+	for _, name := range []string{"spec", "limit"} {
+		if criticalPathCmd.Flags().Lookup(name) == nil {
+			t.Errorf("criticalPathCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestPrintAnalysis_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	estimate := 120
+	analysis := &domain.ScheduleAnalysis{
+		Remaining:   4,
+		Parallelism: 2,
+		CriticalPath: []domain.AnalysisTask{
+			{ID: "ar-1", Title: "Schema", Status: "open", Estimate: &estimate},
+			{ID: "ar-2", Title: "Login", Status: "open"},
+		},
+		PathEstimate: 240,
+		BlockingFanOut: []domain.FanOut{
+			{AnalysisTask: domain.AnalysisTask{ID: "ar-1", Title: "Schema", Status: "open"}, Direct: 2, Downstream: 3},
+		},
+	}
+
+	printAnalysis(&buf, analysis, false)
+
+	output := buf.String()
+	for _, want := range []string{
+		"Remaining: 4 tasks, 2 can be worked on in parallel",
+		"Critical path (2 tasks, ~4h):",
+		"1.  ar-1  Schema  open  2h",
+		"2.  ar-2  Login   open  -",
+		"Blocking fan-out:",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintAnalysis_NothingRemaining(t *testing.T) {
+	var buf bytes.Buffer
+	printAnalysis(&buf, &domain.ScheduleAnalysis{}, false)
+
+	if !strings.Contains(buf.String(), "No unfinished tasks") {
+		t.Errorf("expected empty message, got %q", buf.String())
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign <id> <agent>",
+	Short: "Reserve a task for an agent or group",
+	Long: `Reserve a task for an agent without claiming it on the agent's behalf.
+
+Only the assignee may claim the task, and the task only shows up in
+'airyra ready' and 'airyra next' for the assignee. An assignee starting with @
+names a group: any agent registered with that capability may claim the task.
+
+  airyra assign ar-a1b2 builder@ci-3:/src/app
+  airyra assign ar-a1b2 @go`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.UpdateTask(context.Background(), args[0], client.TaskUpdates{Assignee: &args[1]})
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var unassignCmd = &cobra.Command{
+	Use:   "unassign <id>",
+	Short: "Let any agent claim a task again",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		none := ""
+		task, err := c.UpdateTask(context.Background(), args[0], client.TaskUpdates{Assignee: &none})
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(assignCmd)
+	rootCmd.AddCommand(unassignCmd)
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAssignCmds_Exist(t *testing.T) {
+	for _, name := range []string{"assign", "unassign"} {
+		found := false
+		for _, cmd := range rootCmd.Commands() {
+			if cmd.Name() == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("rootCmd should have %s subcommand", name)
+		}
+	}
+}
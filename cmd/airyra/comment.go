@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment <id> <text>",
+	Short: "Comment on a task",
+	Long: `Leave a work note on a task, such as what was tried and why it failed.
+Comments are authored by the current agent and shown with 'airyra show --comments'.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		body := strings.Join(args[1:], " ")
+		comment, err := c.AddComment(context.Background(), args[0], body)
+		if err != nil {
+			handleError(err)
+		}
+
+		if jsonOutput {
+			printComments(os.Stdout, args[0], []domain.Comment{*comment}, true)
+			return
+		}
+		printSuccess(os.Stdout, "Comment added to task "+args[0], false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(commentCmd)
+}
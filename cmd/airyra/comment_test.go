@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCommentCmd_Exists(t *testing.T) {
+	if commentCmd == nil {
+		t.Error("commentCmd should not be nil")
+	}
+}
+
+func TestCommentCmd_Use(t *testing.T) {
+	if commentCmd.Use != "comment <id> <text>" {
+		t.Errorf("commentCmd.Use = %s, expected 'comment <id> <text>'", commentCmd.Use)
+	}
+}
+
+func TestShowCmd_HasCommentsFlag(t *testing.T) {
+	flag := showCmd.Flags().Lookup("comments")
+	if flag == nil {
+		t.Error("showCmd should have --comments flag")
+	}
+}
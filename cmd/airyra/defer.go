@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var deferCmd = &cobra.Command{
+	Use:   "defer <id>",
+	Short: "Keep a task out of the ready queue for a while",
+	Long: `Defer a task until a time, for a duration or until an event.
+
+A deferred task keeps its status but is left out of 'airyra ready' and
+'airyra next' until the time passes, when it returns to the queue on its own.
+--until takes a date (the start of that day) or an RFC 3339 time; --for takes
+a duration such as 2h or 3d.
+
+With --event the task waits for an external event instead, such as a deploy
+finishing, and returns to the queue when someone runs 'airyra signal <event>'.
+Event names may contain letters, digits, '-', '_', '.' and ':'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		until, _ := cmd.Flags().GetString("until")
+		forStr, _ := cmd.Flags().GetString("for")
+		event, _ := cmd.Flags().GetString("event")
+
+		set := 0
+		for _, value := range []string{until, forStr, event} {
+			if value != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			handleError(fmt.Errorf("specify exactly one of --until, --for or --event"))
+		}
+
+		var updates client.TaskUpdates
+		switch {
+		case until != "":
+			deferUntil, err := parseDeferUntil(until)
+			if err != nil {
+				handleError(err)
+			}
+			updates.DeferUntil = &deferUntil
+		case forStr != "":
+			d, err := parseOffset(forStr)
+			if err != nil || d <= 0 {
+				handleError(fmt.Errorf("invalid duration: %s (use a duration such as 2h or 3d)", forStr))
+			}
+			deferUntil := time.Now().Add(d).Truncate(time.Second).Format(time.RFC3339)
+			updates.DeferUntil = &deferUntil
+		default:
+			if !domain.ValidEventName(event) {
+				handleError(fmt.Errorf("invalid event name %q: %s", event, domain.EventNameRule))
+			}
+			updates.DeferEvent = &event
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.UpdateTask(context.Background(), args[0], updates)
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var undeferCmd = &cobra.Command{
+	Use:   "undefer <id>",
+	Short: "Return a deferred task to the ready queue",
+	Long: `Return a deferred task to the ready queue now, clearing both the time and
+the event it was deferred until.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		none := ""
+		task, err := c.UpdateTask(context.Background(), args[0], client.TaskUpdates{DeferUntil: &none, DeferEvent: &none})
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var signalCmd = &cobra.Command{
+	Use:   "signal <event>",
+	Short: "Signal an event that deferred tasks wait for",
+	Long: `Signal an external event. Every task deferred with 'airyra defer --event'
+until it returns to the ready queue, unless it is also deferred to a later
+time. Signalling an event no task waits for does nothing.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		event := args[0]
+		if !domain.ValidEventName(event) {
+			handleError(fmt.Errorf("invalid event name %q: %s", event, domain.EventNameRule))
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		tasks, err := c.SignalEvent(context.Background(), event)
+		if err != nil {
+			handleError(err)
+		}
+
+		printSignalled(os.Stdout, event, tasks, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deferCmd)
+	rootCmd.AddCommand(undeferCmd)
+	rootCmd.AddCommand(signalCmd)
+
+	deferCmd.Flags().String("until", "", "Date or RFC 3339 time to defer the task until")
+	deferCmd.Flags().String("for", "", "Duration to defer the task for, e.g. 2h or 3d")
+	deferCmd.Flags().String("event", "", "Event to defer the task until, signalled with 'airyra signal'")
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDeferCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"until", "for", "event"} {
+		if deferCmd.Flags().Lookup(name) == nil {
+			t.Errorf("deferCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestUndeferCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "undefer" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have undefer subcommand")
+	}
+}
+
+func TestSignalCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "signal" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have signal subcommand")
+	}
+}
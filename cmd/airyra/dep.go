@@ -20,7 +20,11 @@ var depAddCmd = &cobra.Command{
 	Long: `Add a dependency between two tasks.
 
 The child task will depend on the parent task. The child task cannot
-be started until the parent task is completed.`,
+be started until the parent task is completed.
+
+The parent can be a task in another project, written as project/task-id
+(e.g. backend/ar-1234). The child then stays out of the ready queue until
+that task is done in its own project.`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		childID := args[0]
@@ -66,17 +70,21 @@ var depRmCmd = &cobra.Command{
 var depListCmd = &cobra.Command{
 	Use:   "list <id>",
 	Short: "List dependencies",
-	Long:  `List all dependencies for a task.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `List all dependencies for a task.
+
+With --all, the dependencies of those tasks are listed too, recursively,
+showing everything that must be done before the task can start.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		taskID := args[0]
+		all, _ := cmd.Flags().GetBool("all")
 
 		c, err := getClient()
 		if err != nil {
 			handleError(err)
 		}
 
-		deps, err := c.ListDependencies(context.Background(), taskID)
+		deps, err := c.ListDependencies(context.Background(), taskID, all)
 		if err != nil {
 			handleError(err)
 		}
@@ -85,10 +93,40 @@ var depListCmd = &cobra.Command{
 	},
 }
 
+var depDependentsCmd = &cobra.Command{
+	Use:   "dependents <id>",
+	Short: "List tasks that depend on a task",
+	Long: `List the tasks that depend on a task, i.e. the tasks it blocks.
+
+With --all, the tasks that depend on those tasks are listed too,
+recursively, showing everything that finishing the task would unblock.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID := args[0]
+		all, _ := cmd.Flags().GetBool("all")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		deps, err := c.ListDependents(context.Background(), taskID, all)
+		if err != nil {
+			handleError(err)
+		}
+
+		printDependents(os.Stdout, taskID, deps, jsonOutput)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(depCmd)
 
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRmCmd)
 	depCmd.AddCommand(depListCmd)
+	depCmd.AddCommand(depDependentsCmd)
+
+	depListCmd.Flags().Bool("all", false, "Include transitive dependencies")
+	depDependentsCmd.Flags().Bool("all", false, "Include transitive dependents")
 }
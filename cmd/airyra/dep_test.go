@@ -1,13 +1,16 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/airyra/airyra/internal/client"
 	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
 )
 
 func TestDepCmd_Exists(t *testing.T) {
@@ -58,6 +61,17 @@ func TestDepListCmd_Use(t *testing.T) {
 	}
 }
 
+func TestDepDependentsCmd_Exists(t *testing.T) {
+	if depDependentsCmd.Use != "dependents <id>" {
+		t.Errorf("depDependentsCmd.Use = %q, expected %q", depDependentsCmd.Use, "dependents <id>")
+	}
+	for _, cmd := range []*cobra.Command{depListCmd, depDependentsCmd} {
+		if cmd.Flags().Lookup("all") == nil {
+			t.Errorf("%s should have --all flag", cmd.Name())
+		}
+	}
+}
+
 func TestDepAdd_Success(t *testing.T) {
 	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/v1/projects/testproject/tasks/child123/deps" && r.Method == "POST" {
@@ -134,6 +148,25 @@ func TestDepRm_Success(t *testing.T) {
 	}
 }
 
+func TestDepRm_OtherProject(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/testproject/tasks/child123/deps/backend/parent456" && r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	host, port := parseURL(server.URL)
+	c := client.NewClient(host, port, "testproject", "test@host:/path")
+
+	err := c.RemoveDependency(context.Background(), "child123", "backend/parent456")
+	if err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+}
+
 func TestDepRm_NotFound(t *testing.T) {
 	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -176,7 +209,7 @@ func TestDepList_Success(t *testing.T) {
 	host, port := parseURL(server.URL)
 	c := client.NewClient(host, port, "testproject", "test@host:/path")
 
-	deps, err := c.ListDependencies(context.Background(), "abc123")
+	deps, err := c.ListDependencies(context.Background(), "abc123", false)
 	if err != nil {
 		t.Fatalf("ListDependencies failed: %v", err)
 	}
@@ -199,7 +232,7 @@ func TestDepList_Empty(t *testing.T) {
 	host, port := parseURL(server.URL)
 	c := client.NewClient(host, port, "testproject", "test@host:/path")
 
-	deps, err := c.ListDependencies(context.Background(), "abc123")
+	deps, err := c.ListDependencies(context.Background(), "abc123", false)
 	if err != nil {
 		t.Fatalf("ListDependencies failed: %v", err)
 	}
@@ -208,3 +241,35 @@ func TestDepList_Empty(t *testing.T) {
 		t.Errorf("Expected 0 dependencies, got %d", len(deps))
 	}
 }
+
+func TestDepDependents_Transitive(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/testproject/tasks/abc123/dependents" && r.Method == "GET" {
+			if r.URL.Query().Get("transitive") != "true" {
+				t.Errorf("expected transitive=true, got %q", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode([]domain.Dependency{
+				{ChildID: "child1", ParentID: "abc123", Depth: 1},
+				{ChildID: "child2", ParentID: "child1", Depth: 2},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	host, port := parseURL(server.URL)
+	c := client.NewClient(host, port, "testproject", "test@host:/path")
+
+	deps, err := c.ListDependents(context.Background(), "abc123", true)
+	if err != nil {
+		t.Fatalf("ListDependents failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	printDependents(&buf, "abc123", deps, false)
+	output := buf.String()
+	if !strings.Contains(output, "blocks  child1\n") || !strings.Contains(output, "blocks  child2 (via child1)") {
+		t.Errorf("expected direct and transitive dependents, got:\n%s", output)
+	}
+}
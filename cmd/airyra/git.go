@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// taskTrailer is the commit message trailer used to reference airyra tasks
+const taskTrailer = "Airyra-Task"
+
+// runGit runs a git command and returns its trimmed stdout
+func runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// resolveCommit resolves a revision (e.g. HEAD) to a full commit SHA
+func resolveCommit(rev string) (string, error) {
+	return runGit("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+}
+
+// resolveBranch resolves a branch name; "HEAD" resolves to the current branch
+func resolveBranch(name string) (string, error) {
+	if name != "HEAD" {
+		return name, nil
+	}
+	branch, err := runGit("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("HEAD is not on a branch")
+	}
+	return branch, nil
+}
+
+// resolveRepoPath converts a path to a slash-separated path relative to the repository root
+func resolveRepoPath(path string) (string, error) {
+	root, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the repository", path)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// commitTaskIDs returns the task IDs referenced by the task trailer of a commit
+func commitTaskIDs(rev string) ([]string, error) {
+	out, err := runGit("log", "-1", "--format=%(trailers:key="+taskTrailer+",valueonly)", rev)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, line := range strings.Split(out, "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// gitHooksDir returns the hooks directory of the current repository
+func gitHooksDir() (string, error) {
+	dir, err := runGit("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(dir)
+}
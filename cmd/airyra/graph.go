@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// graphFormats lists the output formats of the graph command
+var graphFormats = []string{"ascii", "dot", "mermaid"}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Render the task and spec dependency graph",
+	Long: `Render task dependencies, spec dependencies and subtasks as a graph.
+
+Formats:
+  ascii    Indented trees in the terminal (default)
+  dot      Graphviz, e.g. airyra graph --format dot | dot -Tsvg > graph.svg
+  mermaid  Mermaid flowchart for Markdown documents
+
+Nodes are colored by status. Edges point downstream: from a task to the
+tasks that depend on it, and from a parent task to its subtasks.
+
+Use --spec to show a single spec and its tasks, or --ancestors and
+--descendants to show what a task waits on and what waits on it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		if !isGraphFormat(format) {
+			handleError(fmt.Errorf("expected format %s, got %q", strings.Join(graphFormats, ", "), format))
+		}
+
+		filter, err := graphFilterFromFlags(cmd)
+		if err != nil {
+			handleError(err)
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		graph, err := c.GetGraph(context.Background(), filter)
+		if err != nil {
+			handleError(err)
+		}
+
+		printGraph(os.Stdout, graph, format, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().String("format", "ascii", "Output format: ascii, dot or mermaid")
+	graphCmd.Flags().String("spec", "", "Only show this spec and its tasks")
+	graphCmd.Flags().String("ancestors", "", "Only show this task and the tasks it waits on")
+	graphCmd.Flags().String("descendants", "", "Only show this task and the tasks that wait on it")
+}
+
+// isGraphFormat checks if format is a known graph output format
+func isGraphFormat(format string) bool {
+	for _, f := range graphFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// graphFilterFromFlags builds the graph filter from the command-line flags
+func graphFilterFromFlags(cmd *cobra.Command) (client.GraphFilter, error) {
+	var filter client.GraphFilter
+	filter.SpecID, _ = cmd.Flags().GetString("spec")
+
+	ancestors, _ := cmd.Flags().GetString("ancestors")
+	descendants, _ := cmd.Flags().GetString("descendants")
+
+	switch {
+	case ancestors != "" && descendants != "":
+		if ancestors != descendants {
+			return filter, fmt.Errorf("--ancestors and --descendants must name the same task")
+		}
+		filter.TaskID = ancestors
+		filter.Direction = string(domain.GraphBoth)
+	case ancestors != "":
+		filter.TaskID = ancestors
+		filter.Direction = string(domain.GraphAncestors)
+	case descendants != "":
+		filter.TaskID = descendants
+		filter.Direction = string(domain.GraphDescendants)
+	}
+
+	return filter, nil
+}
+
+// statusColors maps task and spec statuses to node fill colors
+var statusColors = map[string]string{
+	string(domain.StatusOpen):       "#a9d1f7",
+	string(domain.StatusInProgress): "#ffe08a",
+	string(domain.StatusInReview):   "#c9b6f2",
+	string(domain.StatusBlocked):    "#f4a6a6",
+	string(domain.StatusDone):       "#b7e1a1",
+	string(domain.StatusCancelled):  "#d9d9d9",
+	string(domain.SpecStatusDraft):  "#ffffff",
+	string(domain.SpecStatusActive): "#ffe08a",
+}
+
+// statusANSI maps task and spec statuses to terminal color codes
+var statusANSI = map[string]string{
+	string(domain.StatusOpen):       "34",
+	string(domain.StatusInProgress): "33",
+	string(domain.StatusInReview):   "35",
+	string(domain.StatusBlocked):    "31",
+	string(domain.StatusDone):       "32",
+	string(domain.StatusCancelled):  "90",
+	string(domain.SpecStatusActive): "33",
+}
+
+// statusColor returns the fill color of a node, white for custom states
+func statusColor(status string) string {
+	if color, ok := statusColors[status]; ok {
+		return color
+	}
+	return "#ffffff"
+}
+
+// renderDOT writes the graph in Graphviz DOT format
+func renderDOT(w io.Writer, graph *domain.Graph) {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	fmt.Fprintln(w, "digraph airyra {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [style=filled, fontname="Helvetica"];`)
+	for _, n := range graph.Nodes {
+		shape := "box"
+		if n.Kind == domain.GraphNodeSpec {
+			shape = "folder"
+		}
+		label := fmt.Sprintf(`%s\n%s\n[%s]`, n.ID, quote.Replace(truncate(n.Title, 40)), n.Status)
+		fmt.Fprintf(w, "  \"%s\" [label=\"%s\", shape=%s, fillcolor=\"%s\"];\n", n.ID, label, shape, statusColor(n.Status))
+	}
+	for _, e := range graph.Edges {
+		attrs := ""
+		switch e.Kind {
+		case domain.GraphEdgeSpecDependency:
+			attrs = " [style=bold]"
+		case domain.GraphEdgeSubtask:
+			attrs = " [style=dashed, arrowhead=odiamond]"
+		}
+		fmt.Fprintf(w, "  \"%s\" -> \"%s\"%s;\n", e.From, e.To, attrs)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// mermaidUnsafe matches characters that cannot appear in mermaid node IDs
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// mermaidID converts an ID into a mermaid-safe identifier
+func mermaidID(id string) string {
+	return mermaidUnsafe.ReplaceAllString(id, "_")
+}
+
+// renderMermaid writes the graph as a mermaid flowchart
+func renderMermaid(w io.Writer, graph *domain.Graph) {
+	quote := strings.NewReplacer(`"`, "#quot;")
+
+	fmt.Fprintln(w, "flowchart LR")
+	statuses := make(map[string]bool)
+	var order []string
+	for _, n := range graph.Nodes {
+		open, close := "[", "]"
+		if n.Kind == domain.GraphNodeSpec {
+			open, close = "[[", "]]"
+		}
+		class := "status_" + mermaidID(n.Status)
+		fmt.Fprintf(w, "  %s%s\"%s: %s\"%s:::%s\n", mermaidID(n.ID), open, n.ID, quote.Replace(truncate(n.Title, 40)), close, class)
+		if !statuses[n.Status] {
+			statuses[n.Status] = true
+			order = append(order, n.Status)
+		}
+	}
+	for _, e := range graph.Edges {
+		arrow := "-->"
+		switch e.Kind {
+		case domain.GraphEdgeSpecDependency:
+			arrow = "==>"
+		case domain.GraphEdgeSubtask:
+			arrow = "-.->"
+		}
+		fmt.Fprintf(w, "  %s %s %s\n", mermaidID(e.From), arrow, mermaidID(e.To))
+	}
+	for _, status := range order {
+		fmt.Fprintf(w, "  classDef status_%s fill:%s\n", mermaidID(status), statusColor(status))
+	}
+}
+
+// renderASCII writes specs and tasks as indented trees that follow the
+// graph edges downstream. Nodes reached more than once are expanded only
+// the first time. With color, statuses are highlighted with ANSI colors.
+func renderASCII(w io.Writer, graph *domain.Graph, color bool) {
+	if len(graph.Nodes) == 0 {
+		fmt.Fprintln(w, "No tasks or specs found")
+		return
+	}
+
+	nodes := make(map[string]domain.GraphNode)
+	incoming := make(map[string]bool)
+	outgoing := make(map[string][]domain.GraphEdge)
+	for _, n := range graph.Nodes {
+		nodes[n.ID] = n
+	}
+	for _, e := range graph.Edges {
+		incoming[e.To] = true
+		outgoing[e.From] = append(outgoing[e.From], e)
+	}
+
+	status := func(n domain.GraphNode) string {
+		code, ok := statusANSI[n.Status]
+		if !color || !ok {
+			return "[" + n.Status + "]"
+		}
+		return fmt.Sprintf("\x1b[%sm[%s]\x1b[0m", code, n.Status)
+	}
+
+	printed := make(map[string]bool)
+	var walk func(id, indent string)
+	walk = func(id, indent string) {
+		edges := outgoing[id]
+		for i, e := range edges {
+			branch, next := "├", "│   "
+			if i == len(edges)-1 {
+				branch, next = "└", "    "
+			}
+			connector := "─▶ "
+			if e.Kind == domain.GraphEdgeSubtask {
+				connector = "── "
+			}
+			child := nodes[e.To]
+			if printed[child.ID] {
+				fmt.Fprintf(w, "%s%s%s%s  (see above)\n", indent, branch, connector, child.ID)
+				continue
+			}
+			printed[child.ID] = true
+			fmt.Fprintf(w, "%s%s%s%s  %s  %s\n", indent, branch, connector, child.ID, truncate(child.Title, 40), status(child))
+			walk(child.ID, indent+next)
+		}
+	}
+
+	for _, kind := range []domain.GraphNodeKind{domain.GraphNodeSpec, domain.GraphNodeTask} {
+		var roots []domain.GraphNode
+		for _, n := range graph.Nodes {
+			if n.Kind == kind && !incoming[n.ID] {
+				roots = append(roots, n)
+			}
+		}
+		// Nodes that are only reachable through a cycle still need printing
+		for _, n := range graph.Nodes {
+			if n.Kind == kind && incoming[n.ID] && !reachable(n.ID, roots, outgoing) {
+				roots = append(roots, n)
+			}
+		}
+		if len(roots) == 0 {
+			continue
+		}
+
+		if kind == domain.GraphNodeSpec {
+			fmt.Fprintln(w, "Specs:")
+		} else {
+			fmt.Fprintln(w, "Tasks:")
+		}
+		for _, n := range roots {
+			if printed[n.ID] {
+				continue
+			}
+			printed[n.ID] = true
+			fmt.Fprintf(w, "  %s  %s  %s\n", n.ID, truncate(n.Title, 40), status(n))
+			walk(n.ID, "  ")
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "─▶ is depended on by   ── has subtask")
+}
+
+// reachable checks if id can be reached from any of the roots
+func reachable(id string, roots []domain.GraphNode, outgoing map[string][]domain.GraphEdge) bool {
+	seen := make(map[string]bool)
+	var queue []string
+	for _, r := range roots {
+		queue = append(queue, r.ID)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == id {
+			return true
+		}
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		for _, e := range outgoing[current] {
+			queue = append(queue, e.To)
+		}
+	}
+	return false
+}
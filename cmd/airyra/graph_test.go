@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// testGraph returns a spec with two dependent tasks, one of which has a subtask
+func testGraph() *domain.Graph {
+	spec := "sp-1"
+	return &domain.Graph{
+		Nodes: []domain.GraphNode{
+			{ID: "sp-1", Kind: domain.GraphNodeSpec, Title: "Auth", Status: "active"},
+			{ID: "ar-1", Kind: domain.GraphNodeTask, Title: "Schema", Status: "done", SpecID: &spec},
+			{ID: "ar-2", Kind: domain.GraphNodeTask, Title: `Login "API"`, Status: "open", SpecID: &spec},
+			{ID: "ar-3", Kind: domain.GraphNodeTask, Title: "Endpoint", Status: "in_progress"},
+		},
+		Edges: []domain.GraphEdge{
+			{From: "ar-1", To: "ar-2", Kind: domain.GraphEdgeDependency},
+			{From: "ar-2", To: "ar-3", Kind: domain.GraphEdgeSubtask},
+		},
+	}
+}
+
+func TestGraphCmd_Flags(t *testing.T) {
+	for _, name := range []string{"format", "spec", "ancestors", "descendants"} {
+		if graphCmd.Flags().Lookup(name) == nil {
+			t.Errorf("graphCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	var buf bytes.Buffer
+	renderDOT(&buf, testGraph())
+
+	output := buf.String()
+	for _, want := range []string{
+		"digraph airyra {",
+		`"sp-1" [label="sp-1\nAuth\n[active]", shape=folder`,
+		`Login \"API\"`,
+		`fillcolor="#b7e1a1"`,
+		`"ar-1" -> "ar-2";`,
+		`"ar-2" -> "ar-3" [style=dashed`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("DOT output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	renderMermaid(&buf, testGraph())
+
+	output := buf.String()
+	for _, want := range []string{
+		"flowchart LR",
+		`ar_2["ar-2: Login #quot;API#quot;"]:::status_open`,
+		"ar_1 --> ar_2",
+		"ar_2 -.-> ar_3",
+		"classDef status_done fill:#b7e1a1",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("mermaid output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRenderASCII(t *testing.T) {
+	var buf bytes.Buffer
+	renderASCII(&buf, testGraph(), false)
+
+	output := buf.String()
+	for _, want := range []string{
+		"Specs:\n  sp-1  Auth  [active]",
+		"Tasks:\n  ar-1  Schema  [done]",
+		"  └─▶ ar-2",
+		"      └── ar-3  Endpoint  [in_progress]",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("ASCII output should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "\x1b[") {
+		t.Error("ASCII output should not be colored when color is off")
+	}
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/airyra/airyra/internal/client"
 	"github.com/airyra/airyra/internal/config"
@@ -41,9 +43,9 @@ func mapErrorToExitCode(err error) int {
 		switch domainErr.Code {
 		case domain.ErrCodeTaskNotFound:
 			return ExitTaskNotFound
-		case domain.ErrCodeAlreadyClaimed:
+		case domain.ErrCodeAlreadyClaimed, domain.ErrCodeChildrenNotDone, domain.ErrCodeWIPLimitExceeded:
 			return ExitConflict
-		case domain.ErrCodeNotOwner:
+		case domain.ErrCodeNotOwner, domain.ErrCodeSelfReview, domain.ErrCodeNotAssignee:
 			return ExitPermissionDenied
 		case domain.ErrCodeProjectNotFound:
 			return ExitProjectNotConfigured
@@ -107,6 +109,114 @@ func parsePriority(s string) (int, error) {
 	}
 }
 
+// parseEstimate parses an estimate duration (e.g. 90m, 2h, or a bare number
+// of minutes) into whole minutes
+func parseEstimate(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("estimate cannot be negative, got %d", n)
+		}
+		return n, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid estimate: %s (use a duration such as 90m or 2h)", s)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("estimate cannot be negative, got %s", s)
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
+
+// formatEstimate formats an estimate in minutes as a short duration
+func formatEstimate(minutes int) string {
+	d := time.Duration(minutes) * time.Minute
+	s := d.String()
+	s = strings.TrimSuffix(s, "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// formatSeconds formats a tracked duration in seconds to the minute
+func formatSeconds(seconds int64) string {
+	minutes := int((time.Duration(seconds)*time.Second + 30*time.Second) / time.Minute)
+	if minutes == 0 {
+		return "0m"
+	}
+	return formatEstimate(minutes)
+}
+
+// formatLastSeen formats how long ago an agent was seen
+func formatLastSeen(lastSeen, now time.Time) string {
+	if lastSeen.IsZero() {
+		return "never"
+	}
+	return formatSeconds(int64(now.Sub(lastSeen)/time.Second)) + " ago"
+}
+
+// parseDueDate parses a due date into an RFC 3339 time. It accepts an RFC 3339
+// time, a date (due at the end of that day, local time), or an offset from now
+// such as 48h or 3d. An empty string or "none" returns "", which clears it.
+func parseDueDate(s string, now time.Time) (string, error) {
+	if s == "" || strings.EqualFold(s, "none") {
+		return "", nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t.Add(24*time.Hour - time.Second).Format(time.RFC3339), nil
+	}
+
+	d, err := parseOffset(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid due date: %s (use YYYY-MM-DD, an RFC 3339 time, or an offset such as 48h or 3d)", s)
+	}
+	if d <= 0 {
+		return "", fmt.Errorf("due date offset must be positive, got %s", s)
+	}
+	return now.Add(d).Truncate(time.Second).Format(time.RFC3339), nil
+}
+
+// parseDeferUntil parses the time a task is deferred until into an RFC 3339
+// time. It accepts an RFC 3339 time or a date (the start of that day, local
+// time).
+func parseDeferUntil(s string) (string, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("invalid time: %s (use YYYY-MM-DD or an RFC 3339 time)", s)
+}
+
+// parseOffset parses a duration such as 90m or 48h, or a number of days such
+// as 3d.
+func parseOffset(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// formatDueDate formats a due date, flagging it when it has passed
+func formatDueDate(due time.Time, now time.Time) string {
+	s := due.Local().Format("2006-01-02 15:04")
+	if due.Before(now) {
+		s += " (overdue)"
+	}
+	return s
+}
+
 // pidFilePath returns the path to the PID file
 func pidFilePath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -115,3 +225,13 @@ func pidFilePath() (string, error) {
 	}
 	return homeDir + "/" + config.GlobalConfigDir + "/airyra.pid", nil
 }
+
+// isTerminal checks if w is an interactive terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/airyra/airyra/internal/client"
 	"github.com/airyra/airyra/internal/domain"
@@ -77,11 +78,26 @@ func TestMapErrorToExitCode_DomainErrors(t *testing.T) {
 			errCode:  domain.ErrCodeAlreadyClaimed,
 			expected: ExitConflict,
 		},
+		{
+			name:     "WIP limit exceeded code",
+			errCode:  domain.ErrCodeWIPLimitExceeded,
+			expected: ExitConflict,
+		},
 		{
 			name:     "not owner code",
 			errCode:  domain.ErrCodeNotOwner,
 			expected: ExitPermissionDenied,
 		},
+		{
+			name:     "self review code",
+			errCode:  domain.ErrCodeSelfReview,
+			expected: ExitPermissionDenied,
+		},
+		{
+			name:     "not assignee code",
+			errCode:  domain.ErrCodeNotAssignee,
+			expected: ExitPermissionDenied,
+		},
 		{
 			name:     "invalid transition code",
 			errCode:  domain.ErrCodeInvalidTransition,
@@ -164,6 +180,143 @@ func TestParsePriority(t *testing.T) {
 	}
 }
 
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		hasError bool
+	}{
+		{"90", 90, false},
+		{"0", 0, false},
+		{"45m", 45, false},
+		{"2h", 120, false},
+		{"1h30m", 90, false},
+		{"-1", 0, true},
+		{"-2h", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := parseEstimate(tt.input)
+			if tt.hasError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("parseEstimate(%s) = %d, expected %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	for minutes, expected := range map[int]string{45: "45m", 90: "1h30m", 120: "2h"} {
+		if result := formatEstimate(minutes); result != expected {
+			t.Errorf("formatEstimate(%d) = %q, expected %q", minutes, result, expected)
+		}
+	}
+}
+
+func TestFormatSeconds(t *testing.T) {
+	for seconds, expected := range map[int64]string{0: "0m", 20: "0m", 45: "1m", 5400: "1h30m", 90000: "25h"} {
+		if result := formatSeconds(seconds); result != expected {
+			t.Errorf("formatSeconds(%d) = %q, expected %q", seconds, result, expected)
+		}
+	}
+}
+
+func TestFormatLastSeen(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if result := formatLastSeen(now.Add(-90*time.Minute), now); result != "1h30m ago" {
+		t.Errorf("formatLastSeen() = %q, expected 1h30m ago", result)
+	}
+	if result := formatLastSeen(time.Time{}, now); result != "never" {
+		t.Errorf("formatLastSeen(zero) = %q, expected never", result)
+	}
+}
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	endOfDay := time.Date(2026, 3, 15, 23, 59, 59, 0, time.Local).Format(time.RFC3339)
+	tests := []struct {
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"", "", false},
+		{"none", "", false},
+		{"2026-03-15T09:00:00Z", "2026-03-15T09:00:00Z", false},
+		{"2026-03-15", endOfDay, false},
+		{"48h", "2026-03-03T12:00:00Z", false},
+		{"3d", "2026-03-04T12:00:00Z", false},
+		{"-1d", "", true},
+		{"soon", "", true},
+		{"xd", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := parseDueDate(tt.input, now)
+			if tt.hasError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("parseDueDate(%s) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDeferUntil(t *testing.T) {
+	startOfDay := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local).Format(time.RFC3339)
+	for input, expected := range map[string]string{
+		"2026-11-01":           startOfDay,
+		"2026-11-01T09:00:00Z": "2026-11-01T09:00:00Z",
+	} {
+		result, err := parseDeferUntil(input)
+		if err != nil {
+			t.Errorf("parseDeferUntil(%s): unexpected error: %v", input, err)
+		}
+		if result != expected {
+			t.Errorf("parseDeferUntil(%s) = %q, expected %q", input, result, expected)
+		}
+	}
+
+	for _, input := range []string{"", "2h", "tomorrow"} {
+		if _, err := parseDeferUntil(input); err == nil {
+			t.Errorf("parseDeferUntil(%q): expected error but got nil", input)
+		}
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	for input, expected := range map[string]time.Duration{"90m": 90 * time.Minute, "2h": 2 * time.Hour, "3d": 72 * time.Hour} {
+		result, err := parseOffset(input)
+		if err != nil {
+			t.Errorf("parseOffset(%s): unexpected error: %v", input, err)
+		}
+		if result != expected {
+			t.Errorf("parseOffset(%s) = %v, expected %v", input, result, expected)
+		}
+	}
+
+	if _, err := parseOffset("xd"); err == nil {
+		t.Error("parseOffset(xd): expected error but got nil")
+	}
+}
+
 func TestIsConfigNotFoundError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// hookMarker identifies hook scripts installed by airyra
+const hookMarker = "# Installed by airyra init --git-hooks"
+
+// gitHookScripts maps git hook names to the scripts installed by airyra
+var gitHookScripts = map[string]string{
+	"prepare-commit-msg": "#!/bin/sh\n" + hookMarker + "\nairyra hook prepare-commit-msg \"$@\" || true\n",
+	"post-commit":        "#!/bin/sh\n" + hookMarker + "\nairyra hook post-commit || true\n",
+}
+
+var hookCmd = &cobra.Command{
+	Use:    "hook",
+	Short:  "Git hook entry points",
+	Long:   `Entry points invoked by the git hooks installed with 'airyra init --git-hooks'.`,
+	Hidden: true,
+}
+
+var hookPrepareCommitMsgCmd = &cobra.Command{
+	Use:   "prepare-commit-msg <file> [source] [sha]",
+	Short: "Append the claimed task to a commit message",
+	Long: `Append an Airyra-Task trailer for each task claimed by the current agent
+to the commit message file. Merge commits are left untouched.`,
+	Args: cobra.RangeArgs(1, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 1 && args[1] == "merge" {
+			return
+		}
+
+		ids, err := claimedTaskIDs()
+		if err != nil {
+			// Never block a commit because the server is unreachable
+			fmt.Fprintf(os.Stderr, "airyra: %v\n", err)
+			return
+		}
+
+		for _, id := range ids {
+			if _, err := runGit("interpret-trailers", "--in-place", "--if-exists", "doNothing",
+				"--trailer", taskTrailer+": "+id, args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "airyra: %v\n", err)
+				return
+			}
+		}
+	},
+}
+
+var hookPostCommitCmd = &cobra.Command{
+	Use:   "post-commit",
+	Short: "Link the new commit to the tasks it references",
+	Long:  `Record a commit link for every task referenced by an Airyra-Task trailer in HEAD.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := linkCommitTasks("HEAD"); err != nil {
+			fmt.Fprintf(os.Stderr, "airyra: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hookCmd)
+
+	hookCmd.AddCommand(hookPrepareCommitMsgCmd)
+	hookCmd.AddCommand(hookPostCommitCmd)
+}
+
+// claimedTaskIDs returns the IDs of the in-progress tasks claimed by the current agent
+func claimedTaskIDs() ([]string, error) {
+	c, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for page := 1; ; page++ {
+		result, err := c.ListMyTasks(context.Background(), string(domain.StatusInProgress), page, 100)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range result.Data {
+			ids = append(ids, task.ID)
+		}
+		if page >= result.Pagination.TotalPages {
+			return ids, nil
+		}
+	}
+}
+
+// linkCommitTasks links a commit to every task named in its task trailers
+func linkCommitTasks(rev string) error {
+	ids, err := commitTaskIDs(rev)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	sha, err := resolveCommit(rev)
+	if err != nil {
+		return err
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := c.AddLink(context.Background(), id, domain.LinkTypeCommit, sha); err != nil {
+			return fmt.Errorf("failed to link %s to %s: %w", sha, id, err)
+		}
+	}
+	return nil
+}
+
+// installGitHooks installs the airyra git hooks into the current repository.
+// Existing hooks that were not installed by airyra are left untouched.
+func installGitHooks() error {
+	dir, err := gitHooksDir()
+	if err != nil {
+		return fmt.Errorf("not a git repository: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	// Check every hook before writing any, so a conflict leaves nothing half-installed
+	for name := range gitHookScripts {
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), hookMarker) {
+			return fmt.Errorf("%s hook already exists at %s; add 'airyra hook %s' to it manually", name, path, name)
+		}
+	}
+
+	for name, script := range gitHookScripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			return fmt.Errorf("failed to write %s hook: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -14,17 +14,24 @@ var initCmd = &cobra.Command{
 	Long: `Create an airyra.toml configuration file in the current directory.
 
 The project name is used to identify this project when communicating with
-the airyra server.`,
+the airyra server.
+
+With --git-hooks, git hooks are installed in the current repository: the
+prepare-commit-msg hook appends an Airyra-Task trailer for each task you
+have claimed, and the post-commit hook links the new commit to those tasks.
+If airyra.toml already exists, only the hooks are installed.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		host, _ := cmd.Flags().GetString("host")
 		port, _ := cmd.Flags().GetInt("port")
+		gitHooks, _ := cmd.Flags().GetBool("git-hooks")
 
-		if err := runInit(args[0], host, port); err != nil {
+		message, err := initProject(args[0], host, port, gitHooks)
+		if err != nil {
 			handleError(err)
 		}
 
-		printSuccess(os.Stdout, fmt.Sprintf("Created %s for project '%s'", config.ConfigFileName, args[0]), jsonOutput)
+		printSuccess(os.Stdout, message, jsonOutput)
 	},
 }
 
@@ -33,20 +40,65 @@ func init() {
 
 	initCmd.Flags().String("host", "", "Server host")
 	initCmd.Flags().Int("port", 0, "Server port")
+	initCmd.Flags().Bool("git-hooks", false, "Install git hooks that link commits to claimed tasks")
 }
 
-// runInit creates the airyra.toml configuration file
-func runInit(name, host string, port int) error {
+// configExists reports whether airyra.toml exists in the current directory
+func configExists() bool {
+	_, err := os.Stat(config.ConfigFileName)
+	return err == nil
+}
+
+// initProject creates airyra.toml and, with gitHooks, installs the git hooks.
+// Hooks are installed before the config is written, so a hook conflict leaves
+// the directory uninitialised. It returns the message to report.
+func initProject(name, host string, port int, gitHooks bool) (string, error) {
+	if gitHooks && configExists() {
+		if err := installGitHooks(); err != nil {
+			return "", err
+		}
+		return "Installed git hooks", nil
+	}
+
+	if err := checkInit(name); err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf("Created %s for project '%s'", config.ConfigFileName, name)
+	if gitHooks {
+		if err := installGitHooks(); err != nil {
+			return "", err
+		}
+		message += " and installed git hooks"
+	}
+
+	if err := runInit(name, host, port); err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// checkInit reports why a project cannot be initialised in the current directory
+func checkInit(name string) error {
 	if name == "" {
 		return fmt.Errorf("project name is required")
 	}
 
 	// Check if config already exists
-	configPath := config.ConfigFileName
-	if _, err := os.Stat(configPath); err == nil {
+	if configExists() {
 		return fmt.Errorf("%s already exists in this directory", config.ConfigFileName)
 	}
 
+	return nil
+}
+
+// runInit creates the airyra.toml configuration file
+func runInit(name, host string, port int) error {
+	if err := checkInit(name); err != nil {
+		return err
+	}
+	configPath := config.ConfigFileName
+
 	// Build config content
 	content := fmt.Sprintf("project = %q\n", name)
 
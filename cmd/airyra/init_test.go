@@ -1,12 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/airyra/airyra/internal/config"
+	"github.com/airyra/airyra/internal/domain"
 )
 
 func TestInit_CreatesConfig(t *testing.T) {
@@ -222,3 +228,144 @@ func TestInitCmd_HasPortFlag(t *testing.T) {
 		t.Error("initCmd should have --port flag")
 	}
 }
+
+func TestInitCmd_HasGitHooksFlag(t *testing.T) {
+	flag := initCmd.Flags().Lookup("git-hooks")
+	if flag == nil {
+		t.Error("initCmd should have --git-hooks flag")
+	}
+}
+
+func TestInstallGitHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	if _, err := runGit("init", "--quiet"); err != nil {
+		t.Fatalf("git init failed: %v", err)
+	}
+
+	if err := installGitHooks(); err != nil {
+		t.Fatalf("installGitHooks failed: %v", err)
+	}
+
+	for name := range gitHookScripts {
+		content, err := os.ReadFile(filepath.Join(tmpDir, ".git", "hooks", name))
+		if err != nil {
+			t.Fatalf("%s hook was not installed: %v", name, err)
+		}
+		if !strings.Contains(string(content), "airyra hook "+name) {
+			t.Errorf("%s hook should invoke 'airyra hook %s'", name, name)
+		}
+	}
+
+	// Reinstalling over our own hooks succeeds
+	if err := installGitHooks(); err != nil {
+		t.Errorf("reinstalling hooks should succeed, got: %v", err)
+	}
+}
+
+func TestInstallGitHooks_KeepsForeignHook(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	if _, err := runGit("init", "--quiet"); err != nil {
+		t.Fatalf("git init failed: %v", err)
+	}
+
+	hookPath := filepath.Join(tmpDir, ".git", "hooks", "post-commit")
+	os.MkdirAll(filepath.Dir(hookPath), 0755)
+	os.WriteFile(hookPath, []byte("#!/bin/sh\necho custom\n"), 0755)
+
+	if err := installGitHooks(); err == nil {
+		t.Error("installGitHooks should refuse to overwrite a foreign hook")
+	}
+
+	content, _ := os.ReadFile(hookPath)
+	if string(content) != "#!/bin/sh\necho custom\n" {
+		t.Error("foreign hook should be left untouched")
+	}
+
+	// A conflict on one hook installs none of them
+	if _, err := os.Stat(filepath.Join(tmpDir, ".git", "hooks", "prepare-commit-msg")); !os.IsNotExist(err) {
+		t.Error("no hook should be written when another hook conflicts")
+	}
+}
+
+func TestInitProject_HookConflictLeavesNoConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	if _, err := runGit("init", "--quiet"); err != nil {
+		t.Fatalf("git init failed: %v", err)
+	}
+
+	hookPath := filepath.Join(tmpDir, ".git", "hooks", "post-commit")
+	os.MkdirAll(filepath.Dir(hookPath), 0755)
+	os.WriteFile(hookPath, []byte("#!/bin/sh\necho custom\n"), 0755)
+
+	if _, err := initProject("myproject", "", 0, true); err == nil {
+		t.Fatal("initProject should fail when a hook conflicts")
+	}
+
+	if configExists() {
+		t.Error("config should not be written when hooks cannot be installed")
+	}
+}
+
+func TestClaimedTaskIDs_PagesThroughMyTasks(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("claimed_by") != "me" || query.Get("status") != string(domain.StatusInProgress) {
+			t.Errorf("expected claimed_by=me&status=in_progress, got %s", r.URL.RawQuery)
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []domain.Task{{ID: fmt.Sprintf("ar-%d", page)}},
+			"pagination": map[string]interface{}{
+				"page": page, "per_page": 1, "total": 2, "total_pages": 2,
+			},
+		})
+	})
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	host, port := parseURL(server.URL)
+	if err := runInit("testproject", host, port); err != nil {
+		t.Fatalf("runInit failed: %v", err)
+	}
+
+	ids, err := claimedTaskIDs()
+	if err != nil {
+		t.Fatalf("claimedTaskIDs failed: %v", err)
+	}
+	if strings.Join(ids, ",") != "ar-1,ar-2" {
+		t.Errorf("expected tasks from every page, got %v", ids)
+	}
+}
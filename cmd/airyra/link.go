@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link <id>",
+	Short: "Link a task to commits, branches, pull requests or files",
+	Long: `Link a task to the artifacts that implement it.
+
+Commits and branches are resolved against the local git repository, so
+revisions such as HEAD or HEAD~1 are stored as full commit SHAs and
+--branch HEAD stores the current branch name. File paths are stored
+relative to the repository root.
+
+Without any link flags, the task's existing links are listed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID := args[0]
+
+		links, err := linksFromFlags(cmd)
+		if err != nil {
+			handleError(err)
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		if len(links) == 0 {
+			existing, err := c.ListLinks(context.Background(), taskID)
+			if err != nil {
+				handleError(err)
+			}
+			printLinks(os.Stdout, taskID, existing, jsonOutput)
+			return
+		}
+
+		var added []domain.TaskLink
+		for _, l := range links {
+			link, err := c.AddLink(context.Background(), taskID, l.Type, l.Value)
+			if err != nil {
+				handleError(err)
+			}
+			added = append(added, *link)
+		}
+
+		printLinks(os.Stdout, taskID, added, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+
+	linkCmd.Flags().String("commit", "", "Commit to link (any git revision, e.g. HEAD)")
+	linkCmd.Flags().String("branch", "", "Branch to link (HEAD for the current branch)")
+	linkCmd.Flags().String("pr", "", "Pull request URL to link")
+	linkCmd.Flags().String("file", "", "File path to link")
+}
+
+// linksFromFlags builds the links requested on the command line, resolving
+// git revisions and paths against the local repository
+func linksFromFlags(cmd *cobra.Command) ([]domain.TaskLink, error) {
+	var links []domain.TaskLink
+
+	if cmd.Flags().Changed("commit") {
+		rev, _ := cmd.Flags().GetString("commit")
+		sha, err := resolveCommit(rev)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve commit %q: %w", rev, err)
+		}
+		links = append(links, domain.TaskLink{Type: domain.LinkTypeCommit, Value: sha})
+	}
+
+	if cmd.Flags().Changed("branch") {
+		name, _ := cmd.Flags().GetString("branch")
+		branch, err := resolveBranch(name)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, domain.TaskLink{Type: domain.LinkTypeBranch, Value: branch})
+	}
+
+	if cmd.Flags().Changed("pr") {
+		url, _ := cmd.Flags().GetString("pr")
+		if url == "" {
+			return nil, fmt.Errorf("--pr requires a URL")
+		}
+		links = append(links, domain.TaskLink{Type: domain.LinkTypePR, Value: url})
+	}
+
+	if cmd.Flags().Changed("file") {
+		path, _ := cmd.Flags().GetString("file")
+		rel, err := resolveRepoPath(path)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, domain.TaskLink{Type: domain.LinkTypeFile, Value: rel})
+	}
+
+	return links, nil
+}
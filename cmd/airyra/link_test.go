@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/airyra/airyra/internal/domain"
+)
+
+func TestLinkCmd_Exists(t *testing.T) {
+	if linkCmd == nil {
+		t.Error("linkCmd should not be nil")
+	}
+}
+
+func TestLinkCmd_Use(t *testing.T) {
+	if linkCmd.Use != "link <id>" {
+		t.Errorf("linkCmd.Use = %s, expected 'link <id>'", linkCmd.Use)
+	}
+}
+
+func TestLinkCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"commit", "branch", "pr", "file"} {
+		if linkCmd.Flags().Lookup(name) == nil {
+			t.Errorf("linkCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestLink_AddCommit(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/testproject/tasks/abc123/links" && r.Method == "POST" {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["type"] != "commit" || body["value"] != sha {
+				t.Errorf("unexpected link body: %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(domain.TaskLink{
+				ID:        1,
+				TaskID:    "abc123",
+				Type:      domain.LinkTypeCommit,
+				Value:     sha,
+				CreatedAt: time.Now(),
+				CreatedBy: "test@host:/path",
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	host, port := parseURL(server.URL)
+	c := client.NewClient(host, port, "testproject", "test@host:/path")
+
+	link, err := c.AddLink(context.Background(), "abc123", domain.LinkTypeCommit, sha)
+	if err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+
+	if link.Value != sha {
+		t.Errorf("Expected value %s, got %s", sha, link.Value)
+	}
+}
+
+func TestLink_List(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/testproject/tasks/abc123/links" && r.Method == "GET" {
+			json.NewEncoder(w).Encode([]domain.TaskLink{
+				{ID: 1, TaskID: "abc123", Type: domain.LinkTypeBranch, Value: "feature/x"},
+				{ID: 2, TaskID: "abc123", Type: domain.LinkTypePR, Value: "https://example.com/pr/1"},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	host, port := parseURL(server.URL)
+	c := client.NewClient(host, port, "testproject", "test@host:/path")
+
+	links, err := c.ListLinks(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("ListLinks failed: %v", err)
+	}
+
+	if len(links) != 2 {
+		t.Errorf("Expected 2 links, got %d", len(links))
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var mineCmd = &cobra.Command{
+	Use:   "mine",
+	Short: "List the tasks this agent holds",
+	Long: `List the tasks claimed by this agent. Only the tasks in progress are shown
+unless --status or --all is given.
+
+Claims beyond the project's WIP limits are refused; see 'airyra settings' for
+agent_wip_limit and project_wip_limit.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		status, _ := cmd.Flags().GetString("status")
+		all, _ := cmd.Flags().GetBool("all")
+		page, _ := cmd.Flags().GetInt("page")
+		perPage, _ := cmd.Flags().GetInt("per-page")
+
+		if status == "" && !all {
+			status = string(domain.StatusInProgress)
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		result, err := c.ListMyTasks(context.Background(), status, page, perPage)
+		if err != nil {
+			handleError(err)
+		}
+
+		printTaskList(os.Stdout, result.Data, result.Pagination, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mineCmd)
+
+	mineCmd.Flags().String("status", "", "Filter by status instead of in_progress")
+	mineCmd.Flags().Bool("all", false, "Include the tasks claimed by this agent in any status")
+	mineCmd.Flags().Int("page", 1, "Page number")
+	mineCmd.Flags().Int("per-page", 50, "Items per page")
+}
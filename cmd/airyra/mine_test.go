@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMineCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "mine" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have mine subcommand")
+	}
+}
+
+func TestMineCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"status", "all", "page", "per-page"} {
+		if mineCmd.Flags().Lookup(name) == nil {
+			t.Errorf("mineCmd should have --%s flag", name)
+		}
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/airyra/airyra/internal/client"
 	"github.com/airyra/airyra/internal/domain"
@@ -25,24 +28,174 @@ func printTask(w io.Writer, task *domain.Task, jsonOutput bool) {
 	fmt.Fprintf(tw, "ID:\t%s\n", task.ID)
 	fmt.Fprintf(tw, "Title:\t%s\n", task.Title)
 	fmt.Fprintf(tw, "Status:\t%s\n", task.Status)
-	fmt.Fprintf(tw, "Priority:\t%s\n", priorityString(task.Priority))
+	fmt.Fprintf(tw, "Priority:\t%s\n", taskPriorityString(task))
+	if task.Estimate != nil {
+		fmt.Fprintf(tw, "Estimate:\t%s\n", formatEstimate(*task.Estimate))
+	}
+	if task.DueAt != nil {
+		fmt.Fprintf(tw, "Due:\t%s\n", formatDueDate(*task.DueAt, time.Now()))
+	}
+	if task.DeferUntil != nil && task.DeferUntil.After(time.Now()) {
+		fmt.Fprintf(tw, "Deferred Until:\t%s\n", task.DeferUntil.Local().Format("2006-01-02 15:04"))
+	}
+	if task.DeferEvent != nil {
+		fmt.Fprintf(tw, "Waiting For Event:\t%s\n", *task.DeferEvent)
+	}
 	if task.Description != nil && *task.Description != "" {
 		fmt.Fprintf(tw, "Description:\t%s\n", *task.Description)
 	}
 	if task.ParentID != nil && *task.ParentID != "" {
 		fmt.Fprintf(tw, "Parent:\t%s\n", *task.ParentID)
 	}
+	if task.Assignee != nil {
+		fmt.Fprintf(tw, "Assignee:\t%s\n", *task.Assignee)
+	}
 	if task.ClaimedBy != nil && *task.ClaimedBy != "" {
 		fmt.Fprintf(tw, "Claimed By:\t%s\n", *task.ClaimedBy)
 	}
 	if task.ClaimedAt != nil {
 		fmt.Fprintf(tw, "Claimed At:\t%s\n", task.ClaimedAt.Format("2006-01-02 15:04:05"))
 	}
+	if task.BlockReason != nil {
+		fmt.Fprintf(tw, "Block Reason:\t%s\n", *task.BlockReason)
+	}
+	if task.BlockedBy != nil {
+		blockedBy := fmt.Sprintf("%s (%s)", *task.BlockedBy, domain.ClassifyBlocker(*task.BlockedBy))
+		if task.AutoUnblock {
+			blockedBy += ", unblocks when done"
+		}
+		fmt.Fprintf(tw, "Blocked By:\t%s\n", blockedBy)
+	}
+	if task.QuarantinedAt != nil {
+		fmt.Fprintf(tw, "Quarantined:\t%s\n", task.QuarantinedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if task.Attempts > 0 {
+		fmt.Fprintf(tw, "Failed Attempts:\t%d\n", task.Attempts)
+	}
+	if len(task.WaitingOnSpecs) > 0 {
+		fmt.Fprintf(tw, "Not Ready:\tspec %s waits on unfinished spec %s\n",
+			*task.SpecID, strings.Join(task.WaitingOnSpecs, ", "))
+	}
 	fmt.Fprintf(tw, "Created:\t%s\n", task.CreatedAt.Format("2006-01-02 15:04:05"))
 	fmt.Fprintf(tw, "Updated:\t%s\n", task.UpdatedAt.Format("2006-01-02 15:04:05"))
 	tw.Flush()
 }
 
+// printTaskTree prints a task followed by its subtask tree
+func printTaskTree(w io.Writer, tree *domain.TaskTree, jsonOutput bool) {
+	if jsonOutput {
+		if tree.Children == nil {
+			tree.Children = []*domain.TaskTree{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(tree)
+		return
+	}
+
+	printTask(w, tree.Task, false)
+	if len(tree.Children) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Subtasks:")
+		printSubtasks(w, tree.Children, "  ")
+	}
+}
+
+// printSubtasks prints subtasks as an indented tree
+func printSubtasks(w io.Writer, children []*domain.TaskTree, indent string) {
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%s%s%s  %s  [%s]\n", indent, branch, child.ID, truncate(child.Title, 40), statusString(child.Task))
+		printSubtasks(w, child.Children, indent+next)
+	}
+}
+
+// printTaskWithComments prints a task and its subtask tree followed by its comment thread
+func printTaskWithComments(w io.Writer, tree *domain.TaskTree, comments []domain.Comment, jsonOutput bool) {
+	if jsonOutput {
+		if comments == nil {
+			comments = []domain.Comment{}
+		}
+		if tree.Children == nil {
+			tree.Children = []*domain.TaskTree{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(struct {
+			*domain.TaskTree
+			Comments []domain.Comment `json:"comments"`
+		}{tree, comments})
+		return
+	}
+
+	printTaskTree(w, tree, false)
+	fmt.Fprintln(w)
+	printComments(w, tree.ID, comments, false)
+}
+
+// printTaskWithTimeline prints a task and its subtask tree, its comment thread
+// when comments is set, and its timeline
+func printTaskWithTimeline(w io.Writer, tree *domain.TaskTree, comments *[]domain.Comment, timeline *domain.TaskTimeline, jsonOutput bool) {
+	if jsonOutput {
+		if comments != nil && *comments == nil {
+			comments = &[]domain.Comment{}
+		}
+		if tree.Children == nil {
+			tree.Children = []*domain.TaskTree{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(struct {
+			*domain.TaskTree
+			Comments *[]domain.Comment    `json:"comments,omitempty"`
+			Timeline *domain.TaskTimeline `json:"timeline"`
+		}{tree, comments, timeline})
+		return
+	}
+
+	if comments != nil {
+		printTaskWithComments(w, tree, *comments, false)
+	} else {
+		printTaskTree(w, tree, false)
+	}
+	fmt.Fprintln(w)
+	printTimeline(w, timeline)
+}
+
+// printTimeline prints the time a task spent in each status
+func printTimeline(w io.Writer, timeline *domain.TaskTimeline) {
+	fmt.Fprintln(w, "Timeline:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  STATUS\tSINCE\tDURATION\tBY\n")
+	fmt.Fprintf(tw, "  ------\t-----\t--------\t--\n")
+	for _, period := range timeline.Periods {
+		duration := formatSeconds(period.Seconds)
+		if period.End == nil {
+			duration += " (current)"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", period.Status,
+			period.Start.Local().Format("2006-01-02 15:04"), duration, truncate(period.Agent, 30))
+	}
+	tw.Flush()
+
+	worked := formatSeconds(timeline.WorkedSeconds)
+	if timeline.Estimate != nil {
+		worked += " of " + formatEstimate(*timeline.Estimate) + " estimated"
+	}
+	fmt.Fprintf(w, "\nWorked: %s, blocked: %s, claims: %d, releases: %d",
+		worked, formatSeconds(timeline.BlockedSeconds), timeline.Claims, timeline.Releases)
+	if timeline.Handoffs > 0 {
+		fmt.Fprintf(w, ", handoffs: %d", timeline.Handoffs)
+	}
+	fmt.Fprintln(w)
+	if timeline.CycleSeconds != nil {
+		fmt.Fprintf(w, "Cycle time (first claim to done): %s\n", formatSeconds(*timeline.CycleSeconds))
+	}
+}
+
 // printTaskList prints a list of tasks with pagination info
 func printTaskList(w io.Writer, tasks []*domain.Task, pagination *client.Pagination, jsonOutput bool) {
 	if jsonOutput {
@@ -71,7 +224,7 @@ func printTaskList(w io.Writer, tasks []*domain.Task, pagination *client.Paginat
 	fmt.Fprintf(tw, "--\t-----\t------\t--------\n")
 	for _, task := range tasks {
 		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
-			task.ID, truncate(task.Title, 40), task.Status, priorityString(task.Priority))
+			task.ID, truncate(task.Title, 40), statusString(task), taskPriorityString(task))
 	}
 	tw.Flush()
 
@@ -101,10 +254,319 @@ func printDependencies(w io.Writer, taskID string, deps []domain.Dependency, jso
 	fmt.Fprintf(tw, "----\t-------\n")
 	for _, dep := range deps {
 		if dep.ChildID == taskID {
-			fmt.Fprintf(tw, "depends on\t%s\n", dep.ParentID)
+			fmt.Fprintf(tw, "depends on\t%s\n", dep.ParentRef())
 		} else if dep.ParentID == taskID {
 			fmt.Fprintf(tw, "blocks\t%s\n", dep.ChildID)
+		} else {
+			fmt.Fprintf(tw, "depends on\t%s (via %s)\n", dep.ParentRef(), dep.ChildID)
+		}
+	}
+	tw.Flush()
+}
+
+// printDependents prints the tasks that depend on a task, directly or
+// through other tasks
+func printDependents(w io.Writer, taskID string, deps []domain.Dependency, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(deps)
+		return
+	}
+
+	if len(deps) == 0 {
+		fmt.Fprintf(w, "No tasks depend on %s\n", taskID)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "TYPE\tTASK ID\n")
+	fmt.Fprintf(tw, "----\t-------\n")
+	for _, dep := range deps {
+		if dep.ParentID == taskID {
+			fmt.Fprintf(tw, "blocks\t%s\n", dep.ChildID)
+		} else {
+			fmt.Fprintf(tw, "blocks\t%s (via %s)\n", dep.ChildID, dep.ParentID)
+		}
+	}
+	tw.Flush()
+}
+
+// printLinks prints the links of a task
+func printLinks(w io.Writer, taskID string, links []domain.TaskLink, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(links)
+		return
+	}
+
+	if len(links) == 0 {
+		fmt.Fprintf(w, "Task %s has no links\n", taskID)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "TYPE\tVALUE\tBY\n")
+	fmt.Fprintf(tw, "----\t-----\t--\n")
+	for _, link := range links {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", link.Type, link.Value, truncate(link.CreatedBy, 30))
+	}
+	tw.Flush()
+}
+
+// printComments prints the comments on a task, oldest first
+func printComments(w io.Writer, taskID string, comments []domain.Comment, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(comments)
+		return
+	}
+
+	if len(comments) == 0 {
+		fmt.Fprintf(w, "Task %s has no comments\n", taskID)
+		return
+	}
+
+	fmt.Fprintln(w, "Comments:")
+	for _, comment := range comments {
+		fmt.Fprintf(w, "  %s  %s\n", comment.CreatedAt.Format("2006-01-02 15:04:05"), comment.Author)
+		for _, line := range strings.Split(comment.Body, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+// printWorkflow prints the states and transitions of a project workflow
+func printWorkflow(w io.Writer, workflow *domain.Workflow, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(workflow)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "STATE\tKIND\tTRANSITIONS\n")
+	fmt.Fprintf(tw, "-----\t----\t-----------\n")
+	for _, state := range workflow.States {
+		kind := ""
+		switch {
+		case state.Done:
+			kind = "done"
+		case state.Claimable:
+			kind = "claimable"
 		}
+
+		var targets []string
+		for _, t := range workflow.Transitions {
+			if t.From == state.Name {
+				targets = append(targets, string(t.To))
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", state.Name, kind, strings.Join(targets, ", "))
+	}
+	tw.Flush()
+}
+
+// printSettings prints the project settings
+func printSettings(w io.Writer, settings *domain.ProjectSettings, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(settings)
+		return
+	}
+
+	values := settingsMap(settings)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "SETTING\tVALUE\n")
+	fmt.Fprintf(tw, "-------\t-----\n")
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s\t%s\n", key, values[key])
+	}
+	tw.Flush()
+}
+
+// settingsMap returns the project settings keyed by their JSON names
+func settingsMap(settings *domain.ProjectSettings) map[string]json.RawMessage {
+	data, _ := json.Marshal(settings)
+	var values map[string]json.RawMessage
+	json.Unmarshal(data, &values)
+	return values
+}
+
+// printGraph prints the project graph in the given format
+func printGraph(w io.Writer, graph *domain.Graph, format string, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(graph)
+		return
+	}
+
+	switch format {
+	case "dot":
+		renderDOT(w, graph)
+	case "mermaid":
+		renderMermaid(w, graph)
+	default:
+		renderASCII(w, graph, isTerminal(w))
+	}
+}
+
+// printAnalysis prints the critical path, blocking fan-out and parallelism
+func printAnalysis(w io.Writer, analysis *domain.ScheduleAnalysis, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(analysis)
+		return
+	}
+
+	if analysis.Remaining == 0 {
+		fmt.Fprintln(w, "No unfinished tasks")
+		return
+	}
+
+	fmt.Fprintf(w, "Remaining: %d tasks, %d can be worked on in parallel\n", analysis.Remaining, analysis.Parallelism)
+	fmt.Fprintln(w)
+
+	length := fmt.Sprintf("%d tasks", len(analysis.CriticalPath))
+	if analysis.PathEstimate > 0 {
+		length += ", ~" + formatEstimate(analysis.PathEstimate)
+	}
+	fmt.Fprintf(w, "Critical path (%s):\n", length)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, task := range analysis.CriticalPath {
+		estimate := "-"
+		if task.Estimate != nil {
+			estimate = formatEstimate(*task.Estimate)
+		}
+		fmt.Fprintf(tw, "  %d.\t%s\t%s\t%s\t%s\n", i+1, task.ID, truncate(task.Title, 40), task.Status, estimate)
+	}
+	tw.Flush()
+
+	if len(analysis.BlockingFanOut) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Blocking fan-out:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  ID\tTITLE\tSTATUS\tDIRECT\tDOWNSTREAM\n")
+	fmt.Fprintf(tw, "  --\t-----\t------\t------\t----------\n")
+	for _, f := range analysis.BlockingFanOut {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%d\t%d\n", f.ID, truncate(f.Title, 40), f.Status, f.Direct, f.Downstream)
+	}
+	tw.Flush()
+}
+
+// burndownWidth is the length of the longest bar of a burndown chart
+const burndownWidth = 40
+
+// printSpecProgress prints a spec progress report with a burndown chart of
+// the last days (all days when days is 0)
+func printSpecProgress(w io.Writer, progress *domain.SpecProgress, days int, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(progress)
+		return
+	}
+
+	fmt.Fprintf(w, "Spec %s: %d/%d tasks done (%.0f%%)\n", progress.SpecID, progress.Done, progress.Total, progress.Percent)
+
+	statuses := make([]string, 0, len(progress.ByStatus))
+	for status := range progress.ByStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	counts := make([]string, len(statuses))
+	for i, status := range statuses {
+		counts[i] = fmt.Sprintf("%s %d", status, progress.ByStatus[status])
+	}
+	if len(counts) > 0 {
+		fmt.Fprintf(w, "By status: %s\n", strings.Join(counts, ", "))
+	}
+
+	switch {
+	case progress.Total > 0 && progress.Remaining == 0:
+		fmt.Fprintf(w, "Velocity: %.2f tasks/day, all tasks done\n", progress.Velocity)
+	case progress.EstimatedCompletion != nil:
+		fmt.Fprintf(w, "Velocity: %.2f tasks/day, estimated completion %s\n", progress.Velocity, *progress.EstimatedCompletion)
+	default:
+		fmt.Fprintln(w, "Velocity: no tasks completed yet")
+	}
+
+	points := progress.Burndown
+	if days > 0 && len(points) > days {
+		points = points[len(points)-days:]
+	}
+	if len(points) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Burndown (unfinished tasks):")
+	renderBurndown(w, points, progress.Total)
+}
+
+// renderBurndown draws one bar per day, scaled so that total fills the width
+func renderBurndown(w io.Writer, points []domain.BurndownPoint, total int) {
+	scale := total
+	for _, p := range points {
+		if p.Remaining > scale {
+			scale = p.Remaining
+		}
+	}
+	for _, p := range points {
+		bar := 0
+		if scale > 0 && p.Remaining > 0 {
+			bar = (p.Remaining*burndownWidth + scale - 1) / scale
+		}
+		fmt.Fprintf(w, "  %s | %-*s %d\n", p.Date, burndownWidth, strings.Repeat("#", bar), p.Remaining)
+	}
+}
+
+// printTrash prints the deleted tasks and specs
+func printTrash(w io.Writer, trash *client.Trash, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(trash)
+		return
+	}
+
+	if len(trash.Tasks) == 0 && len(trash.Specs) == 0 {
+		fmt.Fprintln(w, "Trash is empty")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tTYPE\tTITLE\tDELETED\n")
+	fmt.Fprintf(tw, "--\t----\t-----\t-------\n")
+	for _, task := range trash.Tasks {
+		deleted := ""
+		if task.DeletedAt != nil {
+			deleted = task.DeletedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(tw, "%s\ttask\t%s\t%s\n", task.ID, truncate(task.Title, 40), deleted)
+	}
+	for _, spec := range trash.Specs {
+		deleted := ""
+		if spec.DeletedAt != nil {
+			if t, err := time.Parse(time.RFC3339, *spec.DeletedAt); err == nil {
+				deleted = t.Format("2006-01-02 15:04:05")
+			}
+		}
+		fmt.Fprintf(tw, "%s\tspec\t%s\t%s\n", spec.ID, truncate(spec.Title, 40), deleted)
 	}
 	tw.Flush()
 }
@@ -181,6 +643,15 @@ func printSuccess(w io.Writer, message string, jsonOutput bool) {
 }
 
 // priorityString converts a priority int to a human-readable string
+// taskPriorityString formats a task's priority, noting a more urgent
+// priority inherited from the tasks that depend on it.
+func taskPriorityString(task *domain.Task) string {
+	if task.EffectivePriority != nil && *task.EffectivePriority < task.Priority {
+		return fmt.Sprintf("%s (inherits %s)", priorityString(task.Priority), priorityString(*task.EffectivePriority))
+	}
+	return priorityString(task.Priority)
+}
+
 func priorityString(priority int) string {
 	switch priority {
 	case 0:
@@ -198,6 +669,25 @@ func priorityString(priority int) string {
 	}
 }
 
+// statusString describes a task's status for list output, including
+// whether it is deferred and what a blocked task is waiting on
+func statusString(task *domain.Task) string {
+	if task.IsDeferred(time.Now()) {
+		return string(task.Status) + " (deferred)"
+	}
+	if task.Status != domain.StatusBlocked {
+		return string(task.Status)
+	}
+	switch {
+	case task.BlockedBy != nil:
+		return "blocked by " + truncate(*task.BlockedBy, 30)
+	case task.BlockReason != nil:
+		return "blocked: " + truncate(*task.BlockReason, 30)
+	default:
+		return string(task.Status)
+	}
+}
+
 // truncate truncates a string to the specified length
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -224,11 +714,31 @@ func printSpec(w io.Writer, spec *client.Spec, jsonOutput bool) {
 		fmt.Fprintf(tw, "Description:\t%s\n", *spec.Description)
 	}
 	fmt.Fprintf(tw, "Tasks:\t%d/%d done\n", spec.DoneCount, spec.TaskCount)
+	if spec.DueAt != nil {
+		if due, err := time.Parse(time.RFC3339, *spec.DueAt); err == nil {
+			fmt.Fprintf(tw, "Due:\t%s\n", formatDueDate(due, time.Now()))
+		}
+	}
 	fmt.Fprintf(tw, "Created:\t%s\n", spec.CreatedAt)
 	fmt.Fprintf(tw, "Updated:\t%s\n", spec.UpdatedAt)
 	tw.Flush()
 }
 
+// printSpecInstance prints a spec created from a template and its tasks
+func printSpecInstance(w io.Writer, instance *client.SpecInstance, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(instance)
+		return
+	}
+
+	printSpec(w, instance.Spec, false)
+	fmt.Fprintln(w)
+	total := len(instance.Tasks)
+	printTaskList(w, instance.Tasks, &client.Pagination{Page: 1, PerPage: total, Total: total, TotalPages: 1}, false)
+}
+
 // printSpecList prints a list of specs with pagination info
 func printSpecList(w io.Writer, specs []*client.Spec, pagination *client.Pagination, jsonOutput bool) {
 	if jsonOutput {
@@ -295,3 +805,279 @@ func printSpecDependencies(w io.Writer, specID string, deps []client.SpecDepende
 	}
 	tw.Flush()
 }
+
+// printSchedule prints a schedule
+func printSchedule(w io.Writer, schedule *domain.Schedule, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(schedule)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", schedule.ID)
+	fmt.Fprintf(tw, "Cron:\t%s\n", schedule.Cron)
+	fmt.Fprintf(tw, "Title:\t%s\n", schedule.Title)
+	fmt.Fprintf(tw, "Priority:\t%s\n", priorityString(schedule.Priority))
+	if schedule.Estimate != nil {
+		fmt.Fprintf(tw, "Estimate:\t%s\n", formatEstimate(*schedule.Estimate))
+	}
+	if schedule.Description != nil && *schedule.Description != "" {
+		fmt.Fprintf(tw, "Description:\t%s\n", *schedule.Description)
+	}
+	if schedule.SpecID != nil {
+		fmt.Fprintf(tw, "Spec:\t%s\n", *schedule.SpecID)
+	}
+	fmt.Fprintf(tw, "Catch Up:\t%s\n", schedule.CatchUp)
+	fmt.Fprintf(tw, "Next Run:\t%s\n", schedule.NextRunAt.Local().Format("2006-01-02 15:04"))
+	if schedule.LastRunAt != nil {
+		fmt.Fprintf(tw, "Last Run:\t%s\n", schedule.LastRunAt.Local().Format("2006-01-02 15:04"))
+	}
+	if schedule.LastTaskID != nil {
+		fmt.Fprintf(tw, "Last Task:\t%s\n", *schedule.LastTaskID)
+	}
+	tw.Flush()
+}
+
+// printScheduleList prints schedules, soonest first
+func printScheduleList(w io.Writer, schedules []*domain.Schedule, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(schedules)
+		return
+	}
+
+	if len(schedules) == 0 {
+		fmt.Fprintln(w, "No schedules found")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tCRON\tNEXT RUN\tLAST TASK\tTITLE\n")
+	fmt.Fprintf(tw, "--\t----\t--------\t---------\t-----\n")
+	for _, schedule := range schedules {
+		lastTask := "-"
+		if schedule.LastTaskID != nil {
+			lastTask = *schedule.LastTaskID
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", schedule.ID, schedule.Cron,
+			schedule.NextRunAt.Local().Format("2006-01-02 15:04"), lastTask, truncate(schedule.Title, 40))
+	}
+	tw.Flush()
+}
+
+// printSignalled prints the tasks that a signalled event returned to the ready queue
+func printSignalled(w io.Writer, event string, tasks []*domain.Task, jsonOutput bool) {
+	if jsonOutput {
+		if tasks == nil {
+			tasks = []*domain.Task{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(tasks)
+		return
+	}
+
+	if len(tasks) == 0 {
+		fmt.Fprintf(w, "No tasks were waiting for %s\n", event)
+		return
+	}
+
+	fmt.Fprintf(w, "Signalled %s; %d task(s) no longer wait for it:\n\n", event, len(tasks))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tTITLE\tSTATUS\tPRIORITY\n")
+	fmt.Fprintf(tw, "--\t-----\t------\t--------\n")
+	for _, task := range tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			task.ID, truncate(task.Title, 40), statusString(task), taskPriorityString(task))
+	}
+	tw.Flush()
+}
+
+// printOverdue prints overdue tasks and specs, earliest due first
+func printOverdue(w io.Writer, tasks []*domain.Task, specs []*client.Spec, jsonOutput bool) {
+	if jsonOutput {
+		if tasks == nil {
+			tasks = []*domain.Task{}
+		}
+		if specs == nil {
+			specs = []*client.Spec{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(map[string]interface{}{
+			"tasks": tasks,
+			"specs": specs,
+		})
+		return
+	}
+
+	if len(tasks) == 0 && len(specs) == 0 {
+		fmt.Fprintln(w, "Nothing is overdue")
+		return
+	}
+
+	now := time.Now()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tTITLE\tSTATUS\tDUE\tLATE BY\n")
+	fmt.Fprintf(tw, "--\t-----\t------\t---\t-------\n")
+	for _, task := range tasks {
+		if task.DueAt == nil {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", task.ID, truncate(task.Title, 40), statusString(task),
+			task.DueAt.Local().Format("2006-01-02 15:04"), formatLateness(now.Sub(*task.DueAt)))
+	}
+	for _, spec := range specs {
+		if spec.DueAt == nil {
+			continue
+		}
+		due, err := time.Parse(time.RFC3339, *spec.DueAt)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", spec.ID, truncate(spec.Title, 40), spec.Status,
+			due.Local().Format("2006-01-02 15:04"), formatLateness(now.Sub(due)))
+	}
+	tw.Flush()
+}
+
+// formatLateness formats how long an item has been overdue, in days once it
+// exceeds one
+func formatLateness(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.Truncate(time.Minute).String()
+}
+
+// printTimeReport prints the time spent on tasks per agent and per spec
+func printTimeReport(w io.Writer, report *domain.TimeReport, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+		return
+	}
+
+	if report.Total.Tasks == 0 {
+		fmt.Fprintln(w, "No tracked work yet")
+		return
+	}
+
+	row := func(tw io.Writer, name string, effort domain.Effort) {
+		estimated := "-"
+		if effort.EstimateMinutes > 0 {
+			estimated = formatSeconds(effort.EstimatedWorkedSeconds) + " / " + formatEstimate(effort.EstimateMinutes)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%d\t%s\n", name, effort.Tasks, effort.Done,
+			formatSeconds(effort.WorkedSeconds), formatSeconds(effort.BlockedSeconds), effort.Releases, estimated)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "AGENT\tTASKS\tDONE\tWORKED\tBLOCKED\tRELEASES\tACTUAL / ESTIMATE\n")
+	fmt.Fprintf(tw, "-----\t-----\t----\t------\t-------\t--------\t-----------------\n")
+	for _, agent := range report.Agents {
+		row(tw, truncate(agent.Agent, 40), agent.Effort)
+	}
+	tw.Flush()
+
+	if len(report.Specs) > 0 {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "SPEC\tTASKS\tDONE\tWORKED\tBLOCKED\tRELEASES\tACTUAL / ESTIMATE\n")
+		fmt.Fprintf(tw, "----\t-----\t----\t------\t-------\t--------\t-----------------\n")
+		for _, spec := range report.Specs {
+			row(tw, spec.SpecID, spec.Effort)
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row(tw, "Total", report.Total)
+	tw.Flush()
+}
+
+// printAgent prints a single agent
+func printAgent(w io.Writer, agent *domain.Agent, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(agent)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", agent.ID)
+	if agent.Name != nil {
+		fmt.Fprintf(tw, "Name:\t%s\n", *agent.Name)
+	}
+	if len(agent.Capabilities) > 0 {
+		fmt.Fprintf(tw, "Capabilities:\t%s\n", strings.Join(agent.Capabilities, ", "))
+	}
+	keys := make([]string, 0, len(agent.Metadata))
+	for key := range agent.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(tw, "Meta %s:\t%s\n", key, agent.Metadata[key])
+	}
+	if agent.WIPLimit != nil {
+		fmt.Fprintf(tw, "WIP Limit:\t%d\n", *agent.WIPLimit)
+	}
+	fmt.Fprintf(tw, "State:\t%s\n", agent.State)
+	fmt.Fprintf(tw, "Last Seen:\t%s\n", formatLastSeen(agent.LastSeenAt, time.Now()))
+	for _, claim := range agent.Claims {
+		fmt.Fprintf(tw, "Holding:\t%s %s\n", claim.TaskID, truncate(claim.Title, 40))
+	}
+	tw.Flush()
+}
+
+// printAgents prints agents with the tasks they hold, most recently seen first
+func printAgents(w io.Writer, agents []*domain.Agent, jsonOutput bool) {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(agents)
+		return
+	}
+
+	if len(agents) == 0 {
+		fmt.Fprintln(w, "No agents found")
+		return
+	}
+
+	now := time.Now()
+	deadClaims := 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "AGENT\tNAME\tSTATE\tLAST SEEN\tHOLDING\n")
+	fmt.Fprintf(tw, "-----\t----\t-----\t---------\t-------\n")
+	for _, agent := range agents {
+		name := "-"
+		if agent.Name != nil {
+			name = *agent.Name
+		}
+		holding := "-"
+		if len(agent.Claims) > 0 {
+			ids := make([]string, len(agent.Claims))
+			for i, claim := range agent.Claims {
+				ids[i] = claim.TaskID
+			}
+			holding = strings.Join(ids, ", ")
+		}
+		if agent.State == domain.AgentStale {
+			deadClaims += len(agent.Claims)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", truncate(agent.ID, 40), name, agent.State,
+			formatLastSeen(agent.LastSeenAt, now), holding)
+	}
+	tw.Flush()
+
+	if deadClaims > 0 {
+		fmt.Fprintf(w, "\n%d task(s) held by stale agents; free them with 'airyra release <id> --force'\n", deadClaims)
+	}
+}
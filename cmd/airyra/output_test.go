@@ -105,6 +105,51 @@ func TestPrintTask_WithClaimedBy(t *testing.T) {
 	}
 }
 
+func TestPrintTask_WithAssignee(t *testing.T) {
+	var buf bytes.Buffer
+	assignee := "@go"
+	task := &domain.Task{ID: "abc123", Title: "Test Task", Status: domain.StatusOpen, Assignee: &assignee}
+
+	printTask(&buf, task, false)
+
+	output := buf.String()
+	if !strings.Contains(output, "Assignee:") || !strings.Contains(output, "@go") {
+		t.Errorf("Output should contain the assignee, got:\n%s", output)
+	}
+}
+
+func TestPrintTask_WaitingForEvent(t *testing.T) {
+	var buf bytes.Buffer
+	event := "deploy-done"
+	task := &domain.Task{ID: "abc123", Title: "Smoke test", Status: domain.StatusOpen, DeferEvent: &event}
+
+	printTask(&buf, task, false)
+
+	output := buf.String()
+	if !strings.Contains(output, "Waiting For Event:") || !strings.Contains(output, "deploy-done") {
+		t.Errorf("Output should contain the event, got:\n%s", output)
+	}
+	if strings.Contains(output, "Deferred Until:") {
+		t.Errorf("Output should not show a defer time, got:\n%s", output)
+	}
+}
+
+func TestPrintTask_Quarantined(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Now()
+	reason := "quarantined after 3 failed attempts"
+	task := &domain.Task{ID: "abc123", Title: "Poison", Status: domain.StatusBlocked, BlockReason: &reason, Attempts: 3, QuarantinedAt: &now}
+
+	printTask(&buf, task, false)
+
+	output := buf.String()
+	for _, want := range []string{"Quarantined:", "Failed Attempts:", "3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
 func TestPrintTaskList_TableFormat(t *testing.T) {
 	var buf bytes.Buffer
 	tasks := []*domain.Task{
@@ -149,6 +194,65 @@ func TestPrintTaskList_TableFormat(t *testing.T) {
 	}
 }
 
+func TestPrintTask_WithBlockDetails(t *testing.T) {
+	var buf bytes.Buffer
+	reason := "waiting on API keys"
+	blockedBy := "ar-5678"
+	task := &domain.Task{
+		ID:          "abc123",
+		Title:       "Test Task",
+		Status:      domain.StatusBlocked,
+		BlockReason: &reason,
+		BlockedBy:   &blockedBy,
+		AutoUnblock: true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	printTask(&buf, task, false)
+
+	output := buf.String()
+	if !strings.Contains(output, reason) {
+		t.Error("Output should contain block reason")
+	}
+	if !strings.Contains(output, "ar-5678 (task)") {
+		t.Error("Output should contain the blocking task")
+	}
+}
+
+func TestPrintTask_WaitingOnSpecs(t *testing.T) {
+	var buf bytes.Buffer
+	specID := "sp-api"
+	task := &domain.Task{
+		ID:             "ar-1234",
+		Title:          "Ship",
+		Status:         domain.StatusOpen,
+		SpecID:         &specID,
+		WaitingOnSpecs: []string{"sp-storage"},
+	}
+
+	printTask(&buf, task, false)
+
+	if !strings.Contains(buf.String(), "spec sp-api waits on unfinished spec sp-storage") {
+		t.Errorf("Output should explain the spec gate, got %q", buf.String())
+	}
+}
+
+func TestPrintTaskList_ShowsBlocker(t *testing.T) {
+	var buf bytes.Buffer
+	blockedBy := "https://example.com/issues/1"
+	tasks := []*domain.Task{
+		{ID: "abc123", Title: "Blocked", Status: domain.StatusBlocked, BlockedBy: &blockedBy},
+	}
+	pagination := &client.Pagination{Page: 1, PerPage: 50, Total: 1, TotalPages: 1}
+
+	printTaskList(&buf, tasks, pagination, false)
+
+	if !strings.Contains(buf.String(), "blocked by https://example.com") {
+		t.Error("Output should show what the task is blocked by")
+	}
+}
+
 func TestPrintTaskList_JSONFormat(t *testing.T) {
 	var buf bytes.Buffer
 	tasks := []*domain.Task{
@@ -226,6 +330,19 @@ func TestPrintDependencies_TableFormat(t *testing.T) {
 	}
 }
 
+func TestPrintDependencies_OtherProject(t *testing.T) {
+	var buf bytes.Buffer
+	deps := []domain.Dependency{
+		{ChildID: "abc123", ParentID: "def456", Project: "backend"},
+	}
+
+	printDependencies(&buf, "abc123", deps, false)
+
+	if !strings.Contains(buf.String(), "backend/def456") {
+		t.Errorf("Output should qualify the parent with its project, got %q", buf.String())
+	}
+}
+
 func TestPrintDependencies_JSONFormat(t *testing.T) {
 	var buf bytes.Buffer
 	deps := []domain.Dependency{
@@ -243,6 +360,121 @@ func TestPrintDependencies_JSONFormat(t *testing.T) {
 	}
 }
 
+func TestPrintLinks_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	links := []domain.TaskLink{
+		{ID: 1, TaskID: "abc123", Type: domain.LinkTypeBranch, Value: "feature/x", CreatedBy: "user@host:/path"},
+	}
+
+	printLinks(&buf, "abc123", links, false)
+
+	output := buf.String()
+	if !strings.Contains(output, "branch") {
+		t.Error("Output should contain link type")
+	}
+	if !strings.Contains(output, "feature/x") {
+		t.Error("Output should contain link value")
+	}
+}
+
+func TestPrintLinks_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	printLinks(&buf, "abc123", nil, false)
+
+	if !strings.Contains(buf.String(), "no links") {
+		t.Error("Output should indicate the task has no links")
+	}
+}
+
+func TestPrintTaskWithComments_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	task := &domain.Task{
+		ID:        "abc123",
+		Title:     "Test Task",
+		Status:    domain.StatusOpen,
+		Priority:  2,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	comments := []domain.Comment{
+		{ID: 1, TaskID: "abc123", Body: "Tried X, failed because Y", Author: "user@host:/path", CreatedAt: time.Now()},
+	}
+
+	printTaskWithComments(&buf, &domain.TaskTree{Task: task}, comments, false)
+
+	output := buf.String()
+	if !strings.Contains(output, "Test Task") {
+		t.Error("Output should contain task title")
+	}
+	if !strings.Contains(output, "Tried X, failed because Y") {
+		t.Error("Output should contain comment body")
+	}
+	if !strings.Contains(output, "user@host:/path") {
+		t.Error("Output should contain comment author")
+	}
+}
+
+func TestPrintTaskWithComments_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	task := &domain.Task{
+		ID:        "abc123",
+		Title:     "Test Task",
+		Status:    domain.StatusOpen,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	printTaskWithComments(&buf, &domain.TaskTree{Task: task}, nil, true)
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output should be valid JSON: %v", err)
+	}
+	if parsed["id"] != "abc123" {
+		t.Errorf("Expected task fields at top level, got %v", parsed["id"])
+	}
+	if _, ok := parsed["comments"].([]interface{}); !ok {
+		t.Error("Expected comments to be an array")
+	}
+}
+
+func TestPrintTaskTree_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Now()
+	tree := &domain.TaskTree{
+		Task: &domain.Task{ID: "ar-1", Title: "Parent", Status: domain.StatusOpen, CreatedAt: now, UpdatedAt: now},
+		Children: []*domain.TaskTree{
+			{
+				Task: &domain.Task{ID: "ar-2", Title: "First child", Status: domain.StatusDone},
+				Children: []*domain.TaskTree{
+					{Task: &domain.Task{ID: "ar-4", Title: "Grandchild", Status: domain.StatusDone}},
+				},
+			},
+			{Task: &domain.Task{ID: "ar-3", Title: "Second child", Status: domain.StatusOpen}},
+		},
+	}
+
+	printTaskTree(&buf, tree, false)
+
+	output := buf.String()
+	for _, want := range []string{"Subtasks:", "├── ar-2  First child  [done]", "│   └── ar-4", "└── ar-3  Second child  [open]"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintSettings_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	printSettings(&buf, domain.DefaultProjectSettings(), false)
+
+	output := buf.String()
+	if !strings.Contains(output, "require_children_done") || !strings.Contains(output, "true") {
+		t.Errorf("Output should list settings, got:\n%s", output)
+	}
+}
+
 func TestPrintHistory_TableFormat(t *testing.T) {
 	var buf bytes.Buffer
 	entries := []domain.AuditEntry{
@@ -289,6 +521,117 @@ func TestPrintHistory_JSONFormat(t *testing.T) {
 	}
 }
 
+func TestPrintWorkflow_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	workflow := domain.DefaultWorkflow()
+	workflow.States = append(workflow.States, domain.WorkflowState{Name: "review"})
+	workflow.Transitions = append(workflow.Transitions,
+		domain.WorkflowTransition{From: domain.StatusInProgress, To: "review"})
+
+	printWorkflow(&buf, workflow, false)
+
+	output := buf.String()
+	if !strings.Contains(output, "review") {
+		t.Error("Output should contain custom state")
+	}
+	if !strings.Contains(output, "claimable") {
+		t.Error("Output should mark claimable states")
+	}
+	if !strings.Contains(output, "open, blocked, done, cancelled, review") {
+		t.Errorf("Output should list transitions from in_progress, got:\n%s", output)
+	}
+}
+
+func TestPrintTrash_TableFormat(t *testing.T) {
+	var buf bytes.Buffer
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	specDeletedAt := "2024-01-03T00:00:00Z"
+	trash := &client.Trash{
+		Tasks: []*domain.Task{{ID: "ar-1234", Title: "Deleted task", DeletedAt: &deletedAt}},
+		Specs: []*client.Spec{{ID: "sp-5678", Title: "Deleted spec", DeletedAt: &specDeletedAt}},
+	}
+
+	printTrash(&buf, trash, false)
+
+	output := buf.String()
+	if !strings.Contains(output, "ar-1234") || !strings.Contains(output, "2024-01-02 03:04:05") {
+		t.Errorf("Output should list the deleted task, got:\n%s", output)
+	}
+	if !strings.Contains(output, "sp-5678") || !strings.Contains(output, "2024-01-03 00:00:00") {
+		t.Errorf("Output should list the deleted spec, got:\n%s", output)
+	}
+}
+
+func TestPrintSpecProgress(t *testing.T) {
+	var buf bytes.Buffer
+	eta := "2026-01-06"
+	progress := &domain.SpecProgress{
+		SpecID:              "sp-1",
+		Total:               4,
+		Done:                2,
+		Remaining:           2,
+		Percent:             50,
+		ByStatus:            map[string]int{"done": 2, "open": 2},
+		Velocity:            1,
+		EstimatedCompletion: &eta,
+		Burndown: []domain.BurndownPoint{
+			{Date: "2026-01-01", Remaining: 4},
+			{Date: "2026-01-02", Remaining: 3},
+			{Date: "2026-01-03", Remaining: 2},
+		},
+	}
+
+	printSpecProgress(&buf, progress, 2, false)
+	output := buf.String()
+
+	for _, want := range []string{
+		"2/4 tasks done (50%)",
+		"done 2, open 2",
+		"estimated completion 2026-01-06",
+		"2026-01-03 | " + strings.Repeat("#", burndownWidth/2),
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got %q", want, output)
+		}
+	}
+	if strings.Contains(output, "2026-01-01") {
+		t.Error("Output should only chart the last 2 days")
+	}
+}
+
+func TestPrintSpecInstance(t *testing.T) {
+	var buf bytes.Buffer
+	instance := &client.SpecInstance{
+		Spec: &client.Spec{ID: "sp-1", Title: "Add users endpoint", Status: "open", TaskCount: 2},
+		Tasks: []*domain.Task{
+			{ID: "ar-1", Title: "Design users", Status: domain.StatusOpen, Priority: 1},
+			{ID: "ar-2", Title: "Implement users", Status: domain.StatusOpen, Priority: 2},
+		},
+	}
+
+	printSpecInstance(&buf, instance, false)
+	output := buf.String()
+
+	for _, want := range []string{"Add users endpoint", "0/2 done", "ar-1", "Implement users"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got %q", want, output)
+		}
+	}
+	if strings.Contains(output, "Page") {
+		t.Error("Output should not show pagination")
+	}
+}
+
+func TestPrintTrash_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	printTrash(&buf, &client.Trash{}, false)
+
+	if !strings.Contains(buf.String(), "Trash is empty") {
+		t.Errorf("Output should report an empty trash, got: %s", buf.String())
+	}
+}
+
 func TestPrintError(t *testing.T) {
 	var buf bytes.Buffer
 	err := domain.NewTaskNotFoundError("abc123")
@@ -342,3 +685,255 @@ func TestPriorityString(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskPriorityString(t *testing.T) {
+	critical, low := 0, 3
+	task := &domain.Task{Priority: 3}
+
+	if got := taskPriorityString(task); got != "low" {
+		t.Errorf("without effective priority: got %q", got)
+	}
+	task.EffectivePriority = &low
+	if got := taskPriorityString(task); got != "low" {
+		t.Errorf("with same effective priority: got %q", got)
+	}
+	task.EffectivePriority = &critical
+	if got := taskPriorityString(task); got != "low (inherits critical)" {
+		t.Errorf("with inherited priority: got %q", got)
+	}
+}
+
+func TestPrintScheduleList(t *testing.T) {
+	var buf bytes.Buffer
+	lastTask := "ar-1234"
+	schedules := []*domain.Schedule{{
+		ID:         "sc-1a2b",
+		Cron:       "0 9 * * mon",
+		Title:      "Rotate test fixtures ({{date}})",
+		NextRunAt:  time.Date(2026, 1, 5, 9, 0, 0, 0, time.Local),
+		LastTaskID: &lastTask,
+	}}
+
+	printScheduleList(&buf, schedules, false)
+
+	output := buf.String()
+	for _, want := range []string{"sc-1a2b", "0 9 * * mon", "2026-01-05 09:00", "ar-1234", "Rotate test fixtures"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintScheduleList_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	printScheduleList(&buf, nil, false)
+
+	if !strings.Contains(buf.String(), "No schedules found") {
+		t.Errorf("Output should report no schedules, got: %s", buf.String())
+	}
+}
+
+func TestPrintTask_WithDueDate(t *testing.T) {
+	var buf bytes.Buffer
+	due := time.Date(2020, 1, 2, 15, 0, 0, 0, time.Local)
+	task := &domain.Task{ID: "ar-1234", Title: "Late", Status: domain.StatusOpen, DueAt: &due}
+
+	printTask(&buf, task, false)
+
+	if !strings.Contains(buf.String(), "2020-01-02 15:00 (overdue)") {
+		t.Errorf("Output should show the overdue due date, got %q", buf.String())
+	}
+}
+
+func TestPrintOverdue(t *testing.T) {
+	var buf bytes.Buffer
+	taskDue := time.Now().Add(-72 * time.Hour)
+	specDue := time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	tasks := []*domain.Task{{ID: "ar-1234", Title: "Late task", Status: domain.StatusOpen, DueAt: &taskDue}}
+	specs := []*client.Spec{{ID: "sp-5678", Title: "Late spec", Status: "active", DueAt: &specDue}}
+
+	printOverdue(&buf, tasks, specs, false)
+
+	output := buf.String()
+	for _, want := range []string{"ar-1234", "Late task", "3d", "sp-5678", "Late spec", "2h0m0s"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintOverdue_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	printOverdue(&buf, nil, nil, false)
+
+	if !strings.Contains(buf.String(), "Nothing is overdue") {
+		t.Errorf("Output should report nothing overdue, got: %s", buf.String())
+	}
+}
+
+func TestPrintTaskList_ShowsDeferred(t *testing.T) {
+	var buf bytes.Buffer
+	until := time.Now().Add(48 * time.Hour)
+	tasks := []*domain.Task{{ID: "ar-1234", Title: "Later", Status: domain.StatusOpen, DeferUntil: &until}}
+
+	printTaskList(&buf, tasks, &client.Pagination{Page: 1, PerPage: 50, Total: 1, TotalPages: 1}, false)
+
+	if !strings.Contains(buf.String(), "open (deferred)") {
+		t.Errorf("Output should mark the task deferred, got %q", buf.String())
+	}
+}
+
+func TestPrintSignalled(t *testing.T) {
+	var buf bytes.Buffer
+	tasks := []*domain.Task{{ID: "ar-1234", Title: "Smoke test", Status: domain.StatusOpen}}
+
+	printSignalled(&buf, "deploy-done", tasks, false)
+
+	output := buf.String()
+	for _, want := range []string{"deploy-done", "1 task(s)", "ar-1234", "Smoke test"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	printSignalled(&buf, "deploy-done", nil, false)
+	if !strings.Contains(buf.String(), "No tasks were waiting for deploy-done") {
+		t.Errorf("Output should report that nothing was waiting, got %q", buf.String())
+	}
+}
+
+func TestPrintTaskWithTimeline(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	end := start.Add(30 * time.Minute)
+	cycle := int64(5400)
+	estimate := 60
+	tree := &domain.TaskTree{Task: &domain.Task{ID: "ar-1234", Title: "Tracked", Status: domain.StatusDone}}
+	timeline := &domain.TaskTimeline{
+		TaskID:   "ar-1234",
+		Estimate: &estimate,
+		Periods: []domain.StatusPeriod{
+			{Status: domain.StatusInProgress, Start: start, End: &end, Seconds: 1800, Agent: "agent-a"},
+			{Status: domain.StatusDone, Start: end, Seconds: 600},
+		},
+		WorkedSeconds: 1800,
+		CycleSeconds:  &cycle,
+		Claims:        1,
+	}
+
+	printTaskWithTimeline(&buf, tree, nil, timeline, false)
+
+	output := buf.String()
+	for _, want := range []string{"Timeline:", "2026-03-01 09:00", "agent-a", "10m (current)", "Worked: 30m of 1h estimated", "claims: 1", "Cycle time (first claim to done): 1h30m"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Comments") {
+		t.Errorf("Output should leave out comments that were not requested, got:\n%s", output)
+	}
+}
+
+func TestPrintTaskWithTimeline_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	tree := &domain.TaskTree{Task: &domain.Task{ID: "ar-1234", Title: "Tracked", Status: domain.StatusOpen}}
+	timeline := &domain.TaskTimeline{TaskID: "ar-1234", Periods: []domain.StatusPeriod{}}
+
+	printTaskWithTimeline(&buf, tree, nil, timeline, true)
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Output should be valid JSON: %v", err)
+	}
+	if result["id"] != "ar-1234" || result["timeline"] == nil {
+		t.Errorf("JSON should hold the task and its timeline, got %v", result)
+	}
+	if _, ok := result["comments"]; ok {
+		t.Errorf("JSON should leave out comments that were not requested, got %v", result)
+	}
+}
+
+func TestPrintTimeReport(t *testing.T) {
+	var buf bytes.Buffer
+	effort := domain.Effort{Tasks: 2, Done: 1, WorkedSeconds: 5400, Releases: 1, EstimateMinutes: 60, EstimatedWorkedSeconds: 3600}
+	report := &domain.TimeReport{
+		Total:  effort,
+		Agents: []domain.AgentEffort{{Agent: "agent-a", Effort: effort}},
+		Specs:  []domain.SpecEffort{{SpecID: "sp-1", Effort: effort}},
+	}
+
+	printTimeReport(&buf, report, false)
+
+	output := buf.String()
+	for _, want := range []string{"AGENT", "agent-a", "SPEC", "sp-1", "1h30m", "1h / 1h", "Total"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintTimeReport_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	printTimeReport(&buf, &domain.TimeReport{}, false)
+
+	if !strings.Contains(buf.String(), "No tracked work yet") {
+		t.Errorf("Output should report no tracked work, got: %s", buf.String())
+	}
+}
+
+func TestPrintAgents(t *testing.T) {
+	var buf bytes.Buffer
+	name := "Builder"
+	agents := []*domain.Agent{
+		{ID: "worker-1", Name: &name, State: domain.AgentActive, LastSeenAt: time.Now().Add(-time.Minute),
+			Claims: []domain.AgentClaim{{TaskID: "ar-1234", Title: "Ship it"}}},
+		{ID: "worker-2", State: domain.AgentStale, LastSeenAt: time.Now().Add(-2 * time.Hour),
+			Claims: []domain.AgentClaim{{TaskID: "ar-5678", Title: "Stuck"}}},
+	}
+
+	printAgents(&buf, agents, false)
+
+	output := buf.String()
+	for _, want := range []string{"worker-1", "Builder", "active", "1m ago", "ar-1234", "worker-2", "stale", "2h ago", "ar-5678",
+		"1 task(s) held by stale agents"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintAgents_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	printAgents(&buf, nil, false)
+
+	if !strings.Contains(buf.String(), "No agents found") {
+		t.Errorf("Output should report no agents, got: %s", buf.String())
+	}
+}
+
+func TestPrintAgent(t *testing.T) {
+	var buf bytes.Buffer
+	limit := 2
+	agent := &domain.Agent{
+		ID:           "worker-1",
+		Capabilities: []string{"go", "sql"},
+		Metadata:     map[string]string{"host": "ci-3"},
+		WIPLimit:     &limit,
+		State:        domain.AgentIdle,
+		LastSeenAt:   time.Now(),
+	}
+
+	printAgent(&buf, agent, false)
+
+	output := buf.String()
+	for _, want := range []string{"worker-1", "go, sql", "Meta host:", "ci-3", "WIP Limit:", "idle", "0m ago"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var overdueCmd = &cobra.Command{
+	Use:   "overdue",
+	Short: "List overdue tasks and specs",
+	Long: `List the tasks and specs that are past their due date, earliest due first.
+
+A task is overdue until it is done or cancelled. A spec is overdue while any
+of its tasks are unfinished. Set due dates with 'airyra edit --due' and
+'airyra spec edit --due'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		page, _ := cmd.Flags().GetInt("page")
+		perPage, _ := cmd.Flags().GetInt("per-page")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		tasks, err := c.ListOverdueTasks(context.Background(), page, perPage)
+		if err != nil {
+			handleError(err)
+		}
+		specs, err := c.ListOverdueSpecs(context.Background(), page, perPage)
+		if err != nil {
+			handleError(err)
+		}
+
+		printOverdue(os.Stdout, tasks.Data, specs.Data, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(overdueCmd)
+
+	overdueCmd.Flags().Int("page", 1, "Page number")
+	overdueCmd.Flags().Int("per-page", 50, "Items per page")
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestOverdueCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "overdue" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have overdue subcommand")
+	}
+}
+
+func TestOverdueCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"page", "per-page"} {
+		if overdueCmd.Flags().Lookup(name) == nil {
+			t.Errorf("overdueCmd should have --%s flag", name)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "Manage tasks quarantined after repeated failures",
+	Long: `Every time a claimed task is released instead of completed counts as a failed
+attempt. With the project's max_attempts setting, a task that fails that many
+times is quarantined: moved to blocked with a reason, so agents stop picking
+it up. Unblocking a quarantined task gives it a fresh set of attempts.
+
+  airyra settings set max_attempts 3
+  airyra quarantine list
+  airyra unblock <id>`,
+}
+
+var quarantineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List quarantined tasks",
+	Long:  `List the quarantined tasks, most recently quarantined first.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		page, _ := cmd.Flags().GetInt("page")
+		perPage, _ := cmd.Flags().GetInt("per-page")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		result, err := c.ListQuarantinedTasks(context.Background(), page, perPage)
+		if err != nil {
+			handleError(err)
+		}
+
+		printTaskList(os.Stdout, result.Data, result.Pagination, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(quarantineCmd)
+
+	quarantineCmd.AddCommand(quarantineListCmd)
+	quarantineListCmd.Flags().Int("page", 1, "Page number")
+	quarantineListCmd.Flags().Int("per-page", 50, "Items per page")
+}
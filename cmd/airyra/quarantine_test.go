@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestQuarantineCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "quarantine" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have quarantine subcommand")
+	}
+}
+
+func TestQuarantineCmd_HasListSubcommand(t *testing.T) {
+	found := false
+	for _, cmd := range quarantineCmd.Commands() {
+		if cmd.Name() == "list" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("quarantineCmd should have list subcommand")
+	}
+	for _, name := range []string{"page", "per-page"} {
+		if quarantineListCmd.Flags().Lookup(name) == nil {
+			t.Errorf("quarantineListCmd should have --%s flag", name)
+		}
+	}
+}
@@ -14,7 +14,9 @@ var readyCmd = &cobra.Command{
 	Long: `List all tasks that are ready to be worked on.
 
 Ready tasks are open tasks with no unfinished dependencies.
-Tasks are sorted by priority (highest first).`,
+Tasks are sorted by effective priority (highest first): a task inherits
+the highest priority of the tasks that depend on it, directly, transitively
+or through spec dependencies.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		page, _ := cmd.Flags().GetInt("page")
 		perPage, _ := cmd.Flags().GetInt("per-page")
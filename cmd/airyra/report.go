@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show the time spent on tasks per agent and per spec",
+	Long: `Show the time spent on tasks per agent and per spec, rebuilt from the audit log.
+
+WORKED is the time tasks were in progress and BLOCKED the time they were
+blocked. Agents are credited with the time their claims were in progress, the
+releases of their claims, and the tasks they finished. ACTUAL / ESTIMATE
+compares the worked time of done tasks that have an estimate with their
+estimates. Use 'airyra show <id> --timeline' for a single task.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		report, err := c.GetTimeReport(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		printTimeReport(os.Stdout, report, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestReportCmd_Exists(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "report" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("rootCmd should have report subcommand")
+	}
+}
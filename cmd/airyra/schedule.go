@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/airyra/airyra/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage recurring tasks",
+	Long: `Schedules create a task each time their cron expression is due, while the
+server is running. Cron expressions use the five standard fields (minute,
+hour, day of month, month, day of week) or a macro such as @daily, and are
+evaluated in the server's local time zone. The task title and description may
+reference the day of the run as {{date}}.`,
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <cron> <title>",
+	Short: "Add a schedule",
+	Long: `Add a schedule that creates a task titled <title> each time <cron> is due.
+
+Examples:
+  airyra schedule add "0 9 * * mon" "Rotate test fixtures ({{date}})"
+  airyra schedule add @daily "Triage new issues" -p high --estimate 30m
+
+When the server was not running at the time of one or more runs, --catch-up
+decides what happens when it starts again:
+  once - create a single task for the missed runs (default)
+  all  - create a task for each missed run, up to the last 10
+  skip - create no task for the missed runs`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		description, _ := cmd.Flags().GetString("description")
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		estimateStr, _ := cmd.Flags().GetString("estimate")
+		specID, _ := cmd.Flags().GetString("spec")
+		catchUp, _ := cmd.Flags().GetString("catch-up")
+
+		input := client.ScheduleInput{
+			Cron:        args[0],
+			CatchUp:     catchUp,
+			Title:       args[1],
+			Description: description,
+			SpecID:      specID,
+		}
+		if priorityStr != "" {
+			p, err := parsePriority(priorityStr)
+			if err != nil {
+				handleError(err)
+			}
+			input.Priority = &p
+		}
+		if estimateStr != "" {
+			minutes, err := parseEstimate(estimateStr)
+			if err != nil {
+				handleError(err)
+			}
+			input.Estimate = minutes
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		schedule, err := c.CreateSchedule(context.Background(), input)
+		if err != nil {
+			handleError(err)
+		}
+
+		printSchedule(os.Stdout, schedule, jsonOutput)
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List schedules",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		schedules, err := c.ListSchedules(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		printScheduleList(os.Stdout, schedules, jsonOutput)
+	},
+}
+
+var scheduleRmCmd = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "Remove a schedule",
+	Long:  `Remove a schedule. The tasks it already created are kept.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		if err := c.DeleteSchedule(context.Background(), args[0]); err != nil {
+			handleError(err)
+		}
+
+		printSuccess(os.Stdout, fmt.Sprintf("Schedule %s removed", args[0]), jsonOutput)
+	},
+}
+
+var scheduleRunNowCmd = &cobra.Command{
+	Use:   "run-now <id>",
+	Short: "Create a schedule's task now",
+	Long: `Create the task of a schedule right away. The schedule's next run is not
+changed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.RunSchedule(context.Background(), args[0])
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRmCmd)
+	scheduleCmd.AddCommand(scheduleRunNowCmd)
+
+	scheduleAddCmd.Flags().StringP("description", "d", "", "Description of the created tasks")
+	scheduleAddCmd.Flags().StringP("priority", "p", "", "Priority of the created tasks (0-4 or critical/high/normal/low/lowest)")
+	scheduleAddCmd.Flags().String("estimate", "", "Estimate of the created tasks (e.g. 90m, 2h)")
+	scheduleAddCmd.Flags().String("spec", "", "Spec ID to assign the created tasks to")
+	scheduleAddCmd.Flags().String("catch-up", "", "What to do with runs missed while the server was down (once, all, skip)")
+}
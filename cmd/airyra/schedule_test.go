@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestScheduleCmd_HasSubcommands(t *testing.T) {
+	for _, name := range []string{"add", "list", "rm", "run-now"} {
+		found := false
+		for _, cmd := range scheduleCmd.Commands() {
+			if cmd.Name() == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("scheduleCmd should have %s subcommand", name)
+		}
+	}
+}
+
+func TestScheduleAddCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"description", "priority", "estimate", "spec", "catch-up"} {
+		if scheduleAddCmd.Flags().Lookup(name) == nil {
+			t.Errorf("scheduleAddCmd should have --%s flag", name)
+		}
+	}
+}
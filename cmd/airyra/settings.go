@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Show the project settings",
+	Long: `Show the project settings.
+
+  require_children_done  A task cannot be completed while any of its
+                         subtasks is neither done nor cancelled (default true)
+  auto_complete_parents  A task is completed automatically when its last
+                         unfinished subtask is done (default true)
+  spec_dependencies_gate_tasks
+                         Tasks of a spec stay out of the ready queue while
+                         a spec it depends on is unfinished (default false)
+  agent_wip_limit        Most in-progress tasks each agent may hold; 0 means
+                         no limit (default 0)
+  project_wip_limit      Most in-progress tasks across the project; 0 means
+                         no limit (default 0)
+  max_attempts           Quarantine a task once it has been released this
+                         many times without completing; 0 means never
+                         (default 0)
+  admins                 Agents that may hand off tasks claimed by other
+                         agents with 'airyra handoff --force' (default [])`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		settings, err := c.GetSettings(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		printSettings(os.Stdout, settings, jsonOutput)
+	},
+}
+
+var settingsSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a project setting",
+	Long: `Change a project setting, for example:
+
+  airyra settings set require_children_done false
+  airyra settings set agent_wip_limit 3
+  airyra settings set admins '["lead"]'`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		key := args[0]
+		value := parseSettingValue(args[1])
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		current, err := c.GetSettings(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+		if _, ok := settingsMap(current)[key]; !ok {
+			handleError(fmt.Errorf("unknown setting %q", key))
+		}
+
+		updated, err := c.UpdateSettings(context.Background(), map[string]interface{}{key: value})
+		if err != nil {
+			handleError(err)
+		}
+
+		printSettings(os.Stdout, updated, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(settingsCmd)
+
+	settingsCmd.AddCommand(settingsSetCmd)
+}
+
+// parseSettingValue interprets a command-line setting value as JSON (true,
+// false, numbers) and falls back to a plain string
+func parseSettingValue(arg string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(arg), &value); err != nil {
+		return arg
+	}
+	return value
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSettingsCmd_Exists(t *testing.T) {
+	if settingsCmd == nil {
+		t.Error("settingsCmd should not be nil")
+	}
+}
+
+func TestSettingsCmd_HasSetSubcommand(t *testing.T) {
+	found := false
+	for _, cmd := range settingsCmd.Commands() {
+		if cmd.Name() == "set" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("settingsCmd should have set subcommand")
+	}
+}
+
+func TestParseSettingValue(t *testing.T) {
+	if v := parseSettingValue("false"); v != false {
+		t.Errorf("parseSettingValue(false) = %v, want false", v)
+	}
+	if v := parseSettingValue("3"); v != float64(3) {
+		t.Errorf("parseSettingValue(3) = %v, want 3", v)
+	}
+	if v := parseSettingValue("backend"); v != "backend" {
+		t.Errorf("parseSettingValue(backend) = %v, want backend", v)
+	}
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/airyra/airyra/internal/client"
+	"github.com/airyra/airyra/internal/config"
+	"github.com/airyra/airyra/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -16,18 +21,76 @@ var specCmd = &cobra.Command{
 }
 
 var specNewCmd = &cobra.Command{
-	Use:   "new <title>",
+	Use:   "new [title]",
 	Short: "Create a new spec",
-	Long:  `Create a new spec with the given title.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Create a new spec with the given title.
+
+With --template, the spec is created from a template together with its tasks and
+their dependencies, all at once. Templates are JSON files named <name>.json in
+.airyra/templates next to airyra.toml, or in ~/.airyra/templates:
+
+  {
+    "title": "Add {{name}} endpoint",
+    "vars": {"version": "v1"},
+    "tasks": [
+      {"key": "design", "title": "Design /{{version}}/{{name}}", "priority": 1},
+      {"key": "implement", "title": "Implement {{name}}", "depends_on": ["design"]},
+      {"key": "test", "title": "Test {{name}}", "depends_on": ["implement"]},
+      {"key": "docs", "title": "Document {{name}}", "depends_on": ["implement"]}
+    ]
+  }
+
+Titles and descriptions may reference variables as {{name}}; set them with --var,
+which overrides the defaults in "vars". A title given on the command line
+replaces the template title. Estimates are in minutes.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		description, _ := cmd.Flags().GetString("description")
+		templateName, _ := cmd.Flags().GetString("template")
+		varFlags, _ := cmd.Flags().GetStringArray("var")
+
+		if templateName == "" {
+			if len(args) == 0 {
+				handleError(fmt.Errorf("a title is required unless --template is given"))
+			}
+			if len(varFlags) > 0 {
+				handleError(fmt.Errorf("--var requires --template"))
+			}
+		}
+
+		var template *domain.SpecTemplate
+		var vars map[string]string
+		if templateName != "" {
+			var err error
+			if template, err = loadSpecTemplate(templateName); err != nil {
+				handleError(err)
+			}
+			if vars, err = parseTemplateVars(varFlags); err != nil {
+				handleError(err)
+			}
+			if len(args) > 0 {
+				template.Title = args[0]
+			}
+			if description != "" {
+				template.Description = &description
+			}
+		}
 
 		c, err := getClient()
 		if err != nil {
 			handleError(err)
 		}
 
+		if template != nil {
+			instance, err := c.InstantiateSpec(context.Background(), template, vars)
+			if err != nil {
+				handleError(err)
+			}
+
+			printSpecInstance(os.Stdout, instance, jsonOutput)
+			return
+		}
+
 		spec, err := c.CreateSpec(context.Background(), args[0], description)
 		if err != nil {
 			handleError(err)
@@ -37,6 +100,38 @@ var specNewCmd = &cobra.Command{
 	},
 }
 
+// loadSpecTemplate reads the spec template with the given name.
+func loadSpecTemplate(name string) (*domain.SpecTemplate, error) {
+	path, err := config.FindTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	var template domain.SpecTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("invalid template file %s: %w", path, err)
+	}
+	return &template, nil
+}
+
+// parseTemplateVars parses --var flags of the form name=value.
+func parseTemplateVars(flags []string) (map[string]string, error) {
+	vars := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		name, value, ok := strings.Cut(flag, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected name=value", flag)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
 var specListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List specs",
@@ -92,14 +187,47 @@ var specShowCmd = &cobra.Command{
 	},
 }
 
+var specProgressCmd = &cobra.Command{
+	Use:   "progress <id>",
+	Short: "Show spec progress and burndown",
+	Long: `Show how far the tasks of a spec have come.
+
+Reports the tasks by status, the percentage done, the average number of tasks
+completed per day since the first completion, the estimated completion date at
+that pace, and a burndown chart of the unfinished tasks at the end of each day.
+Cancelled tasks are not counted. Use --days 0 to chart every day since the spec
+was created.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		days, _ := cmd.Flags().GetInt("days")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		progress, err := c.GetSpecProgress(context.Background(), args[0])
+		if err != nil {
+			handleError(err)
+		}
+
+		printSpecProgress(os.Stdout, progress, days, jsonOutput)
+	},
+}
+
 var specEditCmd = &cobra.Command{
 	Use:   "edit <id>",
 	Short: "Edit a spec",
-	Long:  `Edit a spec's title or description.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Edit a spec's title, description, or due date.
+
+The due date is a date (due at the end of that day), an RFC 3339 time, or an
+offset from now such as 48h or 3d. Use --due none to clear it. A spec is
+overdue while any of its tasks are unfinished past the due date.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
+		dueStr, _ := cmd.Flags().GetString("due")
 
 		var updates client.SpecUpdates
 
@@ -109,6 +237,13 @@ var specEditCmd = &cobra.Command{
 		if cmd.Flags().Changed("description") {
 			updates.Description = &description
 		}
+		if cmd.Flags().Changed("due") {
+			due, err := parseDueDate(dueStr, time.Now())
+			if err != nil {
+				handleError(err)
+			}
+			updates.DueAt = &due
+		}
 
 		c, err := getClient()
 		if err != nil {
@@ -167,8 +302,9 @@ var specReopenCmd = &cobra.Command{
 var specDeleteCmd = &cobra.Command{
 	Use:   "delete <id>",
 	Short: "Delete a spec",
-	Long:  `Delete a spec by ID. Tasks belonging to the spec will have their spec_id cleared.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Move a spec to the trash. Tasks belonging to the spec are kept and
+have their spec_id cleared when the spec is purged from the trash.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		c, err := getClient()
 		if err != nil {
@@ -180,7 +316,7 @@ var specDeleteCmd = &cobra.Command{
 			handleError(err)
 		}
 
-		printSuccess(os.Stdout, fmt.Sprintf("Spec %s deleted", args[0]), jsonOutput)
+		printSuccess(os.Stdout, fmt.Sprintf("Spec %s deleted (moved to trash)", args[0]), jsonOutput)
 	},
 }
 
@@ -257,6 +393,7 @@ func init() {
 	specCmd.AddCommand(specListCmd)
 	specCmd.AddCommand(specShowCmd)
 	specCmd.AddCommand(specEditCmd)
+	specCmd.AddCommand(specProgressCmd)
 	specCmd.AddCommand(specCancelCmd)
 	specCmd.AddCommand(specReopenCmd)
 	specCmd.AddCommand(specDeleteCmd)
@@ -268,6 +405,8 @@ func init() {
 
 	// New command flags
 	specNewCmd.Flags().StringP("description", "d", "", "Spec description")
+	specNewCmd.Flags().String("template", "", "Create the spec and its tasks from a template")
+	specNewCmd.Flags().StringArray("var", nil, "Template variable as name=value (repeatable)")
 
 	// List command flags
 	specListCmd.Flags().String("status", "", "Filter by status (draft, active, done, cancelled)")
@@ -277,7 +416,11 @@ func init() {
 	// Show command flags
 	specShowCmd.Flags().Bool("tasks", false, "Show tasks belonging to spec")
 
+	// Progress command flags
+	specProgressCmd.Flags().Int("days", 14, "Number of most recent days to chart (0 for all)")
+
 	// Edit command flags
 	specEditCmd.Flags().StringP("title", "t", "", "New title")
 	specEditCmd.Flags().StringP("description", "d", "", "New description")
+	specEditCmd.Flags().String("due", "", "Due date, e.g. 2026-03-01, 48h or 3d (none clears)")
 }
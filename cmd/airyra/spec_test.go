@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTemplateVars(t *testing.T) {
+	tests := []struct {
+		input    []string
+		expected map[string]string
+		hasError bool
+	}{
+		{nil, map[string]string{}, false},
+		{[]string{"name=users"}, map[string]string{"name": "users"}, false},
+		{[]string{"name=users", "path=/v1/users?a=b"}, map[string]string{"name": "users", "path": "/v1/users?a=b"}, false},
+		{[]string{"name="}, map[string]string{"name": ""}, false},
+		{[]string{"name"}, nil, true},
+		{[]string{"=users"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		vars, err := parseTemplateVars(tt.input)
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("parseTemplateVars(%v): expected error but got nil", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTemplateVars(%v): unexpected error: %v", tt.input, err)
+		}
+		if !reflect.DeepEqual(vars, tt.expected) {
+			t.Errorf("parseTemplateVars(%v) = %v, expected %v", tt.input, vars, tt.expected)
+		}
+	}
+}
+
+func TestSpecEditCmd_HasDueFlag(t *testing.T) {
+	if specEditCmd.Flags().Lookup("due") == nil {
+		t.Error("specEditCmd should have --due flag")
+	}
+}
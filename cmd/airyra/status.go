@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 
+	"github.com/airyra/airyra/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -30,8 +31,11 @@ var claimCmd = &cobra.Command{
 var doneCmd = &cobra.Command{
 	Use:   "done <id>",
 	Short: "Mark a task as done",
-	Long:  `Mark a task as complete. Changes status from in_progress to done.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Mark a task as complete. Changes status from in_progress to done.
+
+If the project workflow has a review phase, the task moves to in_review
+instead and is done once another agent runs 'airyra approve'.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		c, err := getClient()
 		if err != nil {
@@ -47,6 +51,52 @@ var doneCmd = &cobra.Command{
 	},
 }
 
+var approveCmd = &cobra.Command{
+	Use:   "approve <id>",
+	Short: "Approve a task under review",
+	Long: `Approve a task under review. Changes status from in_review to done,
+which satisfies dependencies on the task.
+
+A task must be approved by an agent other than the one that completed it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.ApproveTask(context.Background(), args[0])
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var rejectCmd = &cobra.Command{
+	Use:   "reject <id>",
+	Short: "Reject a task under review",
+	Long: `Reject a task under review. Changes status from in_review to open so it
+can be claimed again, and records the reason as a comment on the task.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		reason, _ := cmd.Flags().GetString("reason")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.RejectTask(context.Background(), args[0], reason)
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
 var releaseCmd = &cobra.Command{
 	Use:   "release <id>",
 	Short: "Release a claimed task",
@@ -71,18 +121,57 @@ Use --force to release a task claimed by another agent.`,
 	},
 }
 
+var handoffCmd = &cobra.Command{
+	Use:   "handoff <id> <agent>",
+	Short: "Hand a claimed task to another agent",
+	Long: `Hand a task you have claimed to another agent. The task stays in progress
+and passes to the agent in one step, so no other agent can claim it in
+between. The receiving agent's WIP limit applies.
+
+Project admins can use --force to hand off a task claimed by another agent;
+see the admins setting in 'airyra settings'.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.HandoffTask(context.Background(), args[0], args[1], force)
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
 var blockCmd = &cobra.Command{
 	Use:   "block <id>",
 	Short: "Block a task",
-	Long:  `Mark a task as blocked. Changes status from in_progress to blocked.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Mark a task as blocked. Changes status from in_progress to blocked.
+
+Use --reason to explain the block and --by to reference the task, spec
+or URL the task is waiting on. With --auto-unblock, a task blocked by
+another task is reopened automatically when that task is done.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		reason, _ := cmd.Flags().GetString("reason")
+		blockedBy, _ := cmd.Flags().GetString("by")
+		autoUnblock, _ := cmd.Flags().GetBool("auto-unblock")
+
 		c, err := getClient()
 		if err != nil {
 			handleError(err)
 		}
 
-		task, err := c.BlockTask(context.Background(), args[0])
+		task, err := c.BlockTask(context.Background(), args[0], client.BlockOptions{
+			Reason:      reason,
+			BlockedBy:   blockedBy,
+			AutoUnblock: autoUnblock,
+		})
 		if err != nil {
 			handleError(err)
 		}
@@ -111,12 +200,96 @@ var unblockCmd = &cobra.Command{
 	},
 }
 
+var cancelCmd = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "Cancel a task",
+	Long: `Cancel a task that will not be done. Unlike 'airyra delete', the task and
+its history are kept, and 'airyra reopen' brings it back.
+
+Use --reason to record why as a comment. Whether tasks that depend on a
+cancelled task become ready is set by 'airyra workflow cancel-policy'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		reason, _ := cmd.Flags().GetString("reason")
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.CancelTask(context.Background(), args[0], reason)
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var reopenCmd = &cobra.Command{
+	Use:   "reopen <id>",
+	Short: "Reopen a cancelled task",
+	Long:  `Reopen a cancelled task. Changes status from cancelled to open.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.ReopenTask(context.Background(), args[0])
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var moveCmd = &cobra.Command{
+	Use:   "move <id> <state>",
+	Short: "Move a task to a workflow state",
+	Long: `Move a task to another state of the project workflow, such as a custom
+review or qa state. The move must be an allowed workflow transition; see
+'airyra workflow' for the states and transitions of the project.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		task, err := c.TransitionTask(context.Background(), args[0], args[1])
+		if err != nil {
+			handleError(err)
+		}
+
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(claimCmd)
 	rootCmd.AddCommand(doneCmd)
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(rejectCmd)
 	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(handoffCmd)
 	rootCmd.AddCommand(blockCmd)
 	rootCmd.AddCommand(unblockCmd)
+	rootCmd.AddCommand(cancelCmd)
+	rootCmd.AddCommand(reopenCmd)
+	rootCmd.AddCommand(moveCmd)
+
+	rejectCmd.Flags().String("reason", "", "Why the task is rejected (required)")
+
+	cancelCmd.Flags().String("reason", "", "Why the task is cancelled")
 
 	releaseCmd.Flags().Bool("force", false, "Force release a task claimed by another agent")
+
+	handoffCmd.Flags().Bool("force", false, "Hand off a task claimed by another agent (project admins only)")
+
+	blockCmd.Flags().String("reason", "", "Why the task is blocked")
+	blockCmd.Flags().String("by", "", "Task ID, spec ID or URL the task is waiting on")
+	blockCmd.Flags().Bool("auto-unblock", false, "Unblock automatically when the blocking task is done")
 }
@@ -48,12 +48,29 @@ func TestReleaseCmd_HasForceFlag(t *testing.T) {
 	}
 }
 
+func TestHandoffCmd_Exists(t *testing.T) {
+	if handoffCmd == nil {
+		t.Error("handoffCmd should not be nil")
+	}
+	if handoffCmd.Flags().Lookup("force") == nil {
+		t.Error("handoffCmd should have --force flag")
+	}
+}
+
 func TestBlockCmd_Exists(t *testing.T) {
 	if blockCmd == nil {
 		t.Error("blockCmd should not be nil")
 	}
 }
 
+func TestBlockCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"reason", "by", "auto-unblock"} {
+		if blockCmd.Flags().Lookup(name) == nil {
+			t.Errorf("blockCmd should have --%s flag", name)
+		}
+	}
+}
+
 func TestUnblockCmd_Exists(t *testing.T) {
 	if unblockCmd == nil {
 		t.Error("unblockCmd should not be nil")
@@ -252,7 +269,7 @@ func TestBlock_Success(t *testing.T) {
 	host, port := parseURL(server.URL)
 	c := client.NewClient(host, port, "testproject", "test@host:/path")
 
-	task, err := c.BlockTask(context.Background(), "abc123")
+	task, err := c.BlockTask(context.Background(), "abc123", client.BlockOptions{})
 	if err != nil {
 		t.Fatalf("BlockTask failed: %v", err)
 	}
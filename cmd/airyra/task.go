@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/airyra/airyra/internal/client"
+	"github.com/airyra/airyra/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -80,32 +82,66 @@ var listCmd = &cobra.Command{
 var showCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Show task details",
-	Long:  `Display detailed information about a task.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Display detailed information about a task and its subtask tree.
+
+With --timeline, also show how long the task spent in each status, the time
+worked on it against its estimate, and how often it was claimed and released.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		c, err := getClient()
 		if err != nil {
 			handleError(err)
 		}
 
-		task, err := c.GetTask(context.Background(), args[0])
+		tree, err := c.GetTaskTree(context.Background(), args[0])
 		if err != nil {
 			handleError(err)
 		}
 
-		printTask(os.Stdout, task, jsonOutput)
+		showComments, _ := cmd.Flags().GetBool("comments")
+		showTimeline, _ := cmd.Flags().GetBool("timeline")
+
+		var comments *[]domain.Comment
+		if showComments {
+			list, err := c.ListComments(context.Background(), tree.ID)
+			if err != nil {
+				handleError(err)
+			}
+			comments = &list
+		}
+
+		switch {
+		case showTimeline:
+			timeline, err := c.GetTaskTimeline(context.Background(), tree.ID)
+			if err != nil {
+				handleError(err)
+			}
+			printTaskWithTimeline(os.Stdout, tree, comments, timeline, jsonOutput)
+		case showComments:
+			printTaskWithComments(os.Stdout, tree, *comments, jsonOutput)
+		default:
+			printTaskTree(os.Stdout, tree, jsonOutput)
+		}
 	},
 }
 
 var editCmd = &cobra.Command{
 	Use:   "edit <id>",
 	Short: "Edit a task",
-	Long:  `Edit a task's title, description, or priority.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Edit a task's title, description, priority, estimate, or due date.
+
+The estimate is a duration such as 90m, 2h or 1h30m. It weights the task in
+'airyra critical-path'. Use --estimate 0 to clear it.
+
+The due date is a date (due at the end of that day), an RFC 3339 time, or an
+offset from now such as 48h or 3d. Use --due none to clear it.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
 		priorityStr, _ := cmd.Flags().GetString("priority")
+		estimateStr, _ := cmd.Flags().GetString("estimate")
+		dueStr, _ := cmd.Flags().GetString("due")
 
 		var updates client.TaskUpdates
 
@@ -122,6 +158,20 @@ var editCmd = &cobra.Command{
 			}
 			updates.Priority = &p
 		}
+		if cmd.Flags().Changed("estimate") {
+			minutes, err := parseEstimate(estimateStr)
+			if err != nil {
+				handleError(err)
+			}
+			updates.Estimate = &minutes
+		}
+		if cmd.Flags().Changed("due") {
+			due, err := parseDueDate(dueStr, time.Now())
+			if err != nil {
+				handleError(err)
+			}
+			updates.DueAt = &due
+		}
 
 		c, err := getClient()
 		if err != nil {
@@ -140,8 +190,11 @@ var editCmd = &cobra.Command{
 var deleteCmd = &cobra.Command{
 	Use:   "delete <id>",
 	Short: "Delete a task",
-	Long:  `Delete a task by ID.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Move a task and its subtasks to the trash.
+
+Deleted tasks can be restored with 'airyra trash restore' until they are
+purged. The server purges the trash automatically after a retention period.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		c, err := getClient()
 		if err != nil {
@@ -153,7 +206,7 @@ var deleteCmd = &cobra.Command{
 			handleError(err)
 		}
 
-		printSuccess(os.Stdout, fmt.Sprintf("Task %s deleted", args[0]), jsonOutput)
+		printSuccess(os.Stdout, fmt.Sprintf("Task %s deleted (moved to trash)", args[0]), jsonOutput)
 	},
 }
 
@@ -171,14 +224,20 @@ func init() {
 	createCmd.Flags().String("spec", "", "Spec ID to assign task to")
 
 	// List command flags
-	listCmd.Flags().String("status", "", "Filter by status (open, in_progress, blocked, done)")
+	listCmd.Flags().String("status", "", "Filter by status (open, in_progress, blocked, done, cancelled or a custom workflow state)")
 	listCmd.Flags().Int("page", 1, "Page number")
 	listCmd.Flags().Int("per-page", 50, "Items per page")
 
+	// Show command flags
+	showCmd.Flags().Bool("comments", false, "Include the task's comments")
+	showCmd.Flags().Bool("timeline", false, "Include the time the task spent in each status")
+
 	// Edit command flags
 	editCmd.Flags().StringP("title", "t", "", "New title")
 	editCmd.Flags().StringP("description", "d", "", "New description")
 	editCmd.Flags().StringP("priority", "p", "", "New priority")
+	editCmd.Flags().String("estimate", "", "Expected effort, e.g. 90m or 2h (0 clears)")
+	editCmd.Flags().String("due", "", "Due date, e.g. 2026-03-01, 48h or 3d (none clears)")
 }
 
 // validateCreateArgs validates the arguments for the create command
@@ -100,6 +100,12 @@ func TestShowCmd_Use(t *testing.T) {
 	}
 }
 
+func TestShowCmd_HasTimelineFlag(t *testing.T) {
+	if showCmd.Flags().Lookup("timeline") == nil {
+		t.Error("showCmd should have --timeline flag")
+	}
+}
+
 func TestEditCmd_Exists(t *testing.T) {
 	if editCmd == nil {
 		t.Error("editCmd should not be nil")
@@ -113,6 +119,12 @@ func TestEditCmd_HasTitleFlag(t *testing.T) {
 	}
 }
 
+func TestEditCmd_HasDueFlag(t *testing.T) {
+	if editCmd.Flags().Lookup("due") == nil {
+		t.Error("editCmd should have --due flag")
+	}
+}
+
 func TestDeleteCmd_Exists(t *testing.T) {
 	if deleteCmd == nil {
 		t.Error("deleteCmd should not be nil")
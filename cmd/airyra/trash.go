@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage deleted tasks and specs",
+	Long: `Deleted tasks and specs are moved to the trash, where they can be restored
+until they are purged. The server purges items automatically once they have
+been in the trash for longer than its retention period (30 days by default).`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deleted tasks and specs",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		trash, err := c.ListTrash(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		printTrash(os.Stdout, trash, jsonOutput)
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore a deleted task or spec",
+	Long: `Restore a task or spec from the trash.
+
+Restoring a task also restores the subtasks that were deleted with it.
+A subtask cannot be restored while its parent is still in the trash.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		id := args[0]
+		if isSpecID(id) {
+			spec, err := c.RestoreSpec(context.Background(), id)
+			if err != nil {
+				handleError(err)
+			}
+			printSpec(os.Stdout, spec, jsonOutput)
+			return
+		}
+
+		task, err := c.RestoreTask(context.Background(), id)
+		if err != nil {
+			handleError(err)
+		}
+		printTask(os.Stdout, task, jsonOutput)
+	},
+}
+
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge [id]",
+	Short: "Permanently delete items from the trash",
+	Long: `Permanently delete a task or spec from the trash, or everything in the
+trash with --all. Purging a task also purges its subtasks. Purged items
+cannot be restored.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		if all == (len(args) == 1) {
+			handleError(fmt.Errorf("specify either an ID or --all"))
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		if all {
+			purged, err := c.EmptyTrash(context.Background())
+			if err != nil {
+				handleError(err)
+			}
+			printSuccess(os.Stdout, fmt.Sprintf("Purged %d item(s) from the trash", purged), jsonOutput)
+			return
+		}
+
+		if err := c.PurgeTrash(context.Background(), args[0]); err != nil {
+			handleError(err)
+		}
+		printSuccess(os.Stdout, fmt.Sprintf("%s purged from the trash", args[0]), jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trashCmd)
+
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashPurgeCmd)
+
+	trashPurgeCmd.Flags().Bool("all", false, "Purge everything in the trash")
+}
+
+// isSpecID reports whether an ID refers to a spec rather than a task
+func isSpecID(id string) bool {
+	return strings.HasPrefix(id, "sp-")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTrashCmd_Exists(t *testing.T) {
+	if trashCmd == nil {
+		t.Error("trashCmd should not be nil")
+	}
+}
+
+func TestTrashCmd_HasSubcommands(t *testing.T) {
+	for _, name := range []string{"list", "restore", "purge"} {
+		found := false
+		for _, cmd := range trashCmd.Commands() {
+			if cmd.Name() == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("trashCmd should have %s subcommand", name)
+		}
+	}
+}
+
+func TestTrashPurgeCmd_HasAllFlag(t *testing.T) {
+	flag := trashPurgeCmd.Flags().Lookup("all")
+	if flag == nil {
+		t.Error("trashPurgeCmd should have --all flag")
+	}
+}
+
+func TestIsSpecID(t *testing.T) {
+	if !isSpecID("sp-1a2b") {
+		t.Error("sp-1a2b should be a spec ID")
+	}
+	if isSpecID("ar-1a2b") {
+		t.Error("ar-1a2b should not be a spec ID")
+	}
+}
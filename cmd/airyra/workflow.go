@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Show the project workflow",
+	Long: `Show the task states of the project and the transitions allowed between them.
+
+Claimable states appear in the ready queue; done states satisfy dependencies.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		workflow, err := c.GetWorkflow(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		printWorkflow(os.Stdout, workflow, jsonOutput)
+	},
+}
+
+var workflowSetCmd = &cobra.Command{
+	Use:   "set <file>",
+	Short: "Replace the project workflow",
+	Long: `Replace the project workflow with the one defined in a JSON file, for example:
+
+  {
+    "states": [
+      {"name": "open", "claimable": true},
+      {"name": "in_progress"},
+      {"name": "review"},
+      {"name": "blocked"},
+      {"name": "done", "done": true},
+      {"name": "cancelled"}
+    ],
+    "transitions": [
+      {"from": "open", "to": "in_progress"},
+      {"from": "in_progress", "to": "review"},
+      {"from": "review", "to": "done"},
+      {"from": "review", "to": "in_progress"}
+    ]
+  }
+
+The open, in_progress, blocked, done and cancelled states are required. States that
+still have tasks cannot be removed. Use 'airyra workflow --json' to get the
+current workflow as a starting point.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			handleError(fmt.Errorf("failed to read workflow file: %w", err))
+		}
+
+		var workflow domain.Workflow
+		if err := json.Unmarshal(data, &workflow); err != nil {
+			handleError(fmt.Errorf("invalid workflow file: %w", err))
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		updated, err := c.SetWorkflow(context.Background(), &workflow)
+		if err != nil {
+			handleError(err)
+		}
+
+		printWorkflow(os.Stdout, updated, jsonOutput)
+	},
+}
+
+var workflowReviewCmd = &cobra.Command{
+	Use:   "review <on|off>",
+	Short: "Turn the review phase on or off",
+	Long: `Turn the in_review phase on or off for the project.
+
+With review on, 'airyra done' moves a task to in_review and another agent
+must run 'airyra approve' before the task is done and its dependents become
+ready. 'airyra reject --reason' sends the task back to open.
+
+Review cannot be turned off while tasks are in review.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cmd *cobra.Command, args []string) {
+		if args[0] != "on" && args[0] != "off" {
+			handleError(fmt.Errorf("expected on or off, got %q", args[0]))
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		workflow, err := c.GetWorkflow(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		if args[0] == "on" {
+			workflow.EnableReview()
+		} else {
+			workflow.DisableReview()
+		}
+
+		updated, err := c.SetWorkflow(context.Background(), workflow)
+		if err != nil {
+			handleError(err)
+		}
+
+		printWorkflow(os.Stdout, updated, jsonOutput)
+	},
+}
+
+var workflowCancelPolicyCmd = &cobra.Command{
+	Use:   "cancel-policy <block|satisfy>",
+	Short: "Set how cancelled tasks affect their dependents",
+	Long: `Set how a cancelled task affects the tasks that depend on it.
+
+  block    Dependents stay out of the ready queue until the dependency is
+           removed or the task is reopened and done (default)
+  satisfy  A cancelled task counts as a met dependency`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{string(domain.CancelPolicyBlock), string(domain.CancelPolicySatisfy)},
+	Run: func(cmd *cobra.Command, args []string) {
+		policy := domain.CancelPolicy(args[0])
+		if !policy.IsValid() {
+			handleError(fmt.Errorf("expected block or satisfy, got %q", args[0]))
+		}
+
+		c, err := getClient()
+		if err != nil {
+			handleError(err)
+		}
+
+		workflow, err := c.GetWorkflow(context.Background())
+		if err != nil {
+			handleError(err)
+		}
+
+		workflow.SetCancelPolicy(policy)
+
+		updated, err := c.SetWorkflow(context.Background(), workflow)
+		if err != nil {
+			handleError(err)
+		}
+
+		printWorkflow(os.Stdout, updated, jsonOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workflowCmd)
+
+	workflowCmd.AddCommand(workflowSetCmd)
+	workflowCmd.AddCommand(workflowReviewCmd)
+	workflowCmd.AddCommand(workflowCancelPolicyCmd)
+}
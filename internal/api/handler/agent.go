@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+)
+
+// AgentHandler handles agent registry operations.
+type AgentHandler struct{}
+
+// NewAgentHandler creates a new AgentHandler.
+func NewAgentHandler() *AgentHandler {
+	return &AgentHandler{}
+}
+
+// ListAgents handles GET /agents.
+func (h *AgentHandler) ListAgents(w http.ResponseWriter, r *http.Request) {
+	params := request.ParseAgentQuery(r)
+	if params.State != "" && !params.State.IsValid() {
+		response.Error(w, domain.NewValidationError([]string{"state must be active, idle or stale"}))
+		return
+	}
+
+	svc := service.NewAgentService(middleware.GetDB(r.Context()))
+
+	agents, err := svc.List(service.ListAgentsInput{
+		State:      params.State,
+		StaleAfter: params.StaleAfter,
+	}, time.Now())
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, agents)
+}
+
+// RegisterAgent handles POST /agents. The agent is the one making the request.
+func (h *AgentHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
+	var req request.RegisterAgentRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	agentID := middleware.GetAgentID(r.Context())
+	if agentID == middleware.DefaultAgentID {
+		response.Error(w, domain.NewValidationError([]string{middleware.AgentHeader + " header is required to register"}))
+		return
+	}
+
+	svc := service.NewAgentService(middleware.GetDB(r.Context()))
+
+	agent, err := svc.Register(service.RegisterAgentInput{
+		Name:         req.Name,
+		Capabilities: req.Capabilities,
+		Metadata:     req.Metadata,
+		WIPLimit:     req.WIPLimit,
+	}, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, agent)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// AnalysisHandler handles schedule analysis operations.
+type AnalysisHandler struct{}
+
+// NewAnalysisHandler creates a new AnalysisHandler.
+func NewAnalysisHandler() *AnalysisHandler {
+	return &AnalysisHandler{}
+}
+
+// GetAnalysis handles GET /analysis.
+func (h *AnalysisHandler) GetAnalysis(w http.ResponseWriter, r *http.Request) {
+	params := request.ParseAnalysisQuery(r)
+
+	db := middleware.GetDB(r.Context())
+	svc := service.NewAnalysisService(sqlite.NewGraphRepository(db), sqlite.NewWorkflowRepository(db))
+
+	analysis, err := svc.Schedule(service.AnalysisInput{
+		SpecID: params.SpecID,
+		Limit:  params.Limit,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, analysis)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// CommentHandler handles task comment operations.
+type CommentHandler struct{}
+
+// NewCommentHandler creates a new CommentHandler.
+func NewCommentHandler() *CommentHandler {
+	return &CommentHandler{}
+}
+
+// ListComments handles GET /tasks/{id}/comments.
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	commentRepo := sqlite.NewCommentRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewCommentService(commentRepo, taskRepo, auditRepo)
+
+	comments, err := svc.List(taskID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if comments == nil {
+		comments = []*domain.Comment{}
+	}
+
+	response.OK(w, comments)
+}
+
+// AddComment handles POST /tasks/{id}/comments.
+func (h *CommentHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req request.AddCommentRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	commentRepo := sqlite.NewCommentRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewCommentService(commentRepo, taskRepo, auditRepo)
+
+	comment, err := svc.Add(taskID, req.Body, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, comment)
+}
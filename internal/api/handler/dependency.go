@@ -10,15 +10,19 @@ import (
 	"github.com/airyra/airyra/internal/api/response"
 	"github.com/airyra/airyra/internal/domain"
 	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store"
 	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 // DependencyHandler handles dependency operations.
-type DependencyHandler struct{}
+type DependencyHandler struct {
+	manager *store.Manager
+}
 
-// NewDependencyHandler creates a new DependencyHandler.
-func NewDependencyHandler() *DependencyHandler {
-	return &DependencyHandler{}
+// NewDependencyHandler creates a new DependencyHandler. The manager gives
+// access to other projects, whose tasks can be depended on.
+func NewDependencyHandler(manager *store.Manager) *DependencyHandler {
+	return &DependencyHandler{manager: manager}
 }
 
 // ListDependencies handles GET /tasks/{id}/deps.
@@ -29,9 +33,32 @@ func (h *DependencyHandler) ListDependencies(w http.ResponseWriter, r *http.Requ
 	taskRepo := sqlite.NewTaskRepository(db)
 	depRepo := sqlite.NewDependencyRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo)
+	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo, h.manager, middleware.GetProject(r.Context()))
+
+	deps, err := svc.List(taskID, request.ParseTransitive(r))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if deps == nil {
+		deps = []*domain.Dependency{}
+	}
+
+	response.OK(w, deps)
+}
+
+// ListDependents handles GET /tasks/{id}/dependents.
+func (h *DependencyHandler) ListDependents(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	depRepo := sqlite.NewDependencyRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo, h.manager, middleware.GetProject(r.Context()))
 
-	deps, err := svc.List(taskID)
+	deps, err := svc.ListDependents(taskID, request.ParseTransitive(r))
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -65,7 +92,7 @@ func (h *DependencyHandler) AddDependency(w http.ResponseWriter, r *http.Request
 	taskRepo := sqlite.NewTaskRepository(db)
 	depRepo := sqlite.NewDependencyRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo)
+	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo, h.manager, middleware.GetProject(r.Context()))
 
 	if err := svc.Add(taskID, req.ParentID, agentID); err != nil {
 		response.Error(w, err)
@@ -78,10 +105,11 @@ func (h *DependencyHandler) AddDependency(w http.ResponseWriter, r *http.Request
 	})
 }
 
-// RemoveDependency handles DELETE /tasks/{id}/deps/{depID}.
+// RemoveDependency handles DELETE /tasks/{id}/deps/{depID} and, for a task in
+// another project, DELETE /tasks/{id}/deps/{depProject}/{depID}.
 func (h *DependencyHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
-	depID := chi.URLParam(r, "depID")
+	depID := domain.TaskRef(chi.URLParam(r, "depProject"), chi.URLParam(r, "depID"))
 
 	db := middleware.GetDB(r.Context())
 	agentID := middleware.GetAgentID(r.Context())
@@ -89,7 +117,7 @@ func (h *DependencyHandler) RemoveDependency(w http.ResponseWriter, r *http.Requ
 	taskRepo := sqlite.NewTaskRepository(db)
 	depRepo := sqlite.NewDependencyRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo)
+	svc := service.NewDependencyService(depRepo, taskRepo, auditRepo, h.manager, middleware.GetProject(r.Context()))
 
 	if err := svc.Remove(taskID, depID, agentID); err != nil {
 		response.Error(w, err)
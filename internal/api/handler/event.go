@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+)
+
+// EventHandler handles the external events that deferred tasks wait on.
+type EventHandler struct{}
+
+// NewEventHandler creates a new EventHandler.
+func NewEventHandler() *EventHandler {
+	return &EventHandler{}
+}
+
+// SignalEvent handles POST /events/{name}. It responds with the tasks that
+// were waiting on the event.
+func (h *EventHandler) SignalEvent(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if !domain.ValidEventName(name) {
+		response.Error(w, domain.NewValidationError([]string{domain.EventNameRule}))
+		return
+	}
+
+	agentID := middleware.GetAgentID(r.Context())
+	svc := service.NewEventService(middleware.GetDB(r.Context()))
+
+	tasks, err := svc.Signal(name, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if tasks == nil {
+		tasks = []*domain.Task{}
+	}
+
+	response.OK(w, tasks)
+}
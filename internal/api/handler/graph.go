@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// GraphHandler handles project graph operations.
+type GraphHandler struct{}
+
+// NewGraphHandler creates a new GraphHandler.
+func NewGraphHandler() *GraphHandler {
+	return &GraphHandler{}
+}
+
+// GetGraph handles GET /graph.
+func (h *GraphHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
+	params := request.ParseGraphQuery(r)
+	if errors := params.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	svc := service.NewGraphService(sqlite.NewGraphRepository(db))
+
+	graph, err := svc.Get(service.GraphInput{
+		SpecID:    params.SpecID,
+		TaskID:    params.TaskID,
+		Direction: params.Direction,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, graph)
+}
@@ -10,13 +10,17 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/airyra/airyra/internal/api"
+	"github.com/airyra/airyra/internal/api/handler"
 	"github.com/airyra/airyra/internal/api/middleware"
 	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
 	"github.com/airyra/airyra/internal/store"
 )
 
@@ -72,6 +76,20 @@ func (s *testSetup) doRequest(method, path string, body interface{}, headers map
 	return rr
 }
 
+// createTask creates a task in the testproj project and returns its ID
+func (s *testSetup) createTask(t *testing.T, title string) string {
+	t.Helper()
+
+	rr := s.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{"title": title}, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("failed to create task: %d %s", rr.Code, rr.Body.String())
+	}
+
+	var created map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&created)
+	return created["id"].(string)
+}
+
 // ========================
 // System Tests
 // ========================
@@ -371,269 +389,768 @@ func TestListReadyTasks(t *testing.T) {
 	}
 }
 
-func TestUpdateTask_Success(t *testing.T) {
+func TestListReadyTasks_EffectivePriority(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create a task first
-	createBody := map[string]interface{}{"title": "Original Title"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
-
-	// Update the task
-	updateBody := map[string]interface{}{"title": "Updated Title"}
-	rr := setup.doRequest("PATCH", fmt.Sprintf("/v1/projects/testproj/tasks/%s", taskID), updateBody, nil)
+	create := func(title string, priority int, specID string) string {
+		body := map[string]interface{}{"title": title, "priority": priority}
+		if specID != "" {
+			body["spec_id"] = specID
+		}
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+		var task map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task["id"].(string)
+	}
+	createSpec := func(title string) string {
+		rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": title}, nil)
+		var spec map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&spec)
+		return spec["id"].(string)
+	}
+
+	normalID := create("Normal", domain.PriorityNormal, "")
+	schemaID := create("Schema", domain.PriorityLow, "")
+	releaseID := create("Release", domain.PriorityCritical, "")
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", releaseID),
+		map[string]interface{}{"parent_id": schemaID}, nil)
+
+	// Tasks of a spec inherit from the tasks of the specs depending on it
+	storageID := createSpec("Storage")
+	apiID := createSpec("API")
+	migrateID := create("Migrate", domain.PriorityLowest, storageID)
+	shipID := create("Ship", domain.PriorityHigh, apiID)
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/specs/%s/deps", apiID),
+		map[string]interface{}{"parent_id": storageID}, nil)
 
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
 	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	var task map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+	var resp struct {
+		Data []domain.Task `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	if task["title"] != "Updated Title" {
-		t.Errorf("expected title 'Updated Title', got %v", task["title"])
+	want := []struct {
+		id        string
+		effective int
+	}{
+		{schemaID, domain.PriorityCritical},
+		{shipID, domain.PriorityHigh},
+		{migrateID, domain.PriorityHigh},
+		{normalID, domain.PriorityNormal},
+	}
+	if len(resp.Data) != len(want) {
+		t.Fatalf("expected %d ready tasks, got %+v", len(want), resp.Data)
+	}
+	for i, w := range want {
+		got := resp.Data[i]
+		if got.ID != w.id || got.EffectivePriority == nil || *got.EffectivePriority != w.effective {
+			t.Errorf("ready[%d] = %s (effective %v), want %s (effective %d)", i, got.ID, got.EffectivePriority, w.id, w.effective)
+		}
+	}
+	if resp.Data[0].Priority != domain.PriorityLow {
+		t.Errorf("expected base priority to be kept, got %d", resp.Data[0].Priority)
 	}
 }
 
-func TestDeleteTask_Success(t *testing.T) {
+func TestListReadyTasks_DueSoonFirst(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create a task first
-	createBody := map[string]interface{}{"title": "Task to delete"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
-
-	// Delete the task
-	rr := setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", taskID), nil, nil)
+	create := func(title string, priority int, dueAt time.Time) string {
+		body := map[string]interface{}{"title": title, "priority": priority}
+		if !dueAt.IsZero() {
+			body["due_at"] = dueAt.Format(time.RFC3339)
+		}
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+		var task map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task["id"].(string)
+	}
+
+	now := time.Now()
+	criticalID := create("Critical", domain.PriorityCritical, time.Time{})
+	nextWeekID := create("Next week", domain.PriorityHigh, now.AddDate(0, 0, 7))
+	tonightID := create("Tonight", domain.PriorityLowest, now.Add(6*time.Hour))
+	overdueID := create("Overdue", domain.PriorityLow, now.Add(-time.Hour))
+	// Blocks a task due in two hours, so it is due in two hours too
+	prepID := create("Prep", domain.PriorityLowest, time.Time{})
+	demoID := create("Demo", domain.PriorityNormal, now.Add(2*time.Hour))
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", demoID),
+		map[string]interface{}{"parent_id": prepID}, nil)
 
-	if rr.Code != http.StatusNoContent {
-		t.Errorf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	var resp struct {
+		Data []domain.Task `json:"data"`
 	}
+	json.NewDecoder(rr.Body).Decode(&resp)
 
-	// Verify it's gone
-	getRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", taskID), nil, nil)
-	if getRR.Code != http.StatusNotFound {
-		t.Errorf("expected task to be deleted (404), got %d", getRR.Code)
+	want := []string{overdueID, prepID, tonightID, criticalID, nextWeekID}
+	if len(resp.Data) != len(want) {
+		t.Fatalf("expected %d ready tasks, got %+v", len(want), resp.Data)
+	}
+	for i, id := range want {
+		if resp.Data[i].ID != id {
+			t.Errorf("ready[%d] = %s (%s), want %s", i, resp.Data[i].ID, resp.Data[i].Title, id)
+		}
+	}
+	if prep := resp.Data[1]; prep.DueAt != nil || prep.EffectiveDueAt == nil {
+		t.Errorf("expected Prep to inherit the due date of Demo, got %+v", prep)
 	}
 }
 
-// ========================
-// Status Transition Tests
-// ========================
-
-func TestClaimTask_Success(t *testing.T) {
+func TestListReadyTasks_Deferred(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create a task
-	createBody := map[string]interface{}{"title": "Task to claim"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
-
-	// Claim the task
-	headers := map[string]string{middleware.AgentHeader: "agent-123"}
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
-
+	create := func(title string, deferUntil time.Time) string {
+		body := map[string]interface{}{"title": title}
+		if !deferUntil.IsZero() {
+			body["defer_until"] = deferUntil.Format(time.RFC3339)
+		}
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+		var task map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task["id"].(string)
+	}
+
+	now := time.Now()
+	laterID := create("Later", now.Add(24*time.Hour))
+	surfacedID := create("Surfaced", now.Add(-time.Hour))
+	plainID := create("Plain", time.Time{})
+
+	readyIDs := func() []string {
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+		var resp struct {
+			Data       []domain.Task `json:"data"`
+			Pagination struct {
+				Total int `json:"total"`
+			} `json:"pagination"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		var ids []string
+		for _, task := range resp.Data {
+			ids = append(ids, task.ID)
+		}
+		if resp.Pagination.Total != len(ids) {
+			t.Errorf("expected total %d, got %d", len(ids), resp.Pagination.Total)
+		}
+		return ids
+	}
+
+	// Both tasks share a priority and may share a creation second, so their
+	// order is not checked
+	ids := readyIDs()
+	if len(ids) != 2 || !slices.Contains(ids, surfacedID) || !slices.Contains(ids, plainID) {
+		t.Errorf("expected only the tasks not deferred to be ready, got %v", ids)
+	}
+
+	rr := setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+laterID,
+		map[string]interface{}{"defer_until": ""}, nil)
 	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.DeferUntil != nil {
+		t.Errorf("expected defer_until to be cleared, got %v", task.DeferUntil)
+	}
+	if ids := readyIDs(); len(ids) != 3 {
+		t.Errorf("expected the undeferred task to be ready, got %v", ids)
 	}
 
-	var task map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+laterID,
+		map[string]interface{}{"defer_until": "next week"}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid defer_until, got %d", rr.Code)
 	}
+}
 
-	if task["status"] != "in_progress" {
-		t.Errorf("expected status 'in_progress', got %v", task["status"])
+func TestSignalEvent(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	create := func(title string, body map[string]interface{}) string {
+		body["title"] = title
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var task domain.Task
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task.ID
+	}
+
+	waitingID := create("Smoke test", map[string]interface{}{"defer_event": "deploy-done"})
+	laterID := create("Announce", map[string]interface{}{
+		"defer_event": "deploy-done",
+		"defer_until": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+	})
+	create("Migrate", map[string]interface{}{"defer_event": "backup-done"})
+	plainID := create("Plain", map[string]interface{}{})
+
+	readyIDs := func() []string {
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+		var resp struct {
+			Data []domain.Task `json:"data"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		var ids []string
+		for _, task := range resp.Data {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	signal := func(event string) []domain.Task {
+		rr := setup.doRequest("POST", "/v1/projects/testproj/events/"+event, nil,
+			map[string]string{middleware.AgentHeader: "deployer"})
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var tasks []domain.Task
+		json.NewDecoder(rr.Body).Decode(&tasks)
+		return tasks
+	}
+
+	if ids := readyIDs(); len(ids) != 1 || ids[0] != plainID {
+		t.Errorf("expected only the task waiting on no event to be ready, got %v", ids)
+	}
+
+	signalled := signal("deploy-done")
+	if len(signalled) != 2 {
+		t.Fatalf("expected the two tasks waiting on deploy-done, got %+v", signalled)
+	}
+	for _, task := range signalled {
+		if task.DeferEvent != nil {
+			t.Errorf("expected %s to stop waiting, got %q", task.ID, *task.DeferEvent)
+		}
+	}
+
+	// The task also deferred to a later time stays out until then
+	ids := readyIDs()
+	if len(ids) != 2 || !slices.Contains(ids, waitingID) || slices.Contains(ids, laterID) {
+		t.Errorf("expected the signalled task to be ready, got %v", ids)
+	}
+
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/"+waitingID+"/history", nil, nil)
+	var history []domain.AuditEntry
+	json.NewDecoder(rr.Body).Decode(&history)
+	found := false
+	for _, entry := range history {
+		if entry.Action == domain.ActionSignal && entry.OldValue != nil && *entry.OldValue == "deploy-done" && entry.ChangedBy == "deployer" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a signal audit entry, got %+v", history)
+	}
+
+	if tasks := signal("deploy-done"); len(tasks) != 0 {
+		t.Errorf("expected no task left waiting on deploy-done, got %+v", tasks)
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+plainID,
+		map[string]interface{}{"defer_event": "deploy/done"}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid event name, got %d", rr.Code)
 	}
-	if task["claimed_by"] != "agent-123" {
-		t.Errorf("expected claimed_by 'agent-123', got %v", task["claimed_by"])
+}
+
+func TestDueDates(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	yesterday := time.Now().AddDate(0, 0, -1).Truncate(time.Second)
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{
+		"title":  "Renew certificate",
+		"due_at": yesterday.Format(time.RFC3339),
+	}, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var late domain.Task
+	json.NewDecoder(rr.Body).Decode(&late)
+	if late.DueAt == nil || !late.DueAt.Equal(yesterday) {
+		t.Errorf("expected due date %v, got %v", yesterday, late.DueAt)
+	}
+
+	onTimeID := setup.createTask(t, "On time")
+	setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+onTimeID, map[string]interface{}{
+		"due_at": time.Now().AddDate(0, 0, 1).Format(time.RFC3339),
+	}, nil)
+	setup.createTask(t, "Whenever")
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks?overdue=true", nil, nil)
+	var tasks struct {
+		Data []domain.Task `json:"data"`
+	}
+	json.NewDecoder(rr.Body).Decode(&tasks)
+	if len(tasks.Data) != 1 || tasks.Data[0].ID != late.ID {
+		t.Errorf("expected only %s to be overdue, got %+v", late.ID, tasks.Data)
+	}
+
+	// Clearing the due date is recorded in the history
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+late.ID, map[string]interface{}{"due_at": ""}, nil)
+	var cleared domain.Task
+	json.NewDecoder(rr.Body).Decode(&cleared)
+	if cleared.DueAt != nil {
+		t.Errorf("expected the due date to be cleared, got %v", cleared.DueAt)
+	}
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks?overdue=true", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&tasks)
+	if len(tasks.Data) != 0 {
+		t.Errorf("expected no overdue task, got %+v", tasks.Data)
+	}
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+late.ID+"/history", nil, nil)
+	var history []domain.AuditEntry
+	json.NewDecoder(rr.Body).Decode(&history)
+	found := false
+	for _, entry := range history {
+		if entry.Field != nil && *entry.Field == "due_at" && entry.NewValue == nil {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the cleared due date in the history, got %+v", history)
+	}
+
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{
+		"title":  "Bad date",
+		"due_at": "next tuesday",
+	}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid due date, got %d", rr.Code)
 	}
 }
 
-func TestClaimTask_AlreadyClaimed(t *testing.T) {
+func TestListSpecs_Overdue(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create a task
-	createBody := map[string]interface{}{"title": "Task to claim"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
+	createSpec := func(title string, dueAt time.Time) string {
+		rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{
+			"title":  title,
+			"due_at": dueAt.Format(time.RFC3339),
+		}, nil)
+		var spec map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&spec)
+		return spec["id"].(string)
+	}
 
-	// First agent claims
-	headers1 := map[string]string{middleware.AgentHeader: "agent-1"}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers1)
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	lateID := createSpec("Late", lastWeek)
+	shippedID := createSpec("Shipped", lastWeek)
+	createSpec("Upcoming", time.Now().AddDate(0, 0, 7))
 
-	// Second agent tries to claim
-	headers2 := map[string]string{middleware.AgentHeader: "agent-2"}
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers2)
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{"title": "Ship it", "spec_id": shippedID}, nil)
+	var task map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&task)
+	setup.completeTask(t, task["id"].(string), "agent-1")
 
-	if rr.Code != http.StatusConflict {
-		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	rr = setup.doRequest("GET", "/v1/projects/testproj/specs?overdue=true", nil, nil)
+	var resp struct {
+		Data []handler.SpecResponse `json:"data"`
+	}
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Data) != 1 || resp.Data[0].ID != lateID || resp.Data[0].DueAt == nil {
+		t.Errorf("expected only %s to be overdue, got %+v", lateID, resp.Data)
 	}
+}
 
-	var resp response.ErrorResponse
-	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+func TestListReadyTasks_SpecGate(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	createInSpec := func(title, specID string) string {
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks",
+			map[string]interface{}{"title": title, "spec_id": specID}, nil)
+		var task map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task["id"].(string)
+	}
+	createSpec := func(title string) string {
+		rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": title}, nil)
+		var spec map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&spec)
+		return spec["id"].(string)
+	}
+	ready := func() []domain.Task {
+		t.Helper()
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+		var resp struct {
+			Data []domain.Task `json:"data"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return resp.Data
+	}
+	get := func(path string) domain.Task {
+		t.Helper()
+		var task domain.Task
+		rr := setup.doRequest("GET", "/v1/projects/testproj"+path, nil, nil)
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task
+	}
+
+	storageID := createSpec("Storage")
+	apiID := createSpec("API")
+	migrateID := createInSpec("Migrate", storageID)
+	shipID := createInSpec("Ship", apiID)
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/specs/%s/deps", apiID),
+		map[string]interface{}{"parent_id": storageID}, nil)
+
+	if tasks := ready(); len(tasks) != 2 {
+		t.Fatalf("expected spec dependencies not to gate tasks by default, got %+v", tasks)
+	}
+	if task := get("/tasks/" + shipID); task.WaitingOnSpecs != nil {
+		t.Errorf("expected no spec gate by default, got %v", task.WaitingOnSpecs)
+	}
+
+	rr := setup.doRequest("PATCH", "/v1/projects/testproj/settings",
+		map[string]interface{}{"spec_dependencies_gate_tasks": true}, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	if resp.Error.Code != "ALREADY_CLAIMED" {
-		t.Errorf("expected code 'ALREADY_CLAIMED', got %q", resp.Error.Code)
+	if tasks := ready(); len(tasks) != 1 || tasks[0].ID != migrateID {
+		t.Errorf("expected only %s to be ready, got %+v", migrateID, tasks)
+	}
+	for _, path := range []string{"/tasks/" + shipID, "/tasks/" + shipID + "/tree"} {
+		if task := get(path); len(task.WaitingOnSpecs) != 1 || task.WaitingOnSpecs[0] != storageID {
+			t.Errorf("GET %s: expected the task to wait on %s, got %v", path, storageID, task.WaitingOnSpecs)
+		}
+	}
+
+	setup.completeTask(t, migrateID, "agent-1")
+
+	if tasks := ready(); len(tasks) != 1 || tasks[0].ID != shipID {
+		t.Errorf("expected %s to be ready once its parent spec is done, got %+v", shipID, tasks)
+	}
+	if task := get("/tasks/" + shipID); task.WaitingOnSpecs != nil {
+		t.Errorf("expected no spec gate once the parent spec is done, got %v", task.WaitingOnSpecs)
 	}
 }
 
-func TestCompleteTask_Success(t *testing.T) {
+func TestGetSpecProgress(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create and claim a task
-	createBody := map[string]interface{}{"title": "Task to complete"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
-
-	headers := map[string]string{middleware.AgentHeader: "agent-123"}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+	rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": "Storage"}, nil)
+	var spec map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&spec)
+	specID := spec["id"].(string)
 
-	// Complete the task
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, headers)
+	var taskIDs []string
+	for _, title := range []string{"Schema", "Migrate", "Backfill", "Cleanup"} {
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks",
+			map[string]interface{}{"title": title, "spec_id": specID}, nil)
+		var task map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&task)
+		taskIDs = append(taskIDs, task["id"].(string))
+	}
+	setup.completeTask(t, taskIDs[0], "agent-1")
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskIDs[3]+"/cancel", nil, nil)
 
+	rr = setup.doRequest("GET", "/v1/projects/testproj/specs/"+specID+"/progress", nil, nil)
 	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	var task map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	var progress domain.SpecProgress
+	json.NewDecoder(rr.Body).Decode(&progress)
+	if progress.Total != 3 || progress.Done != 1 || progress.Remaining != 2 {
+		t.Errorf("expected 1 of 3 tasks done, got %+v", progress)
+	}
+	if progress.Percent != 33.3 {
+		t.Errorf("expected 33.3 percent complete, got %v", progress.Percent)
+	}
+	if progress.ByStatus["done"] != 1 || progress.ByStatus["open"] != 2 || progress.ByStatus["cancelled"] != 1 {
+		t.Errorf("unexpected counts by status: %v", progress.ByStatus)
+	}
+	today := time.Now().UTC().Format(domain.DateFormat)
+	if len(progress.CompletedPerDay) != 1 || progress.CompletedPerDay[0] != (domain.DailyCount{Date: today, Count: 1}) {
+		t.Errorf("expected one task completed today, got %+v", progress.CompletedPerDay)
+	}
+	if progress.EstimatedCompletion == nil {
+		t.Error("expected an estimated completion date")
+	}
+	if n := len(progress.Burndown); n == 0 || progress.Burndown[n-1] != (domain.BurndownPoint{Date: today, Remaining: 2}) {
+		t.Errorf("expected the burndown to end today with 2 remaining, got %+v", progress.Burndown)
 	}
 
-	if task["status"] != "done" {
-		t.Errorf("expected status 'done', got %v", task["status"])
+	rr = setup.doRequest("GET", "/v1/projects/testproj/specs/sp-missing/progress", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
 	}
 }
 
-func TestCompleteTask_NotOwner(t *testing.T) {
+func TestInstantiateSpec(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create and claim a task with one agent
-	createBody := map[string]interface{}{"title": "Task to complete"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
-
-	headers1 := map[string]string{middleware.AgentHeader: "agent-1"}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers1)
+	template := map[string]interface{}{
+		"title": "Add {{name}} endpoint",
+		"vars":  map[string]string{"version": "v1"},
+		"tasks": []map[string]interface{}{
+			{"key": "design", "title": "Design /{{version}}/{{name}}", "priority": 1},
+			{"key": "implement", "title": "Implement {{name}}", "depends_on": []string{"design"}},
+			{"key": "test", "title": "Test {{name}}", "depends_on": []string{"implement"}},
+			{"key": "docs", "title": "Document {{name}}", "depends_on": []string{"design"}},
+		},
+	}
 
-	// Try to complete with another agent
-	headers2 := map[string]string{middleware.AgentHeader: "agent-2"}
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, headers2)
+	rr := setup.doRequest("POST", "/v1/projects/testproj/specs/instantiate",
+		map[string]interface{}{"template": template, "vars": map[string]string{"name": "users"}}, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
 
-	if rr.Code != http.StatusForbidden {
-		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	var instance handler.SpecInstanceResponse
+	json.NewDecoder(rr.Body).Decode(&instance)
+	if instance.Spec.Title != "Add users endpoint" || instance.Spec.TaskCount != 4 {
+		t.Errorf("unexpected spec: %+v", instance.Spec)
+	}
+	if len(instance.Tasks) != 4 {
+		t.Fatalf("expected 4 tasks, got %d", len(instance.Tasks))
+	}
+	design, implement := instance.Tasks[0], instance.Tasks[1]
+	if design.Title != "Design /v1/users" || design.Priority != 1 {
+		t.Errorf("unexpected design task: %+v", design)
+	}
+	if design.SpecID == nil || *design.SpecID != instance.Spec.ID {
+		t.Errorf("expected the tasks to belong to %s, got %v", instance.Spec.ID, design.SpecID)
 	}
 
-	var resp response.ErrorResponse
-	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+implement.ID+"/deps", nil, nil)
+	var deps []domain.Dependency
+	json.NewDecoder(rr.Body).Decode(&deps)
+	if len(deps) != 1 || deps[0].ParentID != design.ID {
+		t.Errorf("expected %s to depend on %s, got %+v", implement.ID, design.ID, deps)
 	}
 
-	if resp.Error.Code != "NOT_OWNER" {
-		t.Errorf("expected code 'NOT_OWNER', got %q", resp.Error.Code)
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	var ready struct {
+		Data []domain.Task `json:"data"`
+	}
+	json.NewDecoder(rr.Body).Decode(&ready)
+	if len(ready.Data) != 1 || ready.Data[0].ID != design.ID {
+		t.Errorf("expected only %s to be ready, got %+v", design.ID, ready.Data)
 	}
 }
 
-func TestReleaseTask_Success(t *testing.T) {
+func TestInstantiateSpec_Invalid(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create and claim a task
-	createBody := map[string]interface{}{"title": "Task to release"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"missing template", map[string]interface{}{}},
+		{"missing variable", map[string]interface{}{
+			"template": map[string]interface{}{"title": "Add {{name}} endpoint"},
+		}},
+		{"dependency cycle", map[string]interface{}{
+			"template": map[string]interface{}{
+				"title": "Cycle",
+				"tasks": []map[string]interface{}{
+					{"key": "a", "title": "A", "depends_on": []string{"b"}},
+					{"key": "b", "title": "B", "depends_on": []string{"a"}},
+				},
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := setup.doRequest("POST", "/v1/projects/testproj/specs/instantiate", tt.body, nil)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	rr := setup.doRequest("GET", "/v1/projects/testproj/specs", nil, nil)
+	var specs struct {
+		Data []handler.SpecResponse `json:"data"`
+	}
+	json.NewDecoder(rr.Body).Decode(&specs)
+	if len(specs.Data) != 0 {
+		t.Errorf("expected no spec to be created, got %+v", specs.Data)
+	}
+}
 
-	headers := map[string]string{middleware.AgentHeader: "agent-123"}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+func TestSchedules(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
 
-	// Release the task
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/release", taskID), nil, headers)
+	rr := setup.doRequest("POST", "/v1/projects/testproj/schedules", map[string]interface{}{
+		"cron":     "0 9 * * mon",
+		"title":    "Rotate test fixtures ({{date}})",
+		"priority": 1,
+	}, map[string]string{middleware.AgentHeader: "test-agent"})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var schedule domain.Schedule
+	json.NewDecoder(rr.Body).Decode(&schedule)
+	if schedule.CatchUp != domain.CatchUpOnce || schedule.CreatedBy != "test-agent" {
+		t.Errorf("unexpected schedule: %+v", schedule)
+	}
+	if next := schedule.NextRunAt.Local(); next.Weekday() != time.Monday || next.Hour() != 9 || !next.After(time.Now()) {
+		t.Errorf("expected the next run on a Monday at 09:00, got %v", next)
+	}
 
-	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	rr = setup.doRequest("GET", "/v1/projects/testproj/schedules", nil, nil)
+	var schedules []domain.Schedule
+	json.NewDecoder(rr.Body).Decode(&schedules)
+	if len(schedules) != 1 || schedules[0].ID != schedule.ID {
+		t.Errorf("expected the schedule to be listed, got %+v", schedules)
 	}
 
-	var task map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	rr = setup.doRequest("POST", "/v1/projects/testproj/schedules/"+schedule.ID+"/run", nil, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	want := "Rotate test fixtures (" + time.Now().Format(domain.DateFormat) + ")"
+	if task.Title != want || task.Priority != 1 {
+		t.Errorf("expected a task titled %q with priority 1, got %+v", want, task)
 	}
 
-	if task["status"] != "open" {
-		t.Errorf("expected status 'open', got %v", task["status"])
+	rr = setup.doRequest("GET", "/v1/projects/testproj/schedules/"+schedule.ID, nil, nil)
+	var ran domain.Schedule
+	json.NewDecoder(rr.Body).Decode(&ran)
+	if ran.LastTaskID == nil || *ran.LastTaskID != task.ID || !ran.NextRunAt.Equal(schedule.NextRunAt) {
+		t.Errorf("expected the run to be recorded without moving the next run, got %+v", ran)
 	}
-	if task["claimed_by"] != nil {
-		t.Errorf("expected claimed_by to be nil, got %v", task["claimed_by"])
+
+	rr = setup.doRequest("DELETE", "/v1/projects/testproj/schedules/"+schedule.ID, nil, nil)
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", rr.Code)
+	}
+	rr = setup.doRequest("GET", "/v1/projects/testproj/schedules/"+schedule.ID, nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+task.ID, nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected the created task to outlive its schedule, got %d", rr.Code)
 	}
 }
 
-func TestBlockTask_Success(t *testing.T) {
+func TestCreateSchedule_Invalid(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create a task
-	createBody := map[string]interface{}{"title": "Task to block"}
-	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
-	var created map[string]interface{}
-	json.NewDecoder(createRR.Body).Decode(&created)
-	taskID := created["id"].(string)
+	tests := []struct {
+		name string
+		body map[string]interface{}
+		code int
+	}{
+		{"missing cron", map[string]interface{}{"title": "Standup"}, http.StatusBadRequest},
+		{"invalid cron", map[string]interface{}{"cron": "0 25 * * *", "title": "Standup"}, http.StatusBadRequest},
+		{"never matches", map[string]interface{}{"cron": "0 0 30 2 *", "title": "Standup"}, http.StatusBadRequest},
+		{"invalid catch-up", map[string]interface{}{"cron": "@daily", "title": "Standup", "catch_up": "sometimes"}, http.StatusBadRequest},
+		{"unknown spec", map[string]interface{}{"cron": "@daily", "title": "Standup", "spec_id": "sp-missing"}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := setup.doRequest("POST", "/v1/projects/testproj/schedules", tt.body, nil)
+			if rr.Code != tt.code {
+				t.Errorf("expected status %d, got %d: %s", tt.code, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
 
-	// Block the task
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), nil, nil)
+func TestAgents(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
 
+	worker := map[string]string{middleware.AgentHeader: "worker-1"}
+	rr := setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{
+		"name":         "Builder",
+		"capabilities": []string{"go", "sql"},
+		"metadata":     map[string]string{"host": "ci-3"},
+	}, worker)
 	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var registered domain.Agent
+	json.NewDecoder(rr.Body).Decode(&registered)
+	if registered.ID != "worker-1" || registered.Name == nil || *registered.Name != "Builder" ||
+		len(registered.Capabilities) != 2 || registered.Metadata["host"] != "ci-3" ||
+		registered.RegisteredAt == nil || registered.State != domain.AgentIdle {
+		t.Errorf("unexpected registered agent: %+v", registered)
 	}
 
-	var task map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	// Any request marks an agent as seen, registered or not
+	taskID := setup.createTask(t, "Ship it")
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/claim", nil, worker)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to claim task: %d %s", rr.Code, rr.Body.String())
 	}
+	setup.doRequest("GET", "/v1/projects/testproj/tasks", nil, map[string]string{middleware.AgentHeader: "watcher"})
 
-	if task["status"] != "blocked" {
-		t.Errorf("expected status 'blocked', got %v", task["status"])
+	rr = setup.doRequest("GET", "/v1/projects/testproj/agents", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var agents []domain.Agent
+	json.NewDecoder(rr.Body).Decode(&agents)
+	states := map[string]domain.AgentState{}
+	for _, agent := range agents {
+		states[agent.ID] = agent.State
+		if agent.ID == "worker-1" && (len(agent.Claims) != 1 || agent.Claims[0].TaskID != taskID) {
+			t.Errorf("expected worker-1 to hold %s, got %+v", taskID, agent.Claims)
+		}
+		if agent.ID == "watcher" && agent.RegisteredAt != nil {
+			t.Errorf("expected watcher to be unregistered, got %+v", agent)
+		}
+	}
+	if len(agents) != 2 || states["worker-1"] != domain.AgentActive || states["watcher"] != domain.AgentIdle {
+		t.Errorf("expected worker-1 active and watcher idle, got %+v", states)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/agents?state=active", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&agents)
+	if len(agents) != 1 || agents[0].ID != "worker-1" {
+		t.Errorf("expected only worker-1 to be active, got %+v", agents)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/agents?state=busy", nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown state, got %d", rr.Code)
+	}
+
+	rr = setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{"name": "Nobody"}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an anonymous registration, got %d", rr.Code)
 	}
 }
 
-func TestUnblockTask_Success(t *testing.T) {
+func TestUpdateTask_Success(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create and block a task
-	createBody := map[string]interface{}{"title": "Task to unblock"}
+	// Create a task first
+	createBody := map[string]interface{}{"title": "Original Title"}
 	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
 	var created map[string]interface{}
 	json.NewDecoder(createRR.Body).Decode(&created)
 	taskID := created["id"].(string)
 
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), nil, nil)
-
-	// Unblock the task
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/unblock", taskID), nil, nil)
+	// Update the task
+	updateBody := map[string]interface{}{"title": "Updated Title"}
+	rr := setup.doRequest("PATCH", fmt.Sprintf("/v1/projects/testproj/tasks/%s", taskID), updateBody, nil)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
@@ -644,203 +1161,2425 @@ func TestUnblockTask_Success(t *testing.T) {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	if task["status"] != "open" {
-		t.Errorf("expected status 'open', got %v", task["status"])
+	if task["title"] != "Updated Title" {
+		t.Errorf("expected title 'Updated Title', got %v", task["title"])
+	}
+}
+
+func TestUpdateTask_Estimate(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{"title": "Estimated", "estimate": 90}, nil)
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.Estimate == nil || *task.Estimate != 90 {
+		t.Fatalf("expected estimate 90, got %v", task.Estimate)
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+task.ID, map[string]interface{}{"estimate": 0}, nil)
+	task = domain.Task{}
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.Estimate != nil {
+		t.Errorf("expected estimate 0 to clear the estimate, got %d", *task.Estimate)
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+task.ID, map[string]interface{}{"estimate": -5}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for negative estimate, got %d", rr.Code)
+	}
+}
+
+func TestDeleteTask_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create a task first
+	createBody := map[string]interface{}{"title": "Task to delete"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	// Delete the task
+	rr := setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", taskID), nil, nil)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Verify it's gone
+	getRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", taskID), nil, nil)
+	if getRR.Code != http.StatusNotFound {
+		t.Errorf("expected task to be deleted (404), got %d", getRR.Code)
 	}
 }
 
 // ========================
-// Dependency Tests
+// Status Transition Tests
 // ========================
 
-func TestAddDependency_Success(t *testing.T) {
+func TestClaimTask_Success(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create two tasks
-	body1 := map[string]interface{}{"title": "Child Task"}
-	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
-	var child map[string]interface{}
-	json.NewDecoder(rr1.Body).Decode(&child)
+	// Create a task
+	createBody := map[string]interface{}{"title": "Task to claim"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
 
-	body2 := map[string]interface{}{"title": "Parent Task"}
-	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
-	var parent map[string]interface{}
-	json.NewDecoder(rr2.Body).Decode(&parent)
+	// Claim the task
+	headers := map[string]string{middleware.AgentHeader: "agent-123"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
 
-	// Add dependency: child depends on parent
-	depBody := map[string]interface{}{"parent_id": parent["id"]}
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), depBody, nil)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
 
-	if rr.Code != http.StatusCreated {
-		t.Errorf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	var task map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if task["status"] != "in_progress" {
+		t.Errorf("expected status 'in_progress', got %v", task["status"])
+	}
+	if task["claimed_by"] != "agent-123" {
+		t.Errorf("expected claimed_by 'agent-123', got %v", task["claimed_by"])
 	}
 }
 
-func TestAddDependency_Cycle(t *testing.T) {
+func TestClaimTask_LogsClaimedFromState(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create two tasks
-	body1 := map[string]interface{}{"title": "Task A"}
-	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
-	var taskA map[string]interface{}
-	json.NewDecoder(rr1.Body).Decode(&taskA)
+	workflow := domain.DefaultWorkflow()
+	workflow.States = append(workflow.States, domain.WorkflowState{Name: "triaged", Claimable: true})
+	workflow.Transitions = append(workflow.Transitions,
+		domain.WorkflowTransition{From: domain.StatusOpen, To: "triaged"},
+		domain.WorkflowTransition{From: "triaged", To: domain.StatusInProgress})
+	if rr := setup.doRequest("PUT", "/v1/projects/testproj/workflow", workflow, nil); rr.Code != http.StatusOK {
+		t.Fatalf("failed to set workflow: %d %s", rr.Code, rr.Body.String())
+	}
 
-	body2 := map[string]interface{}{"title": "Task B"}
-	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
-	var taskB map[string]interface{}
-	json.NewDecoder(rr2.Body).Decode(&taskB)
+	taskID := setup.createTask(t, "Triaged task")
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/transition", taskID),
+		map[string]interface{}{"status": "triaged"}, nil)
 
-	// Add A -> B dependency
-	depBody1 := map[string]interface{}{"parent_id": taskB["id"]}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", taskA["id"]), depBody1, nil)
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil,
+		map[string]string{middleware.AgentHeader: "agent-1"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
 
-	// Try to add B -> A dependency (would create cycle)
-	depBody2 := map[string]interface{}{"parent_id": taskA["id"]}
-	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", taskB["id"]), depBody2, nil)
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/history", taskID), nil, nil)
+	var history []map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&history)
+	last := history[len(history)-1]
+	if last["action"] != "claim" || last["old_value"] != "triaged" {
+		t.Errorf("expected the claim to be logged from triaged, got %v", last)
+	}
+}
 
-	if rr.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+func TestClaimTask_WIPLimits(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("PATCH", "/v1/projects/testproj/settings",
+		map[string]interface{}{"agent_wip_limit": 2, "project_wip_limit": 3}, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to set WIP limits: %d %s", rr.Code, rr.Body.String())
 	}
 
-	var resp response.ErrorResponse
-	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, setup.createTask(t, fmt.Sprintf("Task %d", i)))
+	}
+	claim := func(id, agent string) *httptest.ResponseRecorder {
+		return setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/claim", nil,
+			map[string]string{middleware.AgentHeader: agent})
+	}
+	wipError := func(rr *httptest.ResponseRecorder, scope string) {
+		t.Helper()
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp response.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resp)
+		if resp.Error.Code != "WIP_LIMIT_EXCEEDED" || resp.Error.Context["scope"] != scope {
+			t.Errorf("expected a %s WIP_LIMIT_EXCEEDED error, got %+v", scope, resp.Error)
+		}
 	}
 
-	if resp.Error.Code != "CYCLE_DETECTED" {
-		t.Errorf("expected code 'CYCLE_DETECTED', got %q", resp.Error.Code)
+	for _, id := range ids[:2] {
+		if rr := claim(id, "agent-1"); rr.Code != http.StatusOK {
+			t.Fatalf("failed to claim %s: %d %s", id, rr.Code, rr.Body.String())
+		}
+	}
+	wipError(claim(ids[2], "agent-1"), "agent")
+
+	// The claim that hit the limit left the task open
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+ids[2], nil, nil)
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.Status != domain.StatusOpen || task.ClaimedBy != nil {
+		t.Errorf("expected the task to stay open, got %+v", task)
+	}
+
+	if rr := claim(ids[2], "agent-2"); rr.Code != http.StatusOK {
+		t.Fatalf("failed to claim as agent-2: %d %s", rr.Code, rr.Body.String())
+	}
+	wipError(claim(ids[3], "agent-2"), "project")
+
+	// A registered limit tightens the project's per-agent limit
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+ids[0]+"/release", nil,
+		map[string]string{middleware.AgentHeader: "agent-1"})
+	setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{"wip_limit": 1},
+		map[string]string{middleware.AgentHeader: "agent-1"})
+	wipError(claim(ids[0], "agent-1"), "agent")
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/settings", map[string]interface{}{"agent_wip_limit": -1}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a negative limit, got %d", rr.Code)
 	}
 }
 
-func TestListDependencies(t *testing.T) {
+func TestListTasks_ClaimedByMe(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create tasks
-	body1 := map[string]interface{}{"title": "Child Task"}
-	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
-	var child map[string]interface{}
-	json.NewDecoder(rr1.Body).Decode(&child)
+	mine := setup.createTask(t, "Mine")
+	theirs := setup.createTask(t, "Theirs")
+	setup.createTask(t, "Nobody's")
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+mine+"/claim", nil, map[string]string{middleware.AgentHeader: "agent-1"})
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+theirs+"/claim", nil, map[string]string{middleware.AgentHeader: "agent-2"})
 
-	body2 := map[string]interface{}{"title": "Parent Task"}
-	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
-	var parent map[string]interface{}
-	json.NewDecoder(rr2.Body).Decode(&parent)
+	list := func(query string) []domain.Task {
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks?"+query, nil, map[string]string{middleware.AgentHeader: "agent-1"})
+		var resp struct {
+			Data []domain.Task `json:"data"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return resp.Data
+	}
 
-	// Add dependency
-	depBody := map[string]interface{}{"parent_id": parent["id"]}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), depBody, nil)
+	if tasks := list("claimed_by=me"); len(tasks) != 1 || tasks[0].ID != mine {
+		t.Errorf("expected only %s for claimed_by=me, got %+v", mine, tasks)
+	}
+	if tasks := list("claimed_by=agent-2"); len(tasks) != 1 || tasks[0].ID != theirs {
+		t.Errorf("expected only %s for claimed_by=agent-2, got %+v", theirs, tasks)
+	}
+}
 
-	// List dependencies
-	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), nil, nil)
+func TestTaskAssignment(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
 
+	as := func(agent string) map[string]string { return map[string]string{middleware.AgentHeader: agent} }
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks",
+		map[string]interface{}{"title": "Reserved", "assignee": "agent-2"}, nil)
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.Assignee == nil || *task.Assignee != "agent-2" {
+		t.Fatalf("expected the task assigned to agent-2, got %+v", task)
+	}
+	grouped := setup.createTask(t, "Go work")
+	setup.createTask(t, "Anyone")
+
+	readyTitles := func(agent string) []string {
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, as(agent))
+		var resp struct {
+			Data []domain.Task `json:"data"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		var titles []string
+		for _, task := range resp.Data {
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+	claim := func(id, agent string) *httptest.ResponseRecorder {
+		return setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/claim", nil, as(agent))
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+grouped, map[string]interface{}{"assignee": "@go"}, nil)
 	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
 	}
+	setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{"capabilities": []string{"go"}}, as("agent-3"))
 
-	var deps []map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&deps); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	if titles := readyTitles("agent-1"); len(titles) != 1 || titles[0] != "Anyone" {
+		t.Errorf("expected agent-1 to see only the unassigned task, got %v", titles)
+	}
+	if titles := readyTitles("agent-2"); len(titles) != 2 || !slices.Contains(titles, "Reserved") {
+		t.Errorf("expected agent-2 to see its task, got %v", titles)
+	}
+	if titles := readyTitles("agent-3"); len(titles) != 2 || !slices.Contains(titles, "Go work") {
+		t.Errorf("expected agent-3 to see the go group's task, got %v", titles)
 	}
 
-	if len(deps) != 1 {
-		t.Errorf("expected 1 dependency, got %d", len(deps))
+	rr = claim(task.ID, "agent-1")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var errResp response.ErrorResponse
+	json.NewDecoder(rr.Body).Decode(&errResp)
+	if errResp.Error.Code != "NOT_ASSIGNEE" {
+		t.Errorf("expected NOT_ASSIGNEE, got %s", errResp.Error.Code)
+	}
+	if rr := claim(grouped, "agent-1"); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 outside the group, got %d", rr.Code)
+	}
+	if rr := claim(grouped, "agent-3"); rr.Code != http.StatusOK {
+		t.Errorf("expected a group member to claim, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Clearing the assignee opens the task to everyone
+	setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+task.ID, map[string]interface{}{"assignee": ""}, nil)
+	if rr := claim(task.ID, "agent-1"); rr.Code != http.StatusOK {
+		t.Errorf("expected status 200 once unassigned, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+task.ID, map[string]interface{}{"assignee": "@"}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an empty group, got %d", rr.Code)
 	}
 }
 
-func TestRemoveDependency(t *testing.T) {
+func TestHandoffTask(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create tasks
-	body1 := map[string]interface{}{"title": "Child Task"}
-	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
-	var child map[string]interface{}
-	json.NewDecoder(rr1.Body).Decode(&child)
+	taskID := setup.createTask(t, "Half done")
+	other := setup.createTask(t, "Other")
+	as := func(agent string) map[string]string { return map[string]string{middleware.AgentHeader: agent} }
+	handoff := func(id, by, to, query string) *httptest.ResponseRecorder {
+		return setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/handoff"+query,
+			map[string]interface{}{"agent": to}, as(by))
+	}
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/claim", nil, as("agent-1"))
 
-	body2 := map[string]interface{}{"title": "Parent Task"}
-	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
-	var parent map[string]interface{}
-	json.NewDecoder(rr2.Body).Decode(&parent)
+	// Only the owner can hand the task off without force
+	if rr := handoff(taskID, "agent-2", "agent-3", ""); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-owner, got %d: %s", rr.Code, rr.Body.String())
+	}
 
-	// Add dependency
-	depBody := map[string]interface{}{"parent_id": parent["id"]}
-	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), depBody, nil)
+	rr := handoff(taskID, "agent-1", "agent-2", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.Status != domain.StatusInProgress || task.ClaimedBy == nil || *task.ClaimedBy != "agent-2" {
+		t.Errorf("expected the task in progress under agent-2, got %+v", task)
+	}
 
-	// Remove dependency
-	rr := setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps/%s", child["id"], parent["id"]), nil, nil)
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+taskID+"/history", nil, nil)
+	var history []domain.AuditEntry
+	json.NewDecoder(rr.Body).Decode(&history)
+	found := false
+	for _, entry := range history {
+		if entry.Action == domain.ActionHandoff && *entry.OldValue == "agent-1" && *entry.NewValue == "agent-2" && entry.ChangedBy == "agent-1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a handoff audit entry, got %+v", history)
+	}
 
-	if rr.Code != http.StatusNoContent {
-		t.Errorf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	// Force only lets a project admin move a task it does not hold
+	if rr := handoff(taskID, "admin", "agent-3", "?force=true"); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 with force from a non-admin, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/settings", map[string]interface{}{"admins": []string{"admin"}}, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := handoff(taskID, "admin", "agent-3", ""); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for an admin without force, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := handoff(taskID, "admin", "agent-3", "?force=true"); rr.Code != http.StatusOK {
+		t.Errorf("expected status 200 with force from an admin, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	// Verify it's gone
-	listRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), nil, nil)
-	var deps []map[string]interface{}
-	json.NewDecoder(listRR.Body).Decode(&deps)
-	if len(deps) != 0 {
-		t.Errorf("expected 0 dependencies, got %d", len(deps))
+	// The receiving agent's WIP limit applies
+	setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{"wip_limit": 1}, as("agent-3"))
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+other+"/claim", nil, as("agent-1"))
+	if rr := handoff(other, "agent-1", "agent-3", ""); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 past the receiver's WIP limit, got %d: %s", rr.Code, rr.Body.String())
 	}
-}
 
-// ========================
-// Audit Tests
-// ========================
+	if rr := handoff(other, "agent-1", "", ""); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without an agent, got %d", rr.Code)
+	}
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+other+"/release", nil, as("agent-1"))
+	if rr := handoff(other, "agent-1", "agent-2", ""); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a task not in progress, got %d", rr.Code)
+	}
 
-func TestTaskHistory(t *testing.T) {
+	// An assigned task can only go to an agent allowed to claim it
+	setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+other, map[string]interface{}{"assignee": "@go"}, nil)
+	setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{"capabilities": []string{"go"}}, as("agent-1"))
+	setup.doRequest("POST", "/v1/projects/testproj/agents", map[string]interface{}{"capabilities": []string{"go"}}, as("agent-4"))
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+other+"/claim", nil, as("agent-1"))
+	rr = handoff(other, "agent-1", "agent-2", "")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a receiver outside the assignee, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var errResp response.ErrorResponse
+	json.NewDecoder(rr.Body).Decode(&errResp)
+	if errResp.Error.Code != "NOT_ASSIGNEE" {
+		t.Errorf("expected NOT_ASSIGNEE, got %s", errResp.Error.Code)
+	}
+	if rr := handoff(other, "agent-1", "agent-4", ""); rr.Code != http.StatusOK {
+		t.Errorf("expected a group member to receive the task, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestClaimTask_AlreadyClaimed(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
 	// Create a task
-	createBody := map[string]interface{}{"title": "Task with history"}
+	createBody := map[string]interface{}{"title": "Task to claim"}
 	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
 	var created map[string]interface{}
 	json.NewDecoder(createRR.Body).Decode(&created)
 	taskID := created["id"].(string)
 
-	// Get history
-	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/history", taskID), nil, nil)
+	// First agent claims
+	headers1 := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers1)
 
-	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	// Second agent tries to claim
+	headers2 := map[string]string{middleware.AgentHeader: "agent-2"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers2)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	var entries []map[string]interface{}
-	if err := json.NewDecoder(rr.Body).Decode(&entries); err != nil {
+	var resp response.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	// Should have at least the create entry
-	if len(entries) < 1 {
-		t.Errorf("expected at least 1 audit entry, got %d", len(entries))
+	if resp.Error.Code != "ALREADY_CLAIMED" {
+		t.Errorf("expected code 'ALREADY_CLAIMED', got %q", resp.Error.Code)
 	}
 }
 
-func TestAuditQuery(t *testing.T) {
+func TestCompleteTask_Success(t *testing.T) {
 	setup := newTestSetup(t)
 	defer setup.cleanup()
 
-	// Create some tasks to generate audit entries
-	body := map[string]interface{}{"title": "Audit test task"}
-	setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+	// Create and claim a task
+	createBody := map[string]interface{}{"title": "Task to complete"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
 
-	// Query audit log
-	rr := setup.doRequest("GET", "/v1/projects/testproj/audit", nil, nil)
+	headers := map[string]string{middleware.AgentHeader: "agent-123"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+
+	// Complete the task
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, headers)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	var resp response.PaginatedResponse
-	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+	var task map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	entries := resp.Data.([]interface{})
-	if len(entries) < 1 {
-		t.Errorf("expected at least 1 audit entry, got %d", len(entries))
+	if task["status"] != "done" {
+		t.Errorf("expected status 'done', got %v", task["status"])
+	}
+}
+
+func TestCompleteTask_NotOwner(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create and claim a task with one agent
+	createBody := map[string]interface{}{"title": "Task to complete"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	headers1 := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers1)
+
+	// Try to complete with another agent
+	headers2 := map[string]string{middleware.AgentHeader: "agent-2"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, headers2)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp response.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Error.Code != "NOT_OWNER" {
+		t.Errorf("expected code 'NOT_OWNER', got %q", resp.Error.Code)
+	}
+}
+
+func TestReleaseTask_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create and claim a task
+	createBody := map[string]interface{}{"title": "Task to release"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	headers := map[string]string{middleware.AgentHeader: "agent-123"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+
+	// Release the task
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/release", taskID), nil, headers)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if task["status"] != "open" {
+		t.Errorf("expected status 'open', got %v", task["status"])
+	}
+	if task["claimed_by"] != nil {
+		t.Errorf("expected claimed_by to be nil, got %v", task["claimed_by"])
+	}
+}
+
+func TestReleaseTask_Quarantine(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("PATCH", "/v1/projects/testproj/settings", map[string]interface{}{"max_attempts": 2}, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to set max_attempts: %d %s", rr.Code, rr.Body.String())
+	}
+
+	id := setup.createTask(t, "Poison")
+	setup.createTask(t, "Healthy")
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	attempt := func() domain.Task {
+		t.Helper()
+		if rr := setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/claim", nil, headers); rr.Code != http.StatusOK {
+			t.Fatalf("failed to claim: %d %s", rr.Code, rr.Body.String())
+		}
+		rr := setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/release", nil, headers)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("failed to release: %d %s", rr.Code, rr.Body.String())
+		}
+		var task domain.Task
+		json.NewDecoder(rr.Body).Decode(&task)
+		return task
+	}
+	quarantined := func() []domain.Task {
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks?quarantined=true", nil, nil)
+		var resp struct {
+			Data []domain.Task `json:"data"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return resp.Data
+	}
+
+	if task := attempt(); task.Status != domain.StatusOpen || task.Attempts != 1 {
+		t.Fatalf("expected an open task with 1 attempt, got %s with %d", task.Status, task.Attempts)
+	}
+	task := attempt()
+	if task.Status != domain.StatusBlocked || task.QuarantinedAt == nil || task.BlockReason == nil {
+		t.Fatalf("expected the task to be quarantined, got %+v", task)
+	}
+	if list := quarantined(); len(list) != 1 || list[0].ID != id {
+		t.Errorf("expected only %s to be quarantined, got %+v", id, list)
+	}
+
+	// Unblocking gives the task a fresh set of attempts
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/unblock", nil, headers)
+	var unblocked domain.Task
+	json.NewDecoder(rr.Body).Decode(&unblocked)
+	if unblocked.Status != domain.StatusOpen || unblocked.Attempts != 0 || unblocked.QuarantinedAt != nil {
+		t.Errorf("expected unblocking to end the quarantine, got %+v", unblocked)
+	}
+	if list := quarantined(); len(list) != 0 {
+		t.Errorf("expected no quarantined tasks, got %+v", list)
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/settings", map[string]interface{}{"max_attempts": -1}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for negative max_attempts, got %d", rr.Code)
+	}
+}
+
+func TestBlockTask_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create a task
+	createBody := map[string]interface{}{"title": "Task to block"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	// Block the task
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), nil, nil)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if task["status"] != "blocked" {
+		t.Errorf("expected status 'blocked', got %v", task["status"])
+	}
+}
+
+func TestUnblockTask_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create and block a task
+	createBody := map[string]interface{}{"title": "Task to unblock"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), nil, nil)
+
+	// Unblock the task
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/unblock", taskID), nil, nil)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if task["status"] != "open" {
+		t.Errorf("expected status 'open', got %v", task["status"])
+	}
+}
+
+// ========================
+// Dependency Tests
+// ========================
+
+func TestAddDependency_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create two tasks
+	body1 := map[string]interface{}{"title": "Child Task"}
+	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
+	var child map[string]interface{}
+	json.NewDecoder(rr1.Body).Decode(&child)
+
+	body2 := map[string]interface{}{"title": "Parent Task"}
+	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
+	var parent map[string]interface{}
+	json.NewDecoder(rr2.Body).Decode(&parent)
+
+	// Add dependency: child depends on parent
+	depBody := map[string]interface{}{"parent_id": parent["id"]}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), depBody, nil)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestAddDependency_Cycle(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create two tasks
+	body1 := map[string]interface{}{"title": "Task A"}
+	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
+	var taskA map[string]interface{}
+	json.NewDecoder(rr1.Body).Decode(&taskA)
+
+	body2 := map[string]interface{}{"title": "Task B"}
+	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
+	var taskB map[string]interface{}
+	json.NewDecoder(rr2.Body).Decode(&taskB)
+
+	// Add A -> B dependency
+	depBody1 := map[string]interface{}{"parent_id": taskB["id"]}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", taskA["id"]), depBody1, nil)
+
+	// Try to add B -> A dependency (would create cycle)
+	depBody2 := map[string]interface{}{"parent_id": taskA["id"]}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", taskB["id"]), depBody2, nil)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp response.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Error.Code != "CYCLE_DETECTED" {
+		t.Errorf("expected code 'CYCLE_DETECTED', got %q", resp.Error.Code)
+	}
+}
+
+func TestListDependencies(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create tasks
+	body1 := map[string]interface{}{"title": "Child Task"}
+	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
+	var child map[string]interface{}
+	json.NewDecoder(rr1.Body).Decode(&child)
+
+	body2 := map[string]interface{}{"title": "Parent Task"}
+	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
+	var parent map[string]interface{}
+	json.NewDecoder(rr2.Body).Decode(&parent)
+
+	// Add dependency
+	depBody := map[string]interface{}{"parent_id": parent["id"]}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), depBody, nil)
+
+	// List dependencies
+	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), nil, nil)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var deps []map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&deps); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(deps) != 1 {
+		t.Errorf("expected 1 dependency, got %d", len(deps))
+	}
+}
+
+func TestListDependencies_Transitive(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// a <- b <- c, a <- d: c depends on b, which depends on a
+	a := setup.createTask(t, "A")
+	b := setup.createTask(t, "B")
+	c := setup.createTask(t, "C")
+	d := setup.createTask(t, "D")
+	for _, dep := range [][2]string{{b, a}, {c, b}, {d, a}} {
+		setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", dep[0]),
+			map[string]interface{}{"parent_id": dep[1]}, nil)
+	}
+
+	list := func(path string) []domain.Dependency {
+		t.Helper()
+		rr := setup.doRequest("GET", "/v1/projects/testproj"+path, nil, nil)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d: %s", path, rr.Code, rr.Body.String())
+		}
+		var deps []domain.Dependency
+		json.NewDecoder(rr.Body).Decode(&deps)
+		return deps
+	}
+
+	if deps := list("/tasks/" + c + "/deps"); len(deps) != 1 || deps[0].Depth != 0 {
+		t.Errorf("expected 1 direct dependency without depth, got %+v", deps)
+	}
+
+	deps := list("/tasks/" + c + "/deps?transitive=true")
+	if len(deps) != 2 {
+		t.Fatalf("expected 2 transitive dependencies, got %+v", deps)
+	}
+	if deps[0] != (domain.Dependency{ChildID: c, ParentID: b, Depth: 1}) || deps[1] != (domain.Dependency{ChildID: b, ParentID: a, Depth: 2}) {
+		t.Errorf("expected c->b at depth 1 and b->a at depth 2, got %+v", deps)
+	}
+
+	if dependents := list("/tasks/" + a + "/dependents"); len(dependents) != 2 {
+		t.Errorf("expected b and d to depend on a directly, got %+v", dependents)
+	}
+
+	dependents := list("/tasks/" + a + "/dependents?transitive=true")
+	if len(dependents) != 3 {
+		t.Fatalf("expected 3 transitive dependents, got %+v", dependents)
+	}
+	if last := dependents[2]; last.ChildID != c || last.Depth != 2 {
+		t.Errorf("expected c to depend on a at depth 2, got %+v", last)
+	}
+
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/nonexistent/dependents", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown task, got %d", rr.Code)
+	}
+}
+
+func TestCrossProjectDependencies(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("POST", "/v1/projects/backend/tasks", map[string]interface{}{"title": "API"}, nil)
+	var api map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&api)
+	apiID := api["id"].(string)
+	uiID := setup.createTask(t, "UI")
+
+	ready := func() []domain.Task {
+		t.Helper()
+		rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+		var resp struct {
+			Data []domain.Task `json:"data"`
+		}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return resp.Data
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", uiID),
+		map[string]interface{}{"parent_id": "backend/" + apiID}, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", uiID), nil, nil)
+	var deps []domain.Dependency
+	json.NewDecoder(rr.Body).Decode(&deps)
+	if len(deps) != 1 || deps[0].Project != "backend" || deps[0].ParentRef() != "backend/"+apiID {
+		t.Errorf("expected a dependency on backend/%s, got %+v", apiID, deps)
+	}
+
+	if tasks := ready(); len(tasks) != 0 {
+		t.Errorf("expected the UI task to wait on the backend, got %+v", tasks)
+	}
+
+	// A dependency back from the backend would close a cycle across projects
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/backend/tasks/%s/deps", apiID),
+		map[string]interface{}{"parent_id": "testproj/" + uiID}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a cross-project cycle, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", uiID),
+		map[string]interface{}{"parent_id": "unknown/ar-0000"}, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown project, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", uiID),
+		map[string]interface{}{"parent_id": "backend/ar-0000"}, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown task, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/backend/tasks/%s/claim", apiID), nil, headers)
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/backend/tasks/%s/done", apiID), nil, headers)
+
+	if tasks := ready(); len(tasks) != 1 || tasks[0].ID != uiID {
+		t.Errorf("expected the UI task to be ready once the backend task is done, got %+v", tasks)
+	}
+
+	rr = setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps/backend/%s", uiID, apiID), nil, nil)
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+func TestRemoveDependency(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create tasks
+	body1 := map[string]interface{}{"title": "Child Task"}
+	rr1 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body1, nil)
+	var child map[string]interface{}
+	json.NewDecoder(rr1.Body).Decode(&child)
+
+	body2 := map[string]interface{}{"title": "Parent Task"}
+	rr2 := setup.doRequest("POST", "/v1/projects/testproj/tasks", body2, nil)
+	var parent map[string]interface{}
+	json.NewDecoder(rr2.Body).Decode(&parent)
+
+	// Add dependency
+	depBody := map[string]interface{}{"parent_id": parent["id"]}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), depBody, nil)
+
+	// Remove dependency
+	rr := setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps/%s", child["id"], parent["id"]), nil, nil)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Verify it's gone
+	listRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", child["id"]), nil, nil)
+	var deps []map[string]interface{}
+	json.NewDecoder(listRR.Body).Decode(&deps)
+	if len(deps) != 0 {
+		t.Errorf("expected 0 dependencies, got %d", len(deps))
+	}
+}
+
+// ========================
+// Audit Tests
+// ========================
+
+func TestTaskHistory(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create a task
+	createBody := map[string]interface{}{"title": "Task with history"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	// Get history
+	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/history", taskID), nil, nil)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var entries []map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&entries); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Should have at least the create entry
+	if len(entries) < 1 {
+		t.Errorf("expected at least 1 audit entry, got %d", len(entries))
+	}
+}
+
+func TestTaskTimeline(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	agent := map[string]string{middleware.AgentHeader: "agent-a"}
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{"title": "Tracked", "estimate": 30}, nil)
+	var created map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/claim", nil, agent)
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/release", nil, agent)
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/claim", nil, agent)
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/done", nil, agent)
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+taskID+"/timeline", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var timeline domain.TaskTimeline
+	json.NewDecoder(rr.Body).Decode(&timeline)
+
+	if len(timeline.Periods) != 5 || timeline.Periods[4].Status != domain.StatusDone {
+		t.Errorf("expected 5 periods ending in done, got %+v", timeline.Periods)
+	}
+	if timeline.Claims != 2 || timeline.Releases != 1 || timeline.CycleSeconds == nil {
+		t.Errorf("expected 2 claims, 1 release and a cycle time, got %+v", timeline)
+	}
+	if timeline.Estimate == nil || *timeline.Estimate != 30 {
+		t.Errorf("expected the estimate of 30 minutes, got %v", timeline.Estimate)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ar-missing/timeline", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a missing task, got %d", rr.Code)
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": "Tracked spec"}, nil)
+	var spec handler.SpecResponse
+	json.NewDecoder(rr.Body).Decode(&spec)
+
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks", map[string]interface{}{"title": "Tracked", "spec_id": spec.ID}, nil)
+	var created map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	agent := map[string]string{middleware.AgentHeader: "agent-a"}
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/claim", nil, agent)
+	setup.doRequest("POST", "/v1/projects/testproj/tasks/"+taskID+"/done", nil, agent)
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/reports/time", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var report domain.TimeReport
+	json.NewDecoder(rr.Body).Decode(&report)
+
+	if report.Total.Tasks != 1 || report.Total.Done != 1 {
+		t.Errorf("expected 1 task worked on and done, got %+v", report.Total)
+	}
+	if len(report.Agents) != 1 || report.Agents[0].Agent != "agent-a" || report.Agents[0].Done != 1 {
+		t.Errorf("expected agent-a to be credited with the task, got %+v", report.Agents)
+	}
+	if len(report.Specs) != 1 || report.Specs[0].SpecID != spec.ID || report.Specs[0].Done != 1 {
+		t.Errorf("expected the spec to be credited with the task, got %+v", report.Specs)
+	}
+}
+
+func TestAuditQuery(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// Create some tasks to generate audit entries
+	body := map[string]interface{}{"title": "Audit test task"}
+	setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+
+	// Query audit log
+	rr := setup.doRequest("GET", "/v1/projects/testproj/audit", nil, nil)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp response.PaginatedResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	entries := resp.Data.([]interface{})
+	if len(entries) < 1 {
+		t.Errorf("expected at least 1 audit entry, got %d", len(entries))
+	}
+}
+
+// ========================
+// Link Tests
+// ========================
+
+func TestAddLink_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	createBody := map[string]interface{}{"title": "Linked task"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	linkBody := map[string]interface{}{"type": "commit", "value": "0123456789abcdef0123456789abcdef01234567"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/links", taskID), linkBody,
+		map[string]string{"X-Airyra-Agent": "agent-1"})
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var link map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&link); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if link["type"] != "commit" {
+		t.Errorf("expected type 'commit', got %v", link["type"])
+	}
+	if link["created_by"] != "agent-1" {
+		t.Errorf("expected created_by 'agent-1', got %v", link["created_by"])
+	}
+
+	// Adding the same link again is idempotent
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/links", taskID), linkBody, nil)
+
+	listRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/links", taskID), nil, nil)
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", listRR.Code, listRR.Body.String())
+	}
+
+	var links []map[string]interface{}
+	json.NewDecoder(listRR.Body).Decode(&links)
+	if len(links) != 1 {
+		t.Errorf("expected 1 link, got %d", len(links))
+	}
+}
+
+func TestAddLink_InvalidType(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	createBody := map[string]interface{}{"title": "Linked task"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	linkBody := map[string]interface{}{"type": "ticket", "value": "JIRA-1"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/links", taskID), linkBody, nil)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestAddLink_TaskNotFound(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	linkBody := map[string]interface{}{"type": "branch", "value": "main"}
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks/ar-missing/links", linkBody, nil)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// ========================
+// Comment Tests
+// ========================
+
+func TestAddComment_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	createBody := map[string]interface{}{"title": "Commented task"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	commentBody := map[string]interface{}{"body": "Tried X, failed because Y"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/comments", taskID), commentBody,
+		map[string]string{"X-Airyra-Agent": "agent-1"})
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var comment map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&comment); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if comment["author"] != "agent-1" {
+		t.Errorf("expected author 'agent-1', got %v", comment["author"])
+	}
+
+	listRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/comments", taskID), nil, nil)
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", listRR.Code, listRR.Body.String())
+	}
+
+	var comments []map[string]interface{}
+	json.NewDecoder(listRR.Body).Decode(&comments)
+	if len(comments) != 1 {
+		t.Fatalf("expected 1 comment, got %d", len(comments))
+	}
+	if comments[0]["body"] != "Tried X, failed because Y" {
+		t.Errorf("expected comment body to round-trip, got %v", comments[0]["body"])
+	}
+
+	// The comment is recorded in the task history
+	historyRR := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/history", taskID), nil, nil)
+	var history []map[string]interface{}
+	json.NewDecoder(historyRR.Body).Decode(&history)
+
+	found := false
+	for _, entry := range history {
+		if entry["action"] == "comment" && entry["changed_by"] == "agent-1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a comment entry in the task history")
+	}
+}
+
+func TestAddComment_EmptyBody(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	createBody := map[string]interface{}{"title": "Commented task"}
+	createRR := setup.doRequest("POST", "/v1/projects/testproj/tasks", createBody, nil)
+	var created map[string]interface{}
+	json.NewDecoder(createRR.Body).Decode(&created)
+	taskID := created["id"].(string)
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/comments", taskID),
+		map[string]interface{}{"body": "  "}, nil)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestListComments_TaskNotFound(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ar-missing/comments", nil, nil)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// ========================
+// Block Reason Tests
+// ========================
+
+func TestBlockTask_WithReasonAndBlocker(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	blockerID := setup.createTask(t, "Blocking task")
+	taskID := setup.createTask(t, "Blocked task")
+
+	body := map[string]interface{}{
+		"reason":     "needs the API client first",
+		"blocked_by": blockerID,
+	}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), body, nil)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&task)
+
+	if task["status"] != "blocked" {
+		t.Errorf("expected status 'blocked', got %v", task["status"])
+	}
+	if task["block_reason"] != "needs the API client first" {
+		t.Errorf("expected block_reason to be set, got %v", task["block_reason"])
+	}
+	if task["blocked_by"] != blockerID {
+		t.Errorf("expected blocked_by %s, got %v", blockerID, task["blocked_by"])
+	}
+
+	// Unblocking clears the block details
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/unblock", taskID), nil, nil)
+	var unblocked map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&unblocked)
+	if _, ok := unblocked["block_reason"]; ok {
+		t.Errorf("expected block_reason to be cleared, got %v", unblocked["block_reason"])
+	}
+}
+
+func TestBlockTask_BlockerNotFound(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Blocked task")
+
+	body := map[string]interface{}{"blocked_by": "ar-missing"}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), body, nil)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestBlockTask_AutoUnblockRequiresTask(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Blocked task")
+
+	body := map[string]interface{}{
+		"blocked_by":   "https://example.com/issues/1",
+		"auto_unblock": true,
+	}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", taskID), body, nil)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCompleteTask_AutoUnblocksBlockedTasks(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	blockerID := setup.createTask(t, "Blocking task")
+	autoID := setup.createTask(t, "Auto-unblocked task")
+	manualID := setup.createTask(t, "Manually unblocked task")
+
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", autoID),
+		map[string]interface{}{"blocked_by": blockerID, "auto_unblock": true}, nil)
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", manualID),
+		map[string]interface{}{"blocked_by": blockerID}, nil)
+
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", blockerID), nil, headers)
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", blockerID), nil, headers)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", autoID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "open" {
+		t.Errorf("expected auto-unblocked task to be open, got %v", task["status"])
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", manualID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "blocked" {
+		t.Errorf("expected task without auto_unblock to stay blocked, got %v", task["status"])
+	}
+}
+
+// reviewWorkflow is the default workflow with a review state between in_progress and done
+var reviewWorkflow = map[string]interface{}{
+	"states": []map[string]interface{}{
+		{"name": "open", "claimable": true},
+		{"name": "in_progress"},
+		{"name": "review"},
+		{"name": "blocked"},
+		{"name": "done", "done": true},
+		{"name": "cancelled"},
+	},
+	"transitions": []map[string]interface{}{
+		{"from": "open", "to": "in_progress"},
+		{"from": "in_progress", "to": "open"},
+		{"from": "in_progress", "to": "review"},
+		{"from": "review", "to": "in_progress"},
+		{"from": "review", "to": "done"},
+		{"from": "blocked", "to": "open"},
+	},
+}
+
+func TestGetWorkflow_Default(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("GET", "/v1/projects/testproj/workflow", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var workflow domain.Workflow
+	json.NewDecoder(rr.Body).Decode(&workflow)
+
+	if len(workflow.States) != 5 {
+		t.Fatalf("expected 5 default states, got %d", len(workflow.States))
+	}
+	if !workflow.IsClaimable(domain.StatusOpen) || !workflow.IsDone(domain.StatusDone) {
+		t.Errorf("expected open to be claimable and done to be done, got %+v", workflow.States)
+	}
+	if !workflow.CanTransition(domain.StatusInProgress, domain.StatusDone) {
+		t.Error("expected in_progress -> done to be allowed")
+	}
+}
+
+func TestSetWorkflow_Invalid(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("PUT", "/v1/projects/testproj/workflow", map[string]interface{}{
+		"states": []map[string]interface{}{
+			{"name": "open", "claimable": true},
+			{"name": "done", "done": true},
+		},
+	}, nil)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestSetWorkflow_CannotRemoveUsedState(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("PUT", "/v1/projects/testproj/workflow", reviewWorkflow, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	taskID := setup.createTask(t, "Task under review")
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/transition", taskID),
+		map[string]interface{}{"status": "review"}, headers)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("PUT", "/v1/projects/testproj/workflow", domain.DefaultWorkflow(), nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 when removing a used state, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTransitionTask_CustomWorkflow(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	setup.doRequest("PUT", "/v1/projects/testproj/workflow", reviewWorkflow, nil)
+
+	taskID := setup.createTask(t, "Reviewed task")
+	dependentID := setup.createTask(t, "Dependent task")
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", dependentID),
+		map[string]interface{}{"parent_id": taskID}, nil)
+
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	path := fmt.Sprintf("/v1/projects/testproj/tasks/%s/transition", taskID)
+
+	// open -> review is not an allowed transition
+	rr := setup.doRequest("POST", path, map[string]interface{}{"status": "review"}, headers)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", path, map[string]interface{}{"status": "in_progress"}, headers)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Only the claiming agent can move an in-progress task
+	rr = setup.doRequest("POST", path, map[string]interface{}{"status": "review"},
+		map[string]string{middleware.AgentHeader: "agent-2"})
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", path, map[string]interface{}{"status": "review"}, headers)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "review" {
+		t.Errorf("expected status review, got %v", task["status"])
+	}
+
+	// A task in review does not satisfy dependencies
+	var ready response.PaginatedResponse
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&ready)
+	if tasks := ready.Data.([]interface{}); len(tasks) != 0 {
+		t.Errorf("expected no ready tasks while the dependency is in review, got %d", len(tasks))
+	}
+
+	rr = setup.doRequest("POST", path, map[string]interface{}{"status": "done"}, headers)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&ready)
+	tasks := ready.Data.([]interface{})
+	if len(tasks) != 1 || tasks[0].(map[string]interface{})["id"] != dependentID {
+		t.Errorf("expected the dependent task to be ready, got %v", tasks)
+	}
+}
+
+func TestTransitions_FollowCustomWorkflow(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	setup.doRequest("PUT", "/v1/projects/testproj/workflow", reviewWorkflow, nil)
+
+	taskID := setup.createTask(t, "Reviewed task")
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	post := func(action string, body interface{}) *httptest.ResponseRecorder {
+		return setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/%s", taskID, action), body, headers)
+	}
+
+	if rr := post("claim", nil); rr.Code != http.StatusOK {
+		t.Fatalf("failed to claim: %d %s", rr.Code, rr.Body.String())
+	}
+
+	// The workflow sends in-progress work through review and has no way to
+	// block or cancel it
+	for _, action := range []string{"done", "block", "cancel"} {
+		if rr := post(action, map[string]interface{}{}); rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %s, got %d: %s", action, rr.Code, rr.Body.String())
+		}
+	}
+
+	if rr := post("release", nil); rr.Code != http.StatusOK {
+		t.Errorf("expected in_progress -> open to be allowed, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTransitionTask_InProgressClaims(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	setup.doRequest("PATCH", "/v1/projects/testproj/settings", map[string]interface{}{"agent_wip_limit": 1}, nil)
+
+	first := setup.createTask(t, "First")
+	second := setup.createTask(t, "Second")
+	move := func(id, agent string) *httptest.ResponseRecorder {
+		return setup.doRequest("POST", "/v1/projects/testproj/tasks/"+id+"/transition",
+			map[string]interface{}{"status": "in_progress"}, map[string]string{middleware.AgentHeader: agent})
+	}
+	expectError := func(rr *httptest.ResponseRecorder, status int, code string) {
+		t.Helper()
+		var resp response.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resp)
+		if rr.Code != status || resp.Error.Code != code {
+			t.Errorf("expected %d %s, got %d %+v", status, code, rr.Code, resp.Error)
+		}
+	}
+
+	rr := move(first, "agent-1")
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if rr.Code != http.StatusOK || task.ClaimedBy == nil || *task.ClaimedBy != "agent-1" {
+		t.Fatalf("expected agent-1 to claim the task, got %d %+v", rr.Code, task)
+	}
+
+	expectError(move(second, "agent-1"), http.StatusConflict, "WIP_LIMIT_EXCEEDED")
+
+	assignee := "agent-3"
+	setup.doRequest("PATCH", "/v1/projects/testproj/tasks/"+second, map[string]interface{}{"assignee": assignee}, nil)
+	expectError(move(second, "agent-2"), http.StatusForbidden, "NOT_ASSIGNEE")
+}
+
+func TestTransitionTask_UnknownState(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Task")
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/transition", taskID),
+		map[string]interface{}{"status": "review"}, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// enableReview turns on the in_review phase for the testproj project
+func (s *testSetup) enableReview(t *testing.T) {
+	t.Helper()
+
+	workflow := domain.DefaultWorkflow()
+	workflow.EnableReview()
+
+	rr := s.doRequest("PUT", "/v1/projects/testproj/workflow", workflow, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to enable review: %d %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTransitionTask_DoneRequiresReview(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	// A workflow stored before review replaced in_progress -> done
+	workflow := domain.DefaultWorkflow()
+	workflow.EnableReview()
+	workflow.Transitions = append(workflow.Transitions, domain.WorkflowTransition{From: domain.StatusInProgress, To: domain.StatusDone})
+
+	for name, wf := range map[string]*domain.Workflow{"review on": nil, "legacy review workflow": workflow} {
+		t.Run(name, func(t *testing.T) {
+			if wf == nil {
+				setup.enableReview(t)
+			} else if rr := setup.doRequest("PUT", "/v1/projects/testproj/workflow", wf, nil); rr.Code != http.StatusOK {
+				t.Fatalf("failed to set workflow: %d %s", rr.Code, rr.Body.String())
+			}
+
+			taskID := setup.createTask(t, "Reviewed task")
+			dependentID := setup.createTask(t, "Dependent task")
+			setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", dependentID),
+				map[string]interface{}{"parent_id": taskID}, nil)
+
+			author := map[string]string{middleware.AgentHeader: "author"}
+			setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, author)
+
+			rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/transition", taskID),
+				map[string]interface{}{"status": "done"}, author)
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400 moving in_progress -> done, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/"+taskID, nil, nil)
+			var task domain.Task
+			json.NewDecoder(rr.Body).Decode(&task)
+			if task.Status != domain.StatusInProgress {
+				t.Errorf("expected the task to stay in_progress, got %s", task.Status)
+			}
+		})
+	}
+}
+
+func TestTransitionTask_ReviewEndsByApproveOrReject(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+	setup.enableReview(t)
+
+	taskID := setup.createTask(t, "Reviewed task")
+	if rr := setup.completeTask(t, taskID, "author"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	reviewer := map[string]string{middleware.AgentHeader: "reviewer"}
+	for to, use := range map[string]string{"done": "approve", "open": "reject"} {
+		rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/transition", taskID),
+			map[string]interface{}{"status": to}, reviewer)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 moving in_review -> %s, got %d: %s", to, rr.Code, rr.Body.String())
+		}
+		var resp response.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resp)
+		if resp.Error.Code != string(domain.ErrCodeInvalidTransition) || resp.Error.Context["use"] != use {
+			t.Errorf("expected INVALID_TRANSITION pointing to %s, got %+v", use, resp.Error)
+		}
+	}
+
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/"+taskID, nil, nil)
+	var task domain.Task
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task.Status != domain.StatusInReview {
+		t.Errorf("expected the task to stay in_review, got %s", task.Status)
+	}
+}
+
+func TestCompleteTask_WithReview(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	setup.enableReview(t)
+
+	taskID := setup.createTask(t, "Reviewed task")
+	dependentID := setup.createTask(t, "Dependent task")
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", dependentID),
+		map[string]interface{}{"parent_id": taskID}, nil)
+
+	author := map[string]string{middleware.AgentHeader: "author"}
+	reviewer := map[string]string{middleware.AgentHeader: "reviewer"}
+
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, author)
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, author)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "in_review" {
+		t.Fatalf("expected status in_review, got %v", task["status"])
+	}
+
+	// Tasks in review do not satisfy dependencies
+	var ready response.PaginatedResponse
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&ready)
+	if tasks := ready.Data.([]interface{}); len(tasks) != 0 {
+		t.Errorf("expected no ready tasks while in review, got %d", len(tasks))
+	}
+
+	// The author cannot approve their own work
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/approve", taskID), nil, author)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/approve", taskID), nil, reviewer)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	task = nil
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "done" {
+		t.Errorf("expected status done, got %v", task["status"])
+	}
+
+	ready = response.PaginatedResponse{}
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&ready)
+	if tasks := ready.Data.([]interface{}); len(tasks) != 1 {
+		t.Errorf("expected the dependent task to be ready after approval, got %d", len(tasks))
+	}
+}
+
+func TestRejectTask_ReturnsToOpenWithComment(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	setup.enableReview(t)
+
+	taskID := setup.createTask(t, "Reviewed task")
+	author := map[string]string{middleware.AgentHeader: "author"}
+	reviewer := map[string]string{middleware.AgentHeader: "reviewer"}
+
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, author)
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, author)
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/reject", taskID),
+		map[string]interface{}{}, reviewer)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without a reason, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/reject", taskID),
+		map[string]interface{}{"reason": "missing tests"}, reviewer)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "open" {
+		t.Errorf("expected status open, got %v", task["status"])
+	}
+	if task["claimed_by"] != nil {
+		t.Errorf("expected claim to be cleared, got %v", task["claimed_by"])
+	}
+
+	var comments []map[string]interface{}
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/comments", taskID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&comments)
+	if len(comments) != 1 || comments[0]["body"] != "Rejected: missing tests" || comments[0]["author"] != "reviewer" {
+		t.Errorf("expected a rejection comment from the reviewer, got %v", comments)
+	}
+}
+
+func TestApproveTask_NotInReview(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Open task")
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/approve", taskID), nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCancelTask_AndReopen(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Dropped task")
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/cancel", taskID),
+		map[string]interface{}{"reason": "superseded"}, headers)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var task map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "cancelled" {
+		t.Errorf("expected status cancelled, got %v", task["status"])
+	}
+	if task["claimed_by"] != nil {
+		t.Errorf("expected claim to be cleared, got %v", task["claimed_by"])
+	}
+
+	var comments []map[string]interface{}
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/comments", taskID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&comments)
+	if len(comments) != 1 || comments[0]["body"] != "Cancelled: superseded" {
+		t.Errorf("expected the reason as a comment, got %v", comments)
+	}
+
+	// Cancelled tasks are not ready
+	var ready response.PaginatedResponse
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&ready)
+	if tasks := ready.Data.([]interface{}); len(tasks) != 0 {
+		t.Errorf("expected no ready tasks, got %d", len(tasks))
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/cancel", taskID), nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 cancelling twice, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/reopen", taskID), nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	task = nil
+	json.NewDecoder(rr.Body).Decode(&task)
+	if task["status"] != "open" {
+		t.Errorf("expected status open, got %v", task["status"])
+	}
+}
+
+func TestCancelTask_DoneTask(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Finished task")
+	headers := map[string]string{middleware.AgentHeader: "agent-1"}
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, headers)
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/cancel", taskID), nil, headers)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCancelTask_DependentsFollowCancelPolicy(t *testing.T) {
+	for _, policy := range []domain.CancelPolicy{domain.CancelPolicyBlock, domain.CancelPolicySatisfy} {
+		t.Run(string(policy), func(t *testing.T) {
+			setup := newTestSetup(t)
+			defer setup.cleanup()
+
+			workflow := domain.DefaultWorkflow()
+			workflow.SetCancelPolicy(policy)
+			rr := setup.doRequest("PUT", "/v1/projects/testproj/workflow", workflow, nil)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("failed to set cancel policy: %d %s", rr.Code, rr.Body.String())
+			}
+
+			parentID := setup.createTask(t, "Cancelled parent")
+			childID := setup.createTask(t, "Dependent child")
+			setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", childID),
+				map[string]interface{}{"parent_id": parentID}, nil)
+			setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/cancel", parentID), nil, nil)
+
+			var ready response.PaginatedResponse
+			rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+			json.NewDecoder(rr.Body).Decode(&ready)
+			tasks := ready.Data.([]interface{})
+
+			wantReady := policy == domain.CancelPolicySatisfy
+			if gotReady := len(tasks) == 1; gotReady != wantReady {
+				t.Errorf("expected child ready = %v, got %d ready tasks", wantReady, len(tasks))
+			}
+		})
+	}
+}
+
+func TestDeleteTask_MovesSubtreeToTrash(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Parent")
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks",
+		map[string]interface{}{"title": "Subtask", "parent_id": parentID}, nil)
+	var child map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&child)
+	childID := child["id"].(string)
+
+	rr = setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", childID), nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected subtask to be deleted with its parent, got %d", rr.Code)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/trash", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var trash handler.TrashResponse
+	json.NewDecoder(rr.Body).Decode(&trash)
+	if len(trash.Tasks) != 2 {
+		t.Fatalf("expected 2 tasks in trash, got %d", len(trash.Tasks))
+	}
+	for _, task := range trash.Tasks {
+		if task.DeletedAt == nil {
+			t.Errorf("expected task %s to have deleted_at", task.ID)
+		}
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/restore", parentID), nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", childID), nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected subtask to be restored with its parent, got %d", rr.Code)
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/history", parentID), nil, nil)
+	var history []map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&history)
+	if len(history) == 0 || history[len(history)-1]["action"] != "restore" {
+		t.Errorf("expected last history entry to be restore, got %v", history)
+	}
+}
+
+func TestRestoreTask_ParentInTrash(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Parent")
+	rr := setup.doRequest("POST", "/v1/projects/testproj/tasks",
+		map[string]interface{}{"title": "Subtask", "parent_id": parentID}, nil)
+	var child map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&child)
+	childID := child["id"].(string)
+
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/restore", childID), nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestRestoreTask_NotInTrash(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	taskID := setup.createTask(t, "Live task")
+
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/restore", taskID), nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks/ar-0000/restore", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestDeleteTask_DependentsBecomeReady(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Deleted dependency")
+	childID := setup.createTask(t, "Dependent child")
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", childID),
+		map[string]interface{}{"parent_id": parentID}, nil)
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+
+	var ready response.PaginatedResponse
+	rr := setup.doRequest("GET", "/v1/projects/testproj/tasks/ready", nil, nil)
+	json.NewDecoder(rr.Body).Decode(&ready)
+	tasks := ready.Data.([]interface{})
+	if len(tasks) != 1 || tasks[0].(map[string]interface{})["id"] != childID {
+		t.Errorf("expected only the dependent child to be ready, got %v", tasks)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	firstID := setup.createTask(t, "First")
+	secondID := setup.createTask(t, "Second")
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", firstID), nil, nil)
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", secondID), nil, nil)
+
+	rr := setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/trash/%s", firstID), nil, nil)
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/restore", firstID), nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected purged task to be gone, got %d", rr.Code)
+	}
+
+	rr = setup.doRequest("DELETE", "/v1/projects/testproj/trash", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var result map[string]int
+	json.NewDecoder(rr.Body).Decode(&result)
+	if result["purged"] != 1 {
+		t.Errorf("expected 1 purged item, got %d", result["purged"])
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/trash", nil, nil)
+	var trash handler.TrashResponse
+	json.NewDecoder(rr.Body).Decode(&trash)
+	if len(trash.Tasks) != 0 || len(trash.Specs) != 0 {
+		t.Errorf("expected empty trash, got %+v", trash)
+	}
+}
+
+func TestPurgeTrash_KeepsTasksWithLiveSubtasks(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Parent")
+	childID := setup.createSubtask(t, "Child", parentID)
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+
+	// Left behind by a subtask created under the parent while it was deleted
+	db, err := setup.manager.GetDB("testproj")
+	if err != nil {
+		t.Fatalf("failed to open project: %v", err)
+	}
+	if _, err := db.Exec("UPDATE tasks SET deleted_at = NULL WHERE id = ?", childID); err != nil {
+		t.Fatalf("failed to restore child: %v", err)
+	}
+
+	rr := setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/trash/%s", parentID), nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = setup.doRequest("DELETE", "/v1/projects/testproj/trash", nil, nil)
+	var result map[string]int
+	json.NewDecoder(rr.Body).Decode(&result)
+	if result["purged"] != 0 {
+		t.Errorf("expected nothing purged, got %d", result["purged"])
+	}
+
+	if rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", childID), nil, nil); rr.Code != http.StatusOK {
+		t.Errorf("expected the live subtask to survive, got %d", rr.Code)
+	}
+}
+
+func TestCreateTask_RejectsMissingOrTrashedReferences(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	trashedID := setup.createTask(t, "Trashed")
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/tasks/%s", trashedID), nil, nil)
+
+	rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": "Old spec"}, nil)
+	var spec map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&spec)
+	specID := spec["id"].(string)
+	setup.doRequest("DELETE", fmt.Sprintf("/v1/projects/testproj/specs/%s", specID), nil, nil)
+
+	for name, body := range map[string]map[string]interface{}{
+		"missing parent": {"title": "Orphan", "parent_id": "ar-0000"},
+		"trashed parent": {"title": "Orphan", "parent_id": trashedID},
+		"missing spec":   {"title": "Orphan", "spec_id": "sp-missing"},
+		"trashed spec":   {"title": "Orphan", "spec_id": specID},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rr := setup.doRequest("POST", "/v1/projects/testproj/tasks", body, nil)
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+			}
+			var resp response.ErrorResponse
+			json.NewDecoder(rr.Body).Decode(&resp)
+			if resp.Error.Code != string(domain.ErrCodeValidationFailed) {
+				t.Errorf("expected VALIDATION_FAILED, got %s", resp.Error.Code)
+			}
+		})
+	}
+}
+
+// createSubtask creates a subtask of parentID in the testproj project and returns its ID
+func (s *testSetup) createSubtask(t *testing.T, title, parentID string) string {
+	t.Helper()
+
+	rr := s.doRequest("POST", "/v1/projects/testproj/tasks",
+		map[string]interface{}{"title": title, "parent_id": parentID}, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("failed to create subtask: %d %s", rr.Code, rr.Body.String())
+	}
+
+	var created map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&created)
+	return created["id"].(string)
+}
+
+// completeTask claims and completes a task as agent
+func (s *testSetup) completeTask(t *testing.T, taskID, agent string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	headers := map[string]string{middleware.AgentHeader: agent}
+	s.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", taskID), nil, headers)
+	return s.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", taskID), nil, headers)
+}
+
+func TestTaskChildrenAndTree(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Parent")
+	childID := setup.createSubtask(t, "Child", parentID)
+	grandchildID := setup.createSubtask(t, "Grandchild", childID)
+	setup.createTask(t, "Unrelated")
+
+	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/children", parentID), nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var children []domain.Task
+	json.NewDecoder(rr.Body).Decode(&children)
+	if len(children) != 1 || children[0].ID != childID {
+		t.Errorf("expected only the direct child %s, got %+v", childID, children)
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/tree", parentID), nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var tree domain.TaskTree
+	json.NewDecoder(rr.Body).Decode(&tree)
+	if tree.ID != parentID || len(tree.Children) != 1 {
+		t.Fatalf("expected %s with one child, got %+v", parentID, tree)
+	}
+	if len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].ID != grandchildID {
+		t.Errorf("expected grandchild %s in tree, got %+v", grandchildID, tree.Children[0].Children)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/tasks/nonexistent/children", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestCompleteTask_ChildrenNotDone(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Parent")
+	childID := setup.createSubtask(t, "Child", parentID)
+
+	rr := setup.completeTask(t, parentID, "agent-1")
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp map[string]map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp["error"]["code"] != string(domain.ErrCodeChildrenNotDone) {
+		t.Errorf("expected CHILDREN_NOT_DONE, got %v", resp["error"]["code"])
+	}
+	if children, _ := resp["error"]["context"].(map[string]interface{})["children"].([]interface{}); len(children) != 1 || children[0] != childID {
+		t.Errorf("expected unfinished child %s in context, got %v", childID, resp["error"]["context"])
+	}
+
+	rr = setup.doRequest("PATCH", "/v1/projects/testproj/settings",
+		map[string]interface{}{"require_children_done": false}, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var settings domain.ProjectSettings
+	json.NewDecoder(rr.Body).Decode(&settings)
+	if settings.RequireChildrenDone || !settings.AutoCompleteParents {
+		t.Errorf("expected only require_children_done to change, got %+v", settings)
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/done", parentID), nil,
+		map[string]string{middleware.AgentHeader: "agent-1"})
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200 once the rule is off, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCompleteTask_AutoCompletesParents(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rootID := setup.createTask(t, "Root")
+	parentID := setup.createSubtask(t, "Parent", rootID)
+	firstID := setup.createSubtask(t, "First", parentID)
+	secondID := setup.createSubtask(t, "Second", parentID)
+
+	if rr := setup.completeTask(t, firstID, "agent-1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var parent domain.Task
+	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&parent)
+	if parent.Status != domain.StatusOpen {
+		t.Fatalf("expected parent to stay open while a child is unfinished, got %s", parent.Status)
+	}
+
+	rr = setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/cancel", secondID), nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	for _, id := range []string{parentID, rootID} {
+		var task domain.Task
+		rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", id), nil, nil)
+		json.NewDecoder(rr.Body).Decode(&task)
+		if task.Status != domain.StatusDone {
+			t.Errorf("expected %s to be auto-completed, got %s", id, task.Status)
+		}
+	}
+
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s/history", parentID), nil, nil)
+	var history []map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&history)
+	if len(history) == 0 || history[len(history)-1]["action"] != "auto_complete" {
+		t.Errorf("expected last history entry to be auto_complete, got %v", history)
+	}
+}
+
+func TestApproveTask_AutoCompletedParentAwaitsReview(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+	setup.enableReview(t)
+
+	rootID := setup.createTask(t, "Root")
+	parentID := setup.createSubtask(t, "Parent", rootID)
+	childID := setup.createSubtask(t, "Child", parentID)
+
+	if rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/claim", parentID), nil,
+		map[string]string{middleware.AgentHeader: "agent-3"}); rr.Code != http.StatusOK {
+		t.Fatalf("failed to claim parent: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr := setup.completeTask(t, childID, "agent-1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/approve", childID), nil,
+		map[string]string{middleware.AgentHeader: "agent-2"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var parent domain.Task
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&parent)
+	if parent.Status != domain.StatusInReview {
+		t.Errorf("expected parent to await review, got %s", parent.Status)
+	}
+	if parent.ClaimedBy != nil || parent.ClaimedAt != nil {
+		t.Errorf("expected parent's claim to be cleared, got %v at %v", parent.ClaimedBy, parent.ClaimedAt)
+	}
+
+	var root domain.Task
+	rr = setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", rootID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&root)
+	if root.Status != domain.StatusOpen {
+		t.Errorf("expected root to stay open while its subtask is under review, got %s", root.Status)
+	}
+}
+
+func TestCompleteTask_BlockedParentIsNotAutoCompleted(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	parentID := setup.createTask(t, "Parent")
+	childID := setup.createSubtask(t, "Child", parentID)
+
+	if rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/block", parentID), nil, nil); rr.Code != http.StatusOK {
+		t.Fatalf("failed to block parent: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr := setup.completeTask(t, childID, "agent-1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var parent domain.Task
+	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&parent)
+	if parent.Status != domain.StatusBlocked {
+		t.Errorf("expected blocked parent to stay blocked, got %s", parent.Status)
+	}
+}
+
+func TestCancelTask_AllChildrenCancelledKeepsParentOpen(t *testing.T) {
+	for _, policy := range []domain.CancelPolicy{domain.CancelPolicyBlock, domain.CancelPolicySatisfy} {
+		t.Run(string(policy), func(t *testing.T) {
+			setup := newTestSetup(t)
+			defer setup.cleanup()
+
+			workflow := domain.DefaultWorkflow()
+			workflow.SetCancelPolicy(policy)
+			if rr := setup.doRequest("PUT", "/v1/projects/testproj/workflow", workflow, nil); rr.Code != http.StatusOK {
+				t.Fatalf("failed to set cancel policy: %d %s", rr.Code, rr.Body.String())
+			}
+
+			parentID := setup.createTask(t, "Parent")
+			for _, title := range []string{"First", "Second"} {
+				childID := setup.createSubtask(t, title, parentID)
+				if rr := setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/cancel", childID), nil, nil); rr.Code != http.StatusOK {
+					t.Fatalf("failed to cancel %s: %d %s", childID, rr.Code, rr.Body.String())
+				}
+			}
+
+			var parent domain.Task
+			rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+			json.NewDecoder(rr.Body).Decode(&parent)
+			if parent.Status != domain.StatusOpen {
+				t.Errorf("expected the parent of only cancelled subtasks to stay open, got %s", parent.Status)
+			}
+		})
+	}
+}
+
+func TestCompleteTask_AutoCompleteDisabled(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	setup.doRequest("PATCH", "/v1/projects/testproj/settings",
+		map[string]interface{}{"auto_complete_parents": false}, nil)
+
+	parentID := setup.createTask(t, "Parent")
+	childID := setup.createSubtask(t, "Child", parentID)
+
+	if rr := setup.completeTask(t, childID, "agent-1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var parent domain.Task
+	rr := setup.doRequest("GET", fmt.Sprintf("/v1/projects/testproj/tasks/%s", parentID), nil, nil)
+	json.NewDecoder(rr.Body).Decode(&parent)
+	if parent.Status != domain.StatusOpen {
+		t.Errorf("expected parent to stay open, got %s", parent.Status)
+	}
+}
+
+func TestGetGraph(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": "Auth"}, nil)
+	var spec map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&spec)
+	specID := spec["id"].(string)
+
+	schemaID := setup.createTask(t, "Schema")
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks",
+		map[string]interface{}{"title": "Login", "spec_id": specID}, nil)
+	var login map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&login)
+	loginID := login["id"].(string)
+	endpointID := setup.createSubtask(t, "Endpoint", loginID)
+	unrelatedID := setup.createTask(t, "Unrelated")
+
+	setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", loginID),
+		map[string]interface{}{"parent_id": schemaID}, nil)
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/graph", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var graph domain.Graph
+	json.NewDecoder(rr.Body).Decode(&graph)
+	if len(graph.Nodes) != 5 {
+		t.Errorf("expected 5 nodes, got %d", len(graph.Nodes))
+	}
+	edges := make(map[domain.GraphEdge]bool)
+	for _, e := range graph.Edges {
+		edges[e] = true
+	}
+	if !edges[domain.GraphEdge{From: schemaID, To: loginID, Kind: domain.GraphEdgeDependency}] {
+		t.Errorf("expected dependency edge %s -> %s, got %v", schemaID, loginID, graph.Edges)
+	}
+	if !edges[domain.GraphEdge{From: loginID, To: endpointID, Kind: domain.GraphEdgeSubtask}] {
+		t.Errorf("expected subtask edge %s -> %s, got %v", loginID, endpointID, graph.Edges)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/graph?task="+endpointID+"&direction=ancestors", nil, nil)
+	graph = domain.Graph{}
+	json.NewDecoder(rr.Body).Decode(&graph)
+	if _, ok := graph.Node(schemaID); !ok || len(graph.Nodes) != 3 {
+		t.Errorf("expected endpoint, login and schema as ancestors, got %+v", graph.Nodes)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/graph?spec="+specID, nil, nil)
+	graph = domain.Graph{}
+	json.NewDecoder(rr.Body).Decode(&graph)
+	if _, ok := graph.Node(unrelatedID); ok || len(graph.Nodes) != 2 {
+		t.Errorf("expected only the spec and its task, got %+v", graph.Nodes)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/graph?task="+endpointID+"&direction=sideways", nil, nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid direction, got %d", rr.Code)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/graph?task=nonexistent", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown task, got %d", rr.Code)
+	}
+}
+
+func TestGetAnalysis(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.cleanup()
+
+	rr := setup.doRequest("POST", "/v1/projects/testproj/specs", map[string]interface{}{"title": "Auth"}, nil)
+	var spec map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&spec)
+	specID := spec["id"].(string)
+
+	// schema -> login -> endpoint, schema -> docs, plus an unrelated task
+	schemaID := setup.createTask(t, "Schema")
+	rr = setup.doRequest("POST", "/v1/projects/testproj/tasks",
+		map[string]interface{}{"title": "Login", "spec_id": specID, "estimate": 120}, nil)
+	var login map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&login)
+	loginID := login["id"].(string)
+	endpointID := setup.createTask(t, "Endpoint")
+	docsID := setup.createTask(t, "Docs")
+	setup.createTask(t, "Unrelated")
+
+	for _, dep := range [][2]string{{loginID, schemaID}, {endpointID, loginID}, {docsID, schemaID}} {
+		setup.doRequest("POST", fmt.Sprintf("/v1/projects/testproj/tasks/%s/deps", dep[0]),
+			map[string]interface{}{"parent_id": dep[1]}, nil)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/analysis", nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var analysis domain.ScheduleAnalysis
+	json.NewDecoder(rr.Body).Decode(&analysis)
+	if analysis.Remaining != 5 || analysis.Parallelism != 2 {
+		t.Errorf("expected 5 remaining and parallelism 2, got %d and %d", analysis.Remaining, analysis.Parallelism)
+	}
+	if len(analysis.CriticalPath) != 3 || analysis.CriticalPath[0].ID != schemaID || analysis.CriticalPath[2].ID != endpointID {
+		t.Errorf("expected critical path schema, login, endpoint, got %+v", analysis.CriticalPath)
+	}
+	// Unestimated tasks count at the average estimate of 120 minutes
+	if analysis.PathEstimate != 360 {
+		t.Errorf("expected path estimate 360, got %d", analysis.PathEstimate)
+	}
+	if len(analysis.BlockingFanOut) == 0 || analysis.BlockingFanOut[0].ID != schemaID || analysis.BlockingFanOut[0].Downstream != 3 {
+		t.Errorf("expected schema to block 3 tasks, got %+v", analysis.BlockingFanOut)
+	}
+
+	// Completing schema takes it off the path
+	setup.completeTask(t, schemaID, "agent-1")
+	rr = setup.doRequest("GET", "/v1/projects/testproj/analysis?limit=1", nil, nil)
+	analysis = domain.ScheduleAnalysis{}
+	json.NewDecoder(rr.Body).Decode(&analysis)
+	if analysis.Remaining != 4 || len(analysis.CriticalPath) != 2 || len(analysis.BlockingFanOut) != 1 {
+		t.Errorf("expected 4 remaining, a 2-task path and 1 blocker, got %+v", analysis)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/analysis?spec="+specID, nil, nil)
+	analysis = domain.ScheduleAnalysis{}
+	json.NewDecoder(rr.Body).Decode(&analysis)
+	if analysis.Remaining != 1 || len(analysis.BlockingFanOut) != 0 {
+		t.Errorf("expected only the spec's task, got %+v", analysis)
+	}
+
+	rr = setup.doRequest("GET", "/v1/projects/testproj/analysis?spec=sp-nonexistent", nil, nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown spec, got %d", rr.Code)
 	}
 }
 
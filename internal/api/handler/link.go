@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// LinkHandler handles task link operations.
+type LinkHandler struct{}
+
+// NewLinkHandler creates a new LinkHandler.
+func NewLinkHandler() *LinkHandler {
+	return &LinkHandler{}
+}
+
+// ListLinks handles GET /tasks/{id}/links.
+func (h *LinkHandler) ListLinks(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	linkRepo := sqlite.NewLinkRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewLinkService(linkRepo, taskRepo, auditRepo)
+
+	links, err := svc.List(taskID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if links == nil {
+		links = []*domain.TaskLink{}
+	}
+
+	response.OK(w, links)
+}
+
+// AddLink handles POST /tasks/{id}/links.
+func (h *LinkHandler) AddLink(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req request.AddLinkRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	linkRepo := sqlite.NewLinkRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewLinkService(linkRepo, taskRepo, auditRepo)
+
+	link, err := svc.Add(taskID, domain.LinkType(req.Type), req.Value, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, link)
+}
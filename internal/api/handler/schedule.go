@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+)
+
+// ScheduleHandler handles recurring task schedule operations.
+type ScheduleHandler struct{}
+
+// NewScheduleHandler creates a new ScheduleHandler.
+func NewScheduleHandler() *ScheduleHandler {
+	return &ScheduleHandler{}
+}
+
+// ListSchedules handles GET /schedules.
+func (h *ScheduleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	svc := service.NewScheduleService(middleware.GetDB(r.Context()))
+
+	schedules, err := svc.List()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, schedules)
+}
+
+// CreateSchedule handles POST /schedules.
+func (h *ScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var req request.CreateScheduleRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	agentID := middleware.GetAgentID(r.Context())
+	svc := service.NewScheduleService(middleware.GetDB(r.Context()))
+
+	schedule, err := svc.Create(service.CreateScheduleInput{
+		Cron:        req.Cron,
+		CatchUp:     req.CatchUp,
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Estimate:    req.Estimate,
+		SpecID:      req.SpecID,
+	}, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, schedule)
+}
+
+// GetSchedule handles GET /schedules/{id}.
+func (h *ScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	svc := service.NewScheduleService(middleware.GetDB(r.Context()))
+
+	schedule, err := svc.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, schedule)
+}
+
+// DeleteSchedule handles DELETE /schedules/{id}.
+func (h *ScheduleHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	svc := service.NewScheduleService(middleware.GetDB(r.Context()))
+
+	if err := svc.Delete(chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// RunSchedule handles POST /schedules/{id}/run.
+func (h *ScheduleHandler) RunSchedule(w http.ResponseWriter, r *http.Request) {
+	agentID := middleware.GetAgentID(r.Context())
+	svc := service.NewScheduleService(middleware.GetDB(r.Context()))
+
+	task, err := svc.RunNow(chi.URLParam(r, "id"), agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, task)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// SettingsHandler handles project settings operations.
+type SettingsHandler struct{}
+
+// NewSettingsHandler creates a new SettingsHandler.
+func NewSettingsHandler() *SettingsHandler {
+	return &SettingsHandler{}
+}
+
+// GetSettings handles GET /settings.
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	db := middleware.GetDB(r.Context())
+	svc := service.NewSettingsService(sqlite.NewSettingsRepository(db))
+
+	settings, err := svc.Get()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, settings)
+}
+
+// UpdateSettings handles PATCH /settings.
+func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	db := middleware.GetDB(r.Context())
+	svc := service.NewSettingsService(sqlite.NewSettingsRepository(db))
+
+	// Settings left out of the body keep their current values
+	req, err := svc.Get()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if err := request.DecodeJSON(r, req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	settings, err := svc.Set(req)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, settings)
+}
@@ -10,15 +10,19 @@ import (
 	"github.com/airyra/airyra/internal/api/response"
 	"github.com/airyra/airyra/internal/domain"
 	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store"
 	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 // SpecHandler handles spec CRUD operations.
-type SpecHandler struct{}
+type SpecHandler struct {
+	manager *store.Manager
+}
 
-// NewSpecHandler creates a new SpecHandler.
-func NewSpecHandler() *SpecHandler {
-	return &SpecHandler{}
+// NewSpecHandler creates a new SpecHandler. The manager is handed to the
+// services that add task dependencies.
+func NewSpecHandler(manager *store.Manager) *SpecHandler {
+	return &SpecHandler{manager: manager}
 }
 
 // CreateSpec handles POST /specs.
@@ -44,6 +48,7 @@ func (h *SpecHandler) CreateSpec(w http.ResponseWriter, r *http.Request) {
 	spec, err := svc.Create(service.CreateSpecInput{
 		Title:       req.Title,
 		Description: req.Description,
+		DueAt:       request.ParseTime(req.DueAt),
 	}, agentID)
 	if err != nil {
 		response.Error(w, err)
@@ -53,6 +58,35 @@ func (h *SpecHandler) CreateSpec(w http.ResponseWriter, r *http.Request) {
 	response.Created(w, specWithStatus(spec))
 }
 
+// InstantiateSpec handles POST /specs/instantiate.
+func (h *SpecHandler) InstantiateSpec(w http.ResponseWriter, r *http.Request) {
+	var req request.InstantiateSpecRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+	svc := service.NewTemplateService(db, h.manager, middleware.GetProject(r.Context()))
+
+	instance, err := svc.Instantiate(req.Template, req.Vars, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, SpecInstanceResponse{
+		Spec:  specWithStatus(instance.Spec),
+		Tasks: instance.Tasks,
+	})
+}
+
 // GetSpec handles GET /specs/{id}.
 func (h *SpecHandler) GetSpec(w http.ResponseWriter, r *http.Request) {
 	specID := chi.URLParam(r, "id")
@@ -71,6 +105,24 @@ func (h *SpecHandler) GetSpec(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, specWithStatus(spec))
 }
 
+// GetSpecProgress handles GET /specs/{id}/progress.
+func (h *SpecHandler) GetSpecProgress(w http.ResponseWriter, r *http.Request) {
+	specID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	specRepo := sqlite.NewSpecRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewSpecService(specRepo, auditRepo)
+
+	progress, err := svc.Progress(specID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, progress)
+}
+
 // ListSpecs handles GET /specs.
 func (h *SpecHandler) ListSpecs(w http.ResponseWriter, r *http.Request) {
 	pagination := request.ParsePagination(r)
@@ -83,6 +135,7 @@ func (h *SpecHandler) ListSpecs(w http.ResponseWriter, r *http.Request) {
 
 	specs, total, err := svc.List(service.ListSpecsInput{
 		Status:  status,
+		Overdue: request.ParseOverdue(r),
 		Page:    pagination.Page,
 		PerPage: pagination.PerPage,
 	})
@@ -147,6 +200,7 @@ func (h *SpecHandler) UpdateSpec(w http.ResponseWriter, r *http.Request) {
 	spec, err := svc.Update(specID, service.UpdateSpecInput{
 		Title:       req.Title,
 		Description: req.Description,
+		DueAt:       request.ParseTime(req.DueAt),
 	}, agentID)
 	if err != nil {
 		response.Error(w, err)
@@ -321,11 +375,30 @@ type SpecResponse struct {
 	Status      string  `json:"status"`
 	TaskCount   int     `json:"task_count"`
 	DoneCount   int     `json:"done_count"`
+	DueAt       *string `json:"due_at,omitempty"`
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   string  `json:"updated_at"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
+}
+
+// SpecInstanceResponse is the API response for a spec created from a template.
+type SpecInstanceResponse struct {
+	Spec  SpecResponse   `json:"spec"`
+	Tasks []*domain.Task `json:"tasks"`
 }
 
 func specWithStatus(spec *domain.Spec) SpecResponse {
+	var deletedAt *string
+	if spec.DeletedAt != nil {
+		t := spec.DeletedAt.Format("2006-01-02T15:04:05Z07:00")
+		deletedAt = &t
+	}
+	var dueAt *string
+	if spec.DueAt != nil {
+		t := spec.DueAt.Format("2006-01-02T15:04:05Z07:00")
+		dueAt = &t
+	}
+
 	return SpecResponse{
 		ID:          spec.ID,
 		Title:       spec.Title,
@@ -333,7 +406,9 @@ func specWithStatus(spec *domain.Spec) SpecResponse {
 		Status:      string(spec.ComputeStatus()),
 		TaskCount:   spec.TaskCount,
 		DoneCount:   spec.DoneCount,
+		DueAt:       dueAt,
 		CreatedAt:   spec.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   spec.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		DeletedAt:   deletedAt,
 	}
 }
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"database/sql"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -10,15 +12,19 @@ import (
 	"github.com/airyra/airyra/internal/api/response"
 	"github.com/airyra/airyra/internal/domain"
 	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store"
 	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 // TaskHandler handles task CRUD operations.
-type TaskHandler struct{}
+type TaskHandler struct {
+	manager *store.Manager
+}
 
-// NewTaskHandler creates a new TaskHandler.
-func NewTaskHandler() *TaskHandler {
-	return &TaskHandler{}
+// NewTaskHandler creates a new TaskHandler. The manager gives access to other
+// projects, whose tasks can hold back the ready queue.
+func NewTaskHandler(manager *store.Manager) *TaskHandler {
+	return &TaskHandler{manager: manager}
 }
 
 // CreateTask handles POST /tasks.
@@ -47,6 +53,11 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		Priority:    req.Priority,
 		ParentID:    req.ParentID,
 		SpecID:      req.SpecID,
+		Estimate:    req.Estimate,
+		DueAt:       request.ParseTime(req.DueAt),
+		DeferUntil:  request.ParseTime(req.DeferUntil),
+		DeferEvent:  req.DeferEvent,
+		Assignee:    req.Assignee,
 	}, agentID)
 	if err != nil {
 		response.Error(w, err)
@@ -71,9 +82,59 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := explainSpecGate(svc, task, db); err != nil {
+		response.Error(w, err)
+		return
+	}
+
 	response.OK(w, task)
 }
 
+// ListChildren handles GET /tasks/{id}/children.
+func (h *TaskHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTaskService(taskRepo, auditRepo)
+
+	children, err := svc.ListChildren(taskID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if children == nil {
+		children = []*domain.Task{}
+	}
+
+	response.OK(w, children)
+}
+
+// GetTaskTree handles GET /tasks/{id}/tree.
+func (h *TaskHandler) GetTaskTree(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTaskService(taskRepo, auditRepo)
+
+	tree, err := svc.Tree(taskID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if err := explainSpecGate(svc, tree.Task, db); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, tree)
+}
+
 // ListTasks handles GET /tasks.
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	pagination := request.ParsePagination(r)
@@ -85,9 +146,12 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	svc := service.NewTaskService(taskRepo, auditRepo)
 
 	tasks, total, err := svc.List(service.ListTasksInput{
-		Status:  status,
-		Page:    pagination.Page,
-		PerPage: pagination.PerPage,
+		Status:      status,
+		ClaimedBy:   request.ParseClaimedBy(r, middleware.GetAgentID(r.Context())),
+		Overdue:     request.ParseOverdue(r),
+		Quarantined: request.ParseQuarantined(r),
+		Page:        pagination.Page,
+		PerPage:     pagination.PerPage,
 	})
 	if err != nil {
 		response.Error(w, err)
@@ -108,9 +172,29 @@ func (h *TaskHandler) ListReadyTasks(w http.ResponseWriter, r *http.Request) {
 	db := middleware.GetDB(r.Context())
 	taskRepo := sqlite.NewTaskRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
+	depRepo := sqlite.NewDependencyRepository(db)
 	svc := service.NewTaskService(taskRepo, auditRepo)
+	depSvc := service.NewDependencyService(depRepo, taskRepo, auditRepo, h.manager, middleware.GetProject(r.Context()))
+
+	waiting, err := depSvc.Waiting()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	settings, err := service.NewSettingsService(sqlite.NewSettingsRepository(db)).Get()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
 
-	tasks, total, err := svc.ListReady(pagination.Page, pagination.PerPage)
+	now := time.Now()
+	tasks, total, err := svc.ListReady(pagination.Page, pagination.PerPage, sqlite.ReadyOptions{
+		Now:      now,
+		Waiting:  waiting,
+		SpecGate: settings.SpecDependenciesGateTasks,
+		DueSoon:  now.Add(domain.DueSoonWindow),
+		Agent:    middleware.GetAgentID(r.Context()),
+	})
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -150,6 +234,11 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		Description: req.Description,
 		Priority:    req.Priority,
 		ParentID:    req.ParentID,
+		Estimate:    req.Estimate,
+		DueAt:       request.ParseTime(req.DueAt),
+		DeferUntil:  request.ParseTime(req.DeferUntil),
+		DeferEvent:  req.DeferEvent,
+		Assignee:    req.Assignee,
 	}, agentID)
 	if err != nil {
 		response.Error(w, err)
@@ -177,3 +266,13 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 
 	response.NoContent(w)
 }
+
+// explainSpecGate records on a task the unfinished specs that keep it out of
+// the ready queue, according to the project settings.
+func explainSpecGate(svc *service.TaskService, task *domain.Task, db *sql.DB) error {
+	settings, err := service.NewSettingsService(sqlite.NewSettingsRepository(db)).Get()
+	if err != nil {
+		return err
+	}
+	return svc.ExplainSpecGate(task, settings)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// TimelineHandler handles time tracking operations.
+type TimelineHandler struct{}
+
+// NewTimelineHandler creates a new TimelineHandler.
+func NewTimelineHandler() *TimelineHandler {
+	return &TimelineHandler{}
+}
+
+// GetTaskTimeline handles GET /tasks/{id}/timeline.
+func (h *TimelineHandler) GetTaskTimeline(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	svc := service.NewTimelineService(sqlite.NewTaskRepository(db), sqlite.NewAuditRepository(db), sqlite.NewWorkflowRepository(db))
+
+	timeline, err := svc.Get(id)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, timeline)
+}
+
+// GetTimeReport handles GET /reports/time.
+func (h *TimelineHandler) GetTimeReport(w http.ResponseWriter, r *http.Request) {
+	db := middleware.GetDB(r.Context())
+	svc := service.NewTimelineService(sqlite.NewTaskRepository(db), sqlite.NewAuditRepository(db), sqlite.NewWorkflowRepository(db))
+
+	report, err := svc.Report()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, report)
+}
@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
 	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
 	"github.com/airyra/airyra/internal/service"
 	"github.com/airyra/airyra/internal/store/sqlite"
 )
@@ -28,7 +31,11 @@ func (h *TransitionHandler) ClaimTask(w http.ResponseWriter, r *http.Request) {
 
 	taskRepo := sqlite.NewTaskRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewTransitionService(taskRepo, auditRepo)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
 
 	task, err := svc.Claim(taskID, agentID)
 	if err != nil {
@@ -48,7 +55,11 @@ func (h *TransitionHandler) CompleteTask(w http.ResponseWriter, r *http.Request)
 
 	taskRepo := sqlite.NewTaskRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewTransitionService(taskRepo, auditRepo)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
 
 	task, err := svc.Complete(taskID, agentID)
 	if err != nil {
@@ -59,6 +70,65 @@ func (h *TransitionHandler) CompleteTask(w http.ResponseWriter, r *http.Request)
 	response.OK(w, task)
 }
 
+// ApproveTask handles POST /tasks/{id}/approve.
+func (h *TransitionHandler) ApproveTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
+
+	task, err := svc.Approve(taskID, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
+
+// RejectTask handles POST /tasks/{id}/reject.
+func (h *TransitionHandler) RejectTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req request.RejectTaskRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
+
+	task, err := svc.Reject(taskID, agentID, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
+
 // ReleaseTask handles POST /tasks/{id}/release.
 func (h *TransitionHandler) ReleaseTask(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
@@ -71,7 +141,11 @@ func (h *TransitionHandler) ReleaseTask(w http.ResponseWriter, r *http.Request)
 
 	taskRepo := sqlite.NewTaskRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewTransitionService(taskRepo, auditRepo)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
 
 	task, err := svc.Release(taskID, agentID, force)
 	if err != nil {
@@ -82,18 +156,72 @@ func (h *TransitionHandler) ReleaseTask(w http.ResponseWriter, r *http.Request)
 	response.OK(w, task)
 }
 
+// HandoffTask handles POST /tasks/{id}/handoff.
+func (h *TransitionHandler) HandoffTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req request.HandoffTaskRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	// Check for force parameter
+	force := r.URL.Query().Get("force") == "true"
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
+
+	task, err := svc.Handoff(taskID, agentID, req.Agent, force)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
+
 // BlockTask handles POST /tasks/{id}/block.
 func (h *TransitionHandler) BlockTask(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
 
+	// The body is optional for backwards compatibility
+	var req request.BlockTaskRequest
+	if err := request.DecodeJSON(r, &req); err != nil && err != io.EOF {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
 	db := middleware.GetDB(r.Context())
 	agentID := middleware.GetAgentID(r.Context())
 
 	taskRepo := sqlite.NewTaskRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewTransitionService(taskRepo, auditRepo)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
 
-	task, err := svc.Block(taskID, agentID)
+	task, err := svc.Block(taskID, agentID, req.Reason, req.BlockedBy, req.AutoUnblock)
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -111,7 +239,11 @@ func (h *TransitionHandler) UnblockTask(w http.ResponseWriter, r *http.Request)
 
 	taskRepo := sqlite.NewTaskRepository(db)
 	auditRepo := sqlite.NewAuditRepository(db)
-	svc := service.NewTransitionService(taskRepo, auditRepo)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
 
 	task, err := svc.Unblock(taskID, agentID)
 	if err != nil {
@@ -121,3 +253,98 @@ func (h *TransitionHandler) UnblockTask(w http.ResponseWriter, r *http.Request)
 
 	response.OK(w, task)
 }
+
+// CancelTask handles POST /tasks/{id}/cancel.
+func (h *TransitionHandler) CancelTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	// The body is optional; it only carries the reason
+	var req request.CancelTaskRequest
+	if err := request.DecodeJSON(r, &req); err != nil && err != io.EOF {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
+
+	task, err := svc.Cancel(taskID, agentID, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
+
+// ReopenTask handles POST /tasks/{id}/reopen.
+func (h *TransitionHandler) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
+
+	task, err := svc.Reopen(taskID, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
+
+// TransitionTask handles POST /tasks/{id}/transition.
+func (h *TransitionHandler) TransitionTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req request.TransitionTaskRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	workflowRepo := sqlite.NewWorkflowRepository(db)
+	commentRepo := sqlite.NewCommentRepository(db)
+	settingsRepo := sqlite.NewSettingsRepository(db)
+	agentRepo := sqlite.NewAgentRepository(db)
+	svc := service.NewTransitionService(taskRepo, auditRepo, workflowRepo, commentRepo, settingsRepo, agentRepo)
+
+	task, err := svc.Move(taskID, agentID, domain.TaskStatus(req.Status))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// TrashHandler handles listing, restoring and purging deleted tasks and specs.
+type TrashHandler struct{}
+
+// NewTrashHandler creates a new TrashHandler.
+func NewTrashHandler() *TrashHandler {
+	return &TrashHandler{}
+}
+
+// ListTrash handles GET /trash.
+func (h *TrashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	specRepo := sqlite.NewSpecRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTrashService(taskRepo, specRepo, auditRepo)
+
+	trash, err := svc.List()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	resp := TrashResponse{
+		Tasks: trash.Tasks,
+		Specs: make([]SpecResponse, 0, len(trash.Specs)),
+	}
+	if resp.Tasks == nil {
+		resp.Tasks = []*domain.Task{}
+	}
+	for _, spec := range trash.Specs {
+		resp.Specs = append(resp.Specs, specWithStatus(spec))
+	}
+
+	response.OK(w, resp)
+}
+
+// EmptyTrash handles DELETE /trash.
+func (h *TrashHandler) EmptyTrash(w http.ResponseWriter, r *http.Request) {
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	specRepo := sqlite.NewSpecRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTrashService(taskRepo, specRepo, auditRepo)
+
+	purged, err := svc.Empty()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, map[string]int{"purged": purged})
+}
+
+// PurgeTrashItem handles DELETE /trash/{id}.
+func (h *TrashHandler) PurgeTrashItem(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	specRepo := sqlite.NewSpecRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTrashService(taskRepo, specRepo, auditRepo)
+
+	if err := svc.Purge(id); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// RestoreTask handles POST /tasks/{id}/restore.
+func (h *TrashHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	agentID := middleware.GetAgentID(r.Context())
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	specRepo := sqlite.NewSpecRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTrashService(taskRepo, specRepo, auditRepo)
+
+	task, err := svc.RestoreTask(taskID, agentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, task)
+}
+
+// RestoreSpec handles POST /specs/{id}/restore.
+func (h *TrashHandler) RestoreSpec(w http.ResponseWriter, r *http.Request) {
+	specID := chi.URLParam(r, "id")
+
+	db := middleware.GetDB(r.Context())
+	taskRepo := sqlite.NewTaskRepository(db)
+	specRepo := sqlite.NewSpecRepository(db)
+	auditRepo := sqlite.NewAuditRepository(db)
+	svc := service.NewTrashService(taskRepo, specRepo, auditRepo)
+
+	spec, err := svc.RestoreSpec(specID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, specWithStatus(spec))
+}
+
+// TrashResponse is the API response listing the deleted tasks and specs.
+type TrashResponse struct {
+	Tasks []*domain.Task `json:"tasks"`
+	Specs []SpecResponse `json:"specs"`
+}
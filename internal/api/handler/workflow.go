@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/airyra/airyra/internal/api/middleware"
+	"github.com/airyra/airyra/internal/api/request"
+	"github.com/airyra/airyra/internal/api/response"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// WorkflowHandler handles project workflow operations.
+type WorkflowHandler struct{}
+
+// NewWorkflowHandler creates a new WorkflowHandler.
+func NewWorkflowHandler() *WorkflowHandler {
+	return &WorkflowHandler{}
+}
+
+// GetWorkflow handles GET /workflow.
+func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	db := middleware.GetDB(r.Context())
+	svc := service.NewWorkflowService(sqlite.NewWorkflowRepository(db))
+
+	workflow, err := svc.Get()
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, workflow)
+}
+
+// SetWorkflow handles PUT /workflow.
+func (h *WorkflowHandler) SetWorkflow(w http.ResponseWriter, r *http.Request) {
+	var req request.SetWorkflowRequest
+	if err := request.DecodeJSON(r, &req); err != nil {
+		response.Error(w, domain.NewValidationError([]string{"Invalid JSON body"}))
+		return
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		response.Error(w, domain.NewValidationError(errors))
+		return
+	}
+
+	db := middleware.GetDB(r.Context())
+	svc := service.NewWorkflowService(sqlite.NewWorkflowRepository(db))
+
+	workflow, err := svc.Set(&domain.Workflow{
+		States:      req.States,
+		Transitions: req.Transitions,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, workflow)
+}
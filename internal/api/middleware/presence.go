@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// Presence middleware records the requesting agent as seen in the project.
+// It must run after AgentID and ProjectContext. Anonymous requests are not
+// tracked, and a failure to record presence is logged without failing the
+// request.
+func Presence(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agentID := GetAgentID(r.Context())
+		if db := GetDB(r.Context()); db != nil && agentID != DefaultAgentID {
+			if err := sqlite.NewAgentRepository(db).Touch(agentID, time.Now()); err != nil {
+				log.Printf("failed to record presence of %s: %v", agentID, err)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
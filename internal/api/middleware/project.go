@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
-	"regexp"
 
 	"github.com/go-chi/chi/v5"
 
@@ -20,9 +19,6 @@ const (
 	DBKey contextKey = "db"
 )
 
-// Valid project name pattern: alphanumeric, hyphens, underscores, 1-64 chars.
-var validProjectName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
-
 // ProjectContext middleware validates the project name and injects the DB connection.
 func ProjectContext(manager *store.Manager) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -30,7 +26,7 @@ func ProjectContext(manager *store.Manager) func(http.Handler) http.Handler {
 			project := chi.URLParam(r, "project")
 
 			// Validate project name
-			if !validProjectName.MatchString(project) {
+			if !domain.ValidProjectName(project) {
 				response.Error(w, domain.NewValidationError([]string{
 					"Invalid project name. Must be 1-64 alphanumeric characters, hyphens, or underscores.",
 				}))
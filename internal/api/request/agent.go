@@ -0,0 +1,69 @@
+package request
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// RegisterAgentRequest represents a request to register the requesting agent.
+type RegisterAgentRequest struct {
+	Name         *string           `json:"name,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	WIPLimit     *int              `json:"wip_limit,omitempty"`
+}
+
+// Validate validates the register agent request.
+func (r *RegisterAgentRequest) Validate() []string {
+	var errors []string
+
+	if r.Name != nil && *r.Name == "" {
+		errors = append(errors, "name cannot be empty")
+	}
+
+	for _, capability := range r.Capabilities {
+		if capability == "" {
+			errors = append(errors, "capabilities cannot be empty")
+			break
+		}
+	}
+
+	if r.WIPLimit != nil && *r.WIPLimit < 0 {
+		errors = append(errors, "wip_limit cannot be negative")
+	}
+
+	for key := range r.Metadata {
+		if key == "" {
+			errors = append(errors, "metadata keys cannot be empty")
+			break
+		}
+	}
+
+	return errors
+}
+
+// AgentQueryParams contains query parameters for listing agents.
+type AgentQueryParams struct {
+	State      domain.AgentState
+	StaleAfter time.Duration
+}
+
+// ParseAgentQuery extracts agent query parameters from the request. The stale
+// window is a Go duration such as 30m and falls back to the default when
+// invalid.
+func ParseAgentQuery(r *http.Request) AgentQueryParams {
+	params := AgentQueryParams{
+		State:      domain.AgentState(r.URL.Query().Get("state")),
+		StaleAfter: domain.DefaultAgentStaleAfter,
+	}
+
+	if s := r.URL.Query().Get("stale_after"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			params.StaleAfter = d
+		}
+	}
+
+	return params
+}
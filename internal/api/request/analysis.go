@@ -0,0 +1,37 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// DefaultFanOutLimit is the default number of blocking tasks listed by an analysis.
+const DefaultFanOutLimit = 10
+
+// AnalysisQueryParams contains query parameters for schedule analysis.
+type AnalysisQueryParams struct {
+	SpecID *string
+	Limit  int
+}
+
+// ParseAnalysisQuery extracts analysis query parameters from the request.
+// The limit falls back to the default when invalid and is capped at MaxPerPage.
+func ParseAnalysisQuery(r *http.Request) AnalysisQueryParams {
+	params := AnalysisQueryParams{Limit: DefaultFanOutLimit}
+
+	if specID := r.URL.Query().Get("spec"); specID != "" {
+		params.SpecID = &specID
+	}
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			params.Limit = v
+		}
+	}
+
+	if params.Limit > MaxPerPage {
+		params.Limit = MaxPerPage
+	}
+
+	return params
+}
@@ -0,0 +1,19 @@
+package request
+
+import "strings"
+
+// AddCommentRequest represents a request to comment on a task.
+type AddCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// Validate validates the add comment request.
+func (r *AddCommentRequest) Validate() []string {
+	var errors []string
+
+	if strings.TrimSpace(r.Body) == "" {
+		errors = append(errors, "body is required")
+	}
+
+	return errors
+}
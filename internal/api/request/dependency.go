@@ -1,6 +1,14 @@
 package request
 
+import (
+	"net/http"
+	"strings"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
 // AddDependencyRequest represents a request to add a dependency.
+// ParentID is a task ID, or "project/task-id" for a task in another project.
 type AddDependencyRequest struct {
 	ParentID string `json:"parent_id"`
 }
@@ -11,7 +19,16 @@ func (r *AddDependencyRequest) Validate() []string {
 
 	if r.ParentID == "" {
 		errors = append(errors, "parent_id is required")
+	} else if strings.Contains(r.ParentID, "/") {
+		if project, taskID := domain.ParseTaskRef(r.ParentID); !domain.ValidProjectName(project) || taskID == "" {
+			errors = append(errors, "parent_id must be a task ID or project/task-id")
+		}
 	}
 
 	return errors
 }
+
+// ParseTransitive reports whether the transitive query parameter is set to true.
+func ParseTransitive(r *http.Request) bool {
+	return r.URL.Query().Get("transitive") == "true"
+}
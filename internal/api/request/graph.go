@@ -0,0 +1,44 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// GraphQueryParams contains query parameters for graph queries.
+type GraphQueryParams struct {
+	SpecID    *string
+	TaskID    *string
+	Direction domain.GraphDirection
+}
+
+// ParseGraphQuery extracts graph query parameters from the request.
+func ParseGraphQuery(r *http.Request) GraphQueryParams {
+	params := GraphQueryParams{Direction: domain.GraphBoth}
+
+	if specID := r.URL.Query().Get("spec"); specID != "" {
+		params.SpecID = &specID
+	}
+
+	if taskID := r.URL.Query().Get("task"); taskID != "" {
+		params.TaskID = &taskID
+	}
+
+	if direction := r.URL.Query().Get("direction"); direction != "" {
+		params.Direction = domain.GraphDirection(direction)
+	}
+
+	return params
+}
+
+// Validate validates the graph query parameters.
+func (p *GraphQueryParams) Validate() []string {
+	var errors []string
+
+	if !p.Direction.IsValid() {
+		errors = append(errors, "direction must be one of: ancestors, descendants, both")
+	}
+
+	return errors
+}
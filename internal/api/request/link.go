@@ -0,0 +1,26 @@
+package request
+
+import "github.com/airyra/airyra/internal/domain"
+
+// AddLinkRequest represents a request to link a task to an external artifact.
+type AddLinkRequest struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Validate validates the add link request.
+func (r *AddLinkRequest) Validate() []string {
+	var errors []string
+
+	if r.Type == "" {
+		errors = append(errors, "type is required")
+	} else if !domain.LinkType(r.Type).IsValid() {
+		errors = append(errors, "type must be one of: commit, branch, pr, file")
+	}
+
+	if r.Value == "" {
+		errors = append(errors, "value is required")
+	}
+
+	return errors
+}
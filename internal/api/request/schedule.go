@@ -0,0 +1,42 @@
+package request
+
+import "github.com/airyra/airyra/internal/domain"
+
+// CreateScheduleRequest represents a request to create a schedule.
+type CreateScheduleRequest struct {
+	Cron        string               `json:"cron"`
+	CatchUp     domain.CatchUpPolicy `json:"catch_up,omitempty"`
+	Title       string               `json:"title"`
+	Description *string              `json:"description,omitempty"`
+	Priority    *int                 `json:"priority,omitempty"`
+	Estimate    *int                 `json:"estimate,omitempty"`
+	SpecID      *string              `json:"spec_id,omitempty"`
+}
+
+// Validate validates the create schedule request. The cron expression is
+// parsed by the service.
+func (r *CreateScheduleRequest) Validate() []string {
+	var errors []string
+
+	if r.Cron == "" {
+		errors = append(errors, "cron is required")
+	}
+
+	if r.Title == "" {
+		errors = append(errors, "title is required")
+	}
+
+	if r.CatchUp != "" && !r.CatchUp.IsValid() {
+		errors = append(errors, "catch_up must be once, all or skip")
+	}
+
+	if r.Priority != nil && !domain.ValidPriority(*r.Priority) {
+		errors = append(errors, "priority must be between 0 and 4")
+	}
+
+	if r.Estimate != nil && *r.Estimate < 0 {
+		errors = append(errors, "estimate cannot be negative")
+	}
+
+	return errors
+}
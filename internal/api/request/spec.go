@@ -10,6 +10,7 @@ import (
 type CreateSpecRequest struct {
 	Title       string  `json:"title"`
 	Description *string `json:"description,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
 }
 
 // Validate validates the create spec request.
@@ -20,6 +21,28 @@ func (r *CreateSpecRequest) Validate() []string {
 		errors = append(errors, "title is required")
 	}
 
+	if r.DueAt != nil && !validTime(*r.DueAt) {
+		errors = append(errors, "due_at must be an RFC 3339 time")
+	}
+
+	return errors
+}
+
+// InstantiateSpecRequest represents a request to create a spec from a template.
+type InstantiateSpecRequest struct {
+	Template *domain.SpecTemplate `json:"template"`
+	Vars     map[string]string    `json:"vars,omitempty"`
+}
+
+// Validate validates the instantiate spec request. The template itself is
+// validated once its variables are substituted.
+func (r *InstantiateSpecRequest) Validate() []string {
+	var errors []string
+
+	if r.Template == nil {
+		errors = append(errors, "template is required")
+	}
+
 	return errors
 }
 
@@ -27,6 +50,8 @@ func (r *CreateSpecRequest) Validate() []string {
 type UpdateSpecRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
+	// DueAt sets the due date; an empty string clears it.
+	DueAt *string `json:"due_at,omitempty"`
 }
 
 // Validate validates the update spec request.
@@ -37,6 +62,10 @@ func (r *UpdateSpecRequest) Validate() []string {
 		errors = append(errors, "title cannot be empty")
 	}
 
+	if r.DueAt != nil && !validTime(*r.DueAt) {
+		errors = append(errors, "due_at must be an RFC 3339 time")
+	}
+
 	return errors
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/airyra/airyra/internal/domain"
 )
@@ -15,6 +16,11 @@ type CreateTaskRequest struct {
 	Priority    *int    `json:"priority,omitempty"`
 	ParentID    *string `json:"parent_id,omitempty"`
 	SpecID      *string `json:"spec_id,omitempty"`
+	Estimate    *int    `json:"estimate,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
+	DeferUntil  *string `json:"defer_until,omitempty"`
+	DeferEvent  *string `json:"defer_event,omitempty"`
+	Assignee    *string `json:"assignee,omitempty"`
 }
 
 // Validate validates the create task request.
@@ -29,6 +35,26 @@ func (r *CreateTaskRequest) Validate() []string {
 		errors = append(errors, "priority must be between 0 and 4")
 	}
 
+	if r.Estimate != nil && *r.Estimate < 0 {
+		errors = append(errors, "estimate cannot be negative")
+	}
+
+	if r.DueAt != nil && !validTime(*r.DueAt) {
+		errors = append(errors, "due_at must be an RFC 3339 time")
+	}
+
+	if r.DeferUntil != nil && !validTime(*r.DeferUntil) {
+		errors = append(errors, "defer_until must be an RFC 3339 time")
+	}
+
+	if r.DeferEvent != nil && *r.DeferEvent != "" && !domain.ValidEventName(*r.DeferEvent) {
+		errors = append(errors, "defer_event: "+domain.EventNameRule)
+	}
+
+	if r.Assignee != nil && !validAssignee(*r.Assignee) {
+		errors = append(errors, "assignee group must name a capability after "+domain.AssigneeGroupPrefix)
+	}
+
 	return errors
 }
 
@@ -38,6 +64,18 @@ type UpdateTaskRequest struct {
 	Description *string `json:"description,omitempty"`
 	Priority    *int    `json:"priority,omitempty"`
 	ParentID    *string `json:"parent_id,omitempty"`
+	Estimate    *int    `json:"estimate,omitempty"`
+	// DueAt sets the due date; an empty string clears it.
+	DueAt *string `json:"due_at,omitempty"`
+	// DeferUntil keeps the task out of the ready queue until then; an empty
+	// string clears it.
+	DeferUntil *string `json:"defer_until,omitempty"`
+	// DeferEvent keeps the task out of the ready queue until the event is
+	// signalled; an empty string clears it.
+	DeferEvent *string `json:"defer_event,omitempty"`
+	// Assignee restricts who may claim the task to an agent or an @group; an
+	// empty string clears it.
+	Assignee *string `json:"assignee,omitempty"`
 }
 
 // Validate validates the update task request.
@@ -52,9 +90,57 @@ func (r *UpdateTaskRequest) Validate() []string {
 		errors = append(errors, "priority must be between 0 and 4")
 	}
 
+	if r.Estimate != nil && *r.Estimate < 0 {
+		errors = append(errors, "estimate cannot be negative")
+	}
+
+	if r.DueAt != nil && !validTime(*r.DueAt) {
+		errors = append(errors, "due_at must be an RFC 3339 time")
+	}
+
+	if r.DeferUntil != nil && !validTime(*r.DeferUntil) {
+		errors = append(errors, "defer_until must be an RFC 3339 time")
+	}
+
+	if r.DeferEvent != nil && *r.DeferEvent != "" && !domain.ValidEventName(*r.DeferEvent) {
+		errors = append(errors, "defer_event: "+domain.EventNameRule)
+	}
+
+	if r.Assignee != nil && !validAssignee(*r.Assignee) {
+		errors = append(errors, "assignee group must name a capability after "+domain.AssigneeGroupPrefix)
+	}
+
 	return errors
 }
 
+// validAssignee reports whether an assignee is empty, an agent or a group
+// naming a capability.
+func validAssignee(s string) bool {
+	return s != domain.AssigneeGroupPrefix
+}
+
+// validTime reports whether a time field is empty or an RFC 3339 time.
+func validTime(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
+// ParseTime converts a validated time field: nil when absent, the zero time
+// when empty, the time it holds otherwise.
+func ParseTime(s *string) *time.Time {
+	if s == nil {
+		return nil
+	}
+	var t time.Time
+	if *s != "" {
+		t, _ = time.Parse(time.RFC3339, *s)
+	}
+	return &t
+}
+
 // DecodeJSON decodes JSON from request body into the given value.
 func DecodeJSON(r *http.Request, v interface{}) error {
 	return json.NewDecoder(r.Body).Decode(v)
@@ -99,6 +185,32 @@ func ParsePagination(r *http.Request) Pagination {
 	return Pagination{Page: page, PerPage: perPage}
 }
 
+// ClaimedByMe is the claimed_by filter value that stands for the requesting agent.
+const ClaimedByMe = "me"
+
+// ParseClaimedBy extracts the claimed_by filter from query parameters,
+// resolving "me" to agentID.
+func ParseClaimedBy(r *http.Request, agentID string) *string {
+	claimedBy := r.URL.Query().Get("claimed_by")
+	if claimedBy == "" {
+		return nil
+	}
+	if claimedBy == ClaimedByMe {
+		claimedBy = agentID
+	}
+	return &claimedBy
+}
+
+// ParseOverdue reports whether the overdue query parameter is set to true.
+func ParseOverdue(r *http.Request) bool {
+	return r.URL.Query().Get("overdue") == "true"
+}
+
+// ParseQuarantined reports whether the quarantined query parameter is set to true.
+func ParseQuarantined(r *http.Request) bool {
+	return r.URL.Query().Get("quarantined") == "true"
+}
+
 // ParseStatus extracts status filter from query parameters.
 func ParseStatus(r *http.Request) *domain.TaskStatus {
 	s := r.URL.Query().Get("status")
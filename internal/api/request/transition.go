@@ -0,0 +1,101 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// BlockTaskRequest represents a request to block a task.
+// All fields are optional; an empty body blocks the task without details.
+type BlockTaskRequest struct {
+	Reason      *string `json:"reason,omitempty"`
+	BlockedBy   *string `json:"blocked_by,omitempty"`
+	AutoUnblock bool    `json:"auto_unblock,omitempty"`
+}
+
+// Validate validates the block task request.
+func (r *BlockTaskRequest) Validate() []string {
+	var errors []string
+
+	if r.Reason != nil && strings.TrimSpace(*r.Reason) == "" {
+		errors = append(errors, "reason cannot be empty")
+	}
+
+	if r.BlockedBy != nil && strings.TrimSpace(*r.BlockedBy) == "" {
+		errors = append(errors, "blocked_by cannot be empty")
+	}
+
+	if r.AutoUnblock && r.BlockedBy == nil {
+		errors = append(errors, "auto_unblock requires blocked_by")
+	}
+
+	return errors
+}
+
+// RejectTaskRequest represents a request to reject a task under review.
+type RejectTaskRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate validates the reject task request.
+func (r *RejectTaskRequest) Validate() []string {
+	var errors []string
+
+	if strings.TrimSpace(r.Reason) == "" {
+		errors = append(errors, "reason is required")
+	}
+
+	return errors
+}
+
+// CancelTaskRequest represents a request to cancel a task.
+// The reason is optional.
+type CancelTaskRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// Validate validates the cancel task request.
+func (r *CancelTaskRequest) Validate() []string {
+	var errors []string
+
+	if r.Reason != nil && strings.TrimSpace(*r.Reason) == "" {
+		errors = append(errors, "reason cannot be empty")
+	}
+
+	return errors
+}
+
+// HandoffTaskRequest represents a request to hand a claimed task to another agent.
+type HandoffTaskRequest struct {
+	Agent string `json:"agent"`
+}
+
+// Validate validates the handoff task request.
+func (r *HandoffTaskRequest) Validate() []string {
+	var errors []string
+
+	if strings.TrimSpace(r.Agent) == "" {
+		errors = append(errors, "agent is required")
+	}
+
+	return errors
+}
+
+// TransitionTaskRequest represents a request to move a task to a workflow state.
+type TransitionTaskRequest struct {
+	Status string `json:"status"`
+}
+
+// Validate validates the transition task request.
+func (r *TransitionTaskRequest) Validate() []string {
+	var errors []string
+
+	if r.Status == "" {
+		errors = append(errors, "status is required")
+	} else if !domain.TaskStatus(r.Status).IsValid() {
+		errors = append(errors, "status must be a valid workflow state name")
+	}
+
+	return errors
+}
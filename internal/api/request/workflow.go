@@ -0,0 +1,20 @@
+package request
+
+import "github.com/airyra/airyra/internal/domain"
+
+// SetWorkflowRequest represents a request to replace the project workflow.
+type SetWorkflowRequest struct {
+	States      []domain.WorkflowState      `json:"states"`
+	Transitions []domain.WorkflowTransition `json:"transitions"`
+}
+
+// Validate validates the set workflow request.
+func (r *SetWorkflowRequest) Validate() []string {
+	var errors []string
+
+	if len(r.States) == 0 {
+		errors = append(errors, "states is required")
+	}
+
+	return errors
+}
@@ -93,11 +93,12 @@ func NoContent(w http.ResponseWriter) {
 func mapErrorCodeToStatus(code domain.ErrorCode) int {
 	switch code {
 	case domain.ErrCodeTaskNotFound, domain.ErrCodeProjectNotFound, domain.ErrCodeDependencyNotFound,
-		domain.ErrCodeSpecNotFound, domain.ErrCodeSpecDepNotFound:
+		domain.ErrCodeSpecNotFound, domain.ErrCodeSpecDepNotFound, domain.ErrCodeScheduleNotFound:
 		return http.StatusNotFound
-	case domain.ErrCodeAlreadyClaimed, domain.ErrCodeSpecAlreadyCancelled:
+	case domain.ErrCodeAlreadyClaimed, domain.ErrCodeSpecAlreadyCancelled, domain.ErrCodeChildrenNotDone,
+		domain.ErrCodeWIPLimitExceeded:
 		return http.StatusConflict
-	case domain.ErrCodeNotOwner:
+	case domain.ErrCodeNotOwner, domain.ErrCodeSelfReview, domain.ErrCodeNotAssignee:
 		return http.StatusForbidden
 	case domain.ErrCodeInvalidTransition, domain.ErrCodeValidationFailed, domain.ErrCodeCycleDetected,
 		domain.ErrCodeSpecNotCancelled:
@@ -21,11 +21,22 @@ func NewRouter(manager *store.Manager) *chi.Mux {
 
 	// Initialize handlers
 	systemHandler := handler.NewSystemHandler(manager)
-	taskHandler := handler.NewTaskHandler()
+	taskHandler := handler.NewTaskHandler(manager)
 	transitionHandler := handler.NewTransitionHandler()
-	dependencyHandler := handler.NewDependencyHandler()
+	dependencyHandler := handler.NewDependencyHandler(manager)
 	auditHandler := handler.NewAuditHandler()
-	specHandler := handler.NewSpecHandler()
+	specHandler := handler.NewSpecHandler(manager)
+	scheduleHandler := handler.NewScheduleHandler()
+	eventHandler := handler.NewEventHandler()
+	linkHandler := handler.NewLinkHandler()
+	commentHandler := handler.NewCommentHandler()
+	workflowHandler := handler.NewWorkflowHandler()
+	trashHandler := handler.NewTrashHandler()
+	settingsHandler := handler.NewSettingsHandler()
+	graphHandler := handler.NewGraphHandler()
+	analysisHandler := handler.NewAnalysisHandler()
+	timelineHandler := handler.NewTimelineHandler()
+	agentHandler := handler.NewAgentHandler()
 
 	// System routes (no project context needed)
 	r.Get("/v1/health", systemHandler.Health)
@@ -35,6 +46,7 @@ func NewRouter(manager *store.Manager) *chi.Mux {
 	r.Route("/v1/projects/{project}", func(r chi.Router) {
 		// Apply project context middleware
 		r.Use(middleware.ProjectContext(manager))
+		r.Use(middleware.Presence)
 
 		// Task CRUD
 		r.Get("/tasks", taskHandler.ListTasks)
@@ -44,17 +56,75 @@ func NewRouter(manager *store.Manager) *chi.Mux {
 		r.Patch("/tasks/{id}", taskHandler.UpdateTask)
 		r.Delete("/tasks/{id}", taskHandler.DeleteTask)
 
+		// Subtasks
+		r.Get("/tasks/{id}/children", taskHandler.ListChildren)
+		r.Get("/tasks/{id}/tree", taskHandler.GetTaskTree)
+
 		// Status transitions
 		r.Post("/tasks/{id}/claim", transitionHandler.ClaimTask)
 		r.Post("/tasks/{id}/done", transitionHandler.CompleteTask)
+		r.Post("/tasks/{id}/approve", transitionHandler.ApproveTask)
+		r.Post("/tasks/{id}/reject", transitionHandler.RejectTask)
 		r.Post("/tasks/{id}/release", transitionHandler.ReleaseTask)
+		r.Post("/tasks/{id}/handoff", transitionHandler.HandoffTask)
 		r.Post("/tasks/{id}/block", transitionHandler.BlockTask)
 		r.Post("/tasks/{id}/unblock", transitionHandler.UnblockTask)
+		r.Post("/tasks/{id}/cancel", transitionHandler.CancelTask)
+		r.Post("/tasks/{id}/reopen", transitionHandler.ReopenTask)
+		r.Post("/tasks/{id}/transition", transitionHandler.TransitionTask)
+		r.Post("/tasks/{id}/restore", trashHandler.RestoreTask)
 
 		// Dependencies
 		r.Get("/tasks/{id}/deps", dependencyHandler.ListDependencies)
 		r.Post("/tasks/{id}/deps", dependencyHandler.AddDependency)
 		r.Delete("/tasks/{id}/deps/{depID}", dependencyHandler.RemoveDependency)
+		r.Delete("/tasks/{id}/deps/{depProject}/{depID}", dependencyHandler.RemoveDependency)
+		r.Get("/tasks/{id}/dependents", dependencyHandler.ListDependents)
+
+		// Links
+		r.Get("/tasks/{id}/links", linkHandler.ListLinks)
+		r.Post("/tasks/{id}/links", linkHandler.AddLink)
+
+		// Comments
+		r.Get("/tasks/{id}/comments", commentHandler.ListComments)
+		r.Post("/tasks/{id}/comments", commentHandler.AddComment)
+
+		// Workflow
+		r.Get("/workflow", workflowHandler.GetWorkflow)
+		r.Put("/workflow", workflowHandler.SetWorkflow)
+
+		// Settings
+		r.Get("/settings", settingsHandler.GetSettings)
+		r.Patch("/settings", settingsHandler.UpdateSettings)
+
+		// Trash
+		r.Get("/trash", trashHandler.ListTrash)
+		r.Delete("/trash", trashHandler.EmptyTrash)
+		r.Delete("/trash/{id}", trashHandler.PurgeTrashItem)
+
+		// Schedules
+		r.Get("/schedules", scheduleHandler.ListSchedules)
+		r.Post("/schedules", scheduleHandler.CreateSchedule)
+		r.Get("/schedules/{id}", scheduleHandler.GetSchedule)
+		r.Delete("/schedules/{id}", scheduleHandler.DeleteSchedule)
+		r.Post("/schedules/{id}/run", scheduleHandler.RunSchedule)
+
+		// Events
+		r.Post("/events/{name}", eventHandler.SignalEvent)
+
+		// Graph
+		r.Get("/graph", graphHandler.GetGraph)
+
+		// Analysis
+		r.Get("/analysis", analysisHandler.GetAnalysis)
+
+		// Time tracking
+		r.Get("/tasks/{id}/timeline", timelineHandler.GetTaskTimeline)
+		r.Get("/reports/time", timelineHandler.GetTimeReport)
+
+		// Agents
+		r.Get("/agents", agentHandler.ListAgents)
+		r.Post("/agents", agentHandler.RegisterAgent)
 
 		// Audit
 		r.Get("/tasks/{id}/history", auditHandler.GetTaskHistory)
@@ -63,6 +133,7 @@ func NewRouter(manager *store.Manager) *chi.Mux {
 		// Specs CRUD
 		r.Get("/specs", specHandler.ListSpecs)
 		r.Post("/specs", specHandler.CreateSpec)
+		r.Post("/specs/instantiate", specHandler.InstantiateSpec)
 		r.Get("/specs/ready", specHandler.ListReadySpecs)
 		r.Get("/specs/{id}", specHandler.GetSpec)
 		r.Patch("/specs/{id}", specHandler.UpdateSpec)
@@ -71,9 +142,11 @@ func NewRouter(manager *store.Manager) *chi.Mux {
 		// Spec actions
 		r.Post("/specs/{id}/cancel", specHandler.CancelSpec)
 		r.Post("/specs/{id}/reopen", specHandler.ReopenSpec)
+		r.Post("/specs/{id}/restore", trashHandler.RestoreSpec)
 
 		// Spec tasks
 		r.Get("/specs/{id}/tasks", specHandler.ListSpecTasks)
+		r.Get("/specs/{id}/progress", specHandler.GetSpecProgress)
 
 		// Spec dependencies
 		r.Get("/specs/{id}/deps", specHandler.ListSpecDependencies)
@@ -166,8 +166,6 @@ func (c *Client) GetTask(ctx context.Context, id string) (*domain.Task, error) {
 
 // ListTasks lists tasks with optional filtering.
 func (c *Client) ListTasks(ctx context.Context, status string, page, perPage int) (*TaskListResponse, error) {
-	path := c.projectPath("/tasks")
-
 	// Build query parameters
 	params := url.Values{}
 	if status != "" {
@@ -176,9 +174,48 @@ func (c *Client) ListTasks(ctx context.Context, status string, page, perPage int
 	params.Set("page", strconv.Itoa(page))
 	params.Set("per_page", strconv.Itoa(perPage))
 
-	if len(params) > 0 {
-		path = path + "?" + params.Encode()
+	return c.listTasks(ctx, params)
+}
+
+// ListOverdueTasks lists the unfinished tasks past their due date, earliest
+// due first.
+func (c *Client) ListOverdueTasks(ctx context.Context, page, perPage int) (*TaskListResponse, error) {
+	params := url.Values{}
+	params.Set("overdue", "true")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	return c.listTasks(ctx, params)
+}
+
+// ListQuarantinedTasks lists the tasks quarantined after too many failed
+// attempts, most recently quarantined first.
+func (c *Client) ListQuarantinedTasks(ctx context.Context, page, perPage int) (*TaskListResponse, error) {
+	params := url.Values{}
+	params.Set("quarantined", "true")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	return c.listTasks(ctx, params)
+}
+
+// ListMyTasks lists the tasks claimed by the client's agent, optionally
+// filtered by status.
+func (c *Client) ListMyTasks(ctx context.Context, status string, page, perPage int) (*TaskListResponse, error) {
+	params := url.Values{}
+	params.Set("claimed_by", "me")
+	if status != "" {
+		params.Set("status", status)
 	}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	return c.listTasks(ctx, params)
+}
+
+// listTasks lists tasks matching the query parameters.
+func (c *Client) listTasks(ctx context.Context, params url.Values) (*TaskListResponse, error) {
+	path := c.projectPath("/tasks") + "?" + params.Encode()
 
 	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -263,6 +300,11 @@ func (c *Client) UpdateTask(ctx context.Context, id string, updates TaskUpdates)
 		Title:       updates.Title,
 		Description: updates.Description,
 		Priority:    updates.Priority,
+		Estimate:    updates.Estimate,
+		DueAt:       updates.DueAt,
+		DeferUntil:  updates.DeferUntil,
+		DeferEvent:  updates.DeferEvent,
+		Assignee:    updates.Assignee,
 	}
 
 	req, err := c.newJSONRequest(ctx, http.MethodPatch, c.projectPath("/tasks/"+id), body)
@@ -291,7 +333,7 @@ func (c *Client) UpdateTask(ctx context.Context, id string, updates TaskUpdates)
 	return &task, nil
 }
 
-// DeleteTask deletes a task.
+// DeleteTask moves a task and its subtasks to the trash.
 func (c *Client) DeleteTask(ctx context.Context, id string) error {
 	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/tasks/"+id), nil)
 	if err != nil {
@@ -314,6 +356,66 @@ func (c *Client) DeleteTask(ctx context.Context, id string) error {
 	return nil
 }
 
+// =============================================================================
+// Subtasks
+// =============================================================================
+
+// ListChildren lists the direct subtasks of a task.
+func (c *Client) ListChildren(ctx context.Context, taskID string) ([]domain.Task, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/children"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list children failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var children []domain.Task
+	if err := json.NewDecoder(resp.Body).Decode(&children); err != nil {
+		return nil, fmt.Errorf("failed to decode children response: %w", err)
+	}
+
+	return children, nil
+}
+
+// GetTaskTree retrieves a task together with all of its subtasks, recursively.
+func (c *Client) GetTaskTree(ctx context.Context, taskID string) (*domain.TaskTree, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/tree"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get task tree failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var tree domain.TaskTree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to decode task tree response: %w", err)
+	}
+
+	return &tree, nil
+}
+
 // =============================================================================
 // Status Transitions
 // =============================================================================
@@ -328,6 +430,16 @@ func (c *Client) CompleteTask(ctx context.Context, id string) (*domain.Task, err
 	return c.doTransition(ctx, id, "done")
 }
 
+// ApproveTask approves a task under review, marking it done.
+func (c *Client) ApproveTask(ctx context.Context, id string) (*domain.Task, error) {
+	return c.doTransition(ctx, id, "approve")
+}
+
+// RejectTask rejects a task under review, returning it to open with the reason as a comment.
+func (c *Client) RejectTask(ctx context.Context, id, reason string) (*domain.Task, error) {
+	return c.doTransitionWithBody(ctx, id, "reject", rejectTaskRequest{Reason: reason})
+}
+
 // ReleaseTask releases a claimed task.
 func (c *Client) ReleaseTask(ctx context.Context, id string, force bool) (*domain.Task, error) {
 	path := c.projectPath("/tasks/" + id + "/release")
@@ -335,7 +447,727 @@ func (c *Client) ReleaseTask(ctx context.Context, id string, force bool) (*domai
 		path = path + "?force=true"
 	}
 
-	req, err := c.newRequest(ctx, http.MethodPost, path, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("release task failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var task domain.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// BlockTask marks a task as blocked, recording why and what it is waiting on.
+func (c *Client) BlockTask(ctx context.Context, id string, opts BlockOptions) (*domain.Task, error) {
+	var body blockTaskRequest
+	if opts.Reason != "" {
+		body.Reason = &opts.Reason
+	}
+	if opts.BlockedBy != "" {
+		body.BlockedBy = &opts.BlockedBy
+	}
+	body.AutoUnblock = opts.AutoUnblock
+
+	return c.doTransitionWithBody(ctx, id, "block", body)
+}
+
+// UnblockTask unblocks a blocked task.
+func (c *Client) UnblockTask(ctx context.Context, id string) (*domain.Task, error) {
+	return c.doTransition(ctx, id, "unblock")
+}
+
+// CancelTask cancels a task that will not be done. An empty reason is omitted.
+func (c *Client) CancelTask(ctx context.Context, id, reason string) (*domain.Task, error) {
+	var body cancelTaskRequest
+	if reason != "" {
+		body.Reason = &reason
+	}
+	return c.doTransitionWithBody(ctx, id, "cancel", body)
+}
+
+// ReopenTask reopens a cancelled task.
+func (c *Client) ReopenTask(ctx context.Context, id string) (*domain.Task, error) {
+	return c.doTransition(ctx, id, "reopen")
+}
+
+// TransitionTask moves a task to a state of the project workflow.
+func (c *Client) TransitionTask(ctx context.Context, id, status string) (*domain.Task, error) {
+	return c.doTransitionWithBody(ctx, id, "transition", transitionTaskRequest{Status: status})
+}
+
+// HandoffTask hands a claimed task to another agent without releasing it.
+// With force, a project admin can hand off a task claimed by another agent.
+func (c *Client) HandoffTask(ctx context.Context, id, agent string, force bool) (*domain.Task, error) {
+	path := c.projectPath("/tasks/" + id + "/handoff")
+	if force {
+		path = path + "?force=true"
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, handoffTaskRequest{Agent: agent})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("handoff task failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var task domain.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// doTransition performs a status transition on a task.
+func (c *Client) doTransition(ctx context.Context, id, action string) (*domain.Task, error) {
+	return c.doTransitionWithBody(ctx, id, action, nil)
+}
+
+// doTransitionWithBody performs a status transition on a task, sending body as JSON when it is not nil.
+func (c *Client) doTransitionWithBody(ctx context.Context, id, action string, body interface{}) (*domain.Task, error) {
+	path := c.projectPath("/tasks/" + id + "/" + action)
+
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = c.newJSONRequest(ctx, http.MethodPost, path, body)
+	} else {
+		req, err = c.newRequest(ctx, http.MethodPost, path, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("%s task failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var task domain.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// =============================================================================
+// Dependencies
+// =============================================================================
+
+// AddDependency adds a dependency between two tasks. A parent in another
+// project is referenced as "project/task-id".
+func (c *Client) AddDependency(ctx context.Context, childID, parentID string) error {
+	body := addDependencyRequest{
+		ParentID: parentID,
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+childID+"/deps"), body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return ErrServerNotRunning
+		}
+		return fmt.Errorf("add dependency failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return parseErrorResponse(resp)
+	}
+
+	// Drain and discard response body
+	io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// RemoveDependency removes a dependency between two tasks.
+func (c *Client) RemoveDependency(ctx context.Context, childID, parentID string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/tasks/"+childID+"/deps/"+parentID), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return ErrServerNotRunning
+		}
+		return fmt.Errorf("remove dependency failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListDependencies lists the dependencies of a task. With transitive, the
+// dependencies of the tasks it depends on are included, recursively.
+func (c *Client) ListDependencies(ctx context.Context, taskID string, transitive bool) ([]domain.Dependency, error) {
+	return c.listDependencies(ctx, "/tasks/"+taskID+"/deps", transitive, "list dependencies")
+}
+
+// ListDependents lists the dependencies on a task, i.e. the tasks it blocks.
+// With transitive, the tasks blocked by those tasks are included, recursively.
+func (c *Client) ListDependents(ctx context.Context, taskID string, transitive bool) ([]domain.Dependency, error) {
+	return c.listDependencies(ctx, "/tasks/"+taskID+"/dependents", transitive, "list dependents")
+}
+
+// listDependencies fetches a list of dependencies from path.
+func (c *Client) listDependencies(ctx context.Context, path string, transitive bool, action string) ([]domain.Dependency, error) {
+	path = c.projectPath(path)
+	if transitive {
+		path += "?transitive=true"
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("%s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var deps []domain.Dependency
+	if err := json.NewDecoder(resp.Body).Decode(&deps); err != nil {
+		return nil, fmt.Errorf("failed to decode dependencies response: %w", err)
+	}
+
+	return deps, nil
+}
+
+// =============================================================================
+// Links
+// =============================================================================
+
+// AddLink links a task to a commit, branch, pull request or file.
+func (c *Client) AddLink(ctx context.Context, taskID string, linkType domain.LinkType, value string) (*domain.TaskLink, error) {
+	body := addLinkRequest{
+		Type:  string(linkType),
+		Value: value,
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+taskID+"/links"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("add link failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var link domain.TaskLink
+	if err := json.NewDecoder(resp.Body).Decode(&link); err != nil {
+		return nil, fmt.Errorf("failed to decode link response: %w", err)
+	}
+
+	return &link, nil
+}
+
+// ListLinks lists the links of a task.
+func (c *Client) ListLinks(ctx context.Context, taskID string) ([]domain.TaskLink, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/links"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list links failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var links []domain.TaskLink
+	if err := json.NewDecoder(resp.Body).Decode(&links); err != nil {
+		return nil, fmt.Errorf("failed to decode links response: %w", err)
+	}
+
+	return links, nil
+}
+
+// =============================================================================
+// Comments
+// =============================================================================
+
+// AddComment adds a comment to a task.
+func (c *Client) AddComment(ctx context.Context, taskID, body string) (*domain.Comment, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+taskID+"/comments"), addCommentRequest{Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("add comment failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var comment domain.Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// ListComments lists the comments on a task, oldest first.
+func (c *Client) ListComments(ctx context.Context, taskID string) ([]domain.Comment, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/comments"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list comments failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var comments []domain.Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+		return nil, fmt.Errorf("failed to decode comments response: %w", err)
+	}
+
+	return comments, nil
+}
+
+// =============================================================================
+// Workflow
+// =============================================================================
+
+// GetWorkflow retrieves the project workflow.
+func (c *Client) GetWorkflow(ctx context.Context) (*domain.Workflow, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/workflow"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doWorkflowRequest(req, "get workflow")
+}
+
+// SetWorkflow replaces the project workflow.
+func (c *Client) SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPut, c.projectPath("/workflow"), workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doWorkflowRequest(req, "set workflow")
+}
+
+// doWorkflowRequest sends a workflow request and decodes the returned workflow.
+func (c *Client) doWorkflowRequest(req *http.Request, action string) (*domain.Workflow, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("%s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var workflow domain.Workflow
+	if err := json.NewDecoder(resp.Body).Decode(&workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow response: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+// =============================================================================
+// Settings
+// =============================================================================
+
+// GetSettings retrieves the project settings.
+func (c *Client) GetSettings(ctx context.Context) (*domain.ProjectSettings, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/settings"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doSettingsRequest(req, "get settings")
+}
+
+// UpdateSettings changes the given project settings, keyed by their JSON
+// names. Settings that are not included keep their current values.
+func (c *Client) UpdateSettings(ctx context.Context, updates map[string]interface{}) (*domain.ProjectSettings, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, c.projectPath("/settings"), updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doSettingsRequest(req, "update settings")
+}
+
+// doSettingsRequest sends a settings request and decodes the returned settings.
+func (c *Client) doSettingsRequest(req *http.Request, action string) (*domain.ProjectSettings, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("%s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var settings domain.ProjectSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("failed to decode settings response: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// =============================================================================
+// Graph
+// =============================================================================
+
+// GetGraph retrieves the task and spec graph of the project, narrowed by filter.
+func (c *Client) GetGraph(ctx context.Context, filter GraphFilter) (*domain.Graph, error) {
+	path := c.projectPath("/graph")
+
+	params := url.Values{}
+	if filter.SpecID != "" {
+		params.Set("spec", filter.SpecID)
+	}
+	if filter.TaskID != "" {
+		params.Set("task", filter.TaskID)
+	}
+	if filter.Direction != "" {
+		params.Set("direction", filter.Direction)
+	}
+
+	if len(params) > 0 {
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get graph failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var graph domain.Graph
+	if err := json.NewDecoder(resp.Body).Decode(&graph); err != nil {
+		return nil, fmt.Errorf("failed to decode graph response: %w", err)
+	}
+
+	return &graph, nil
+}
+
+// =============================================================================
+// Analysis
+// =============================================================================
+
+// GetAnalysis retrieves the critical path, blocking fan-out and parallelism of
+// the project's unfinished tasks, narrowed by filter.
+func (c *Client) GetAnalysis(ctx context.Context, filter AnalysisFilter) (*domain.ScheduleAnalysis, error) {
+	path := c.projectPath("/analysis")
+
+	params := url.Values{}
+	if filter.SpecID != "" {
+		params.Set("spec", filter.SpecID)
+	}
+	if filter.Limit > 0 {
+		params.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	if len(params) > 0 {
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get analysis failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var analysis domain.ScheduleAnalysis
+	if err := json.NewDecoder(resp.Body).Decode(&analysis); err != nil {
+		return nil, fmt.Errorf("failed to decode analysis response: %w", err)
+	}
+
+	return &analysis, nil
+}
+
+// =============================================================================
+// Trash
+// =============================================================================
+
+// ListTrash lists the deleted tasks and specs.
+func (c *Client) ListTrash(ctx context.Context) (*Trash, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/trash"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list trash failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var trash Trash
+	if err := json.NewDecoder(resp.Body).Decode(&trash); err != nil {
+		return nil, fmt.Errorf("failed to decode trash response: %w", err)
+	}
+
+	return &trash, nil
+}
+
+// RestoreTask restores a deleted task and the subtasks deleted with it.
+func (c *Client) RestoreTask(ctx context.Context, id string) (*domain.Task, error) {
+	return c.doTransition(ctx, id, "restore")
+}
+
+// RestoreSpec restores a deleted spec.
+func (c *Client) RestoreSpec(ctx context.Context, id string) (*Spec, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/specs/"+id+"/restore"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("restore spec failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var spec Spec
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec response: %w", err)
+	}
+
+	return &spec, nil
+}
+
+// PurgeTrash permanently deletes a task or spec from the trash.
+func (c *Client) PurgeTrash(ctx context.Context, id string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/trash/"+id), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return ErrServerNotRunning
+		}
+		return fmt.Errorf("purge trash failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// EmptyTrash permanently deletes everything in the trash and returns the
+// number of tasks and specs removed.
+func (c *Client) EmptyTrash(ctx context.Context) (int, error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/trash"), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return 0, ErrServerNotRunning
+		}
+		return 0, fmt.Errorf("empty trash failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, parseErrorResponse(resp)
+	}
+
+	var result emptyTrashResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode empty trash response: %w", err)
+	}
+
+	return result.Purged, nil
+}
+
+// =============================================================================
+// Audit
+// =============================================================================
+
+// GetTaskHistory retrieves the audit history for a task.
+func (c *Client) GetTaskHistory(ctx context.Context, taskID string) ([]domain.AuditEntry, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/history"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get task history failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var entries []domain.AuditEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entries response: %w", err)
+	}
+
+	return entries, nil
+}
+
+// =============================================================================
+// Specs
+// =============================================================================
+
+// CreateSpec creates a new spec.
+func (c *Client) CreateSpec(ctx context.Context, title, description string) (*Spec, error) {
+	body := createSpecRequest{
+		Title: title,
+	}
+	if description != "" {
+		body.Description = &description
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/specs"), body)
 	if err != nil {
 		return nil, err
 	}
@@ -345,35 +1177,31 @@ func (c *Client) ReleaseTask(ctx context.Context, id string, force bool) (*domai
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("release task failed: %w", err)
+		return nil, fmt.Errorf("create spec failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusCreated {
 		return nil, parseErrorResponse(resp)
 	}
 
-	var task domain.Task
-	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
-		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	var spec Spec
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec response: %w", err)
 	}
 
-	return &task, nil
-}
-
-// BlockTask marks a task as blocked.
-func (c *Client) BlockTask(ctx context.Context, id string) (*domain.Task, error) {
-	return c.doTransition(ctx, id, "block")
+	return &spec, nil
 }
 
-// UnblockTask unblocks a blocked task.
-func (c *Client) UnblockTask(ctx context.Context, id string) (*domain.Task, error) {
-	return c.doTransition(ctx, id, "unblock")
-}
+// InstantiateSpec creates a spec with its tasks and their dependencies from a
+// template, substituting vars for the template variables.
+func (c *Client) InstantiateSpec(ctx context.Context, template *domain.SpecTemplate, vars map[string]string) (*SpecInstance, error) {
+	body := instantiateSpecRequest{
+		Template: template,
+		Vars:     vars,
+	}
 
-// doTransition performs a status transition on a task.
-func (c *Client) doTransition(ctx context.Context, id, action string) (*domain.Task, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+id+"/"+action), nil)
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/specs/instantiate"), body)
 	if err != nil {
 		return nil, err
 	}
@@ -383,82 +1211,81 @@ func (c *Client) doTransition(ctx context.Context, id, action string) (*domain.T
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("%s task failed: %w", action, err)
+		return nil, fmt.Errorf("instantiate spec failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusCreated {
 		return nil, parseErrorResponse(resp)
 	}
 
-	var task domain.Task
-	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
-		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	var instance SpecInstance
+	if err := json.NewDecoder(resp.Body).Decode(&instance); err != nil {
+		return nil, fmt.Errorf("failed to decode spec instance response: %w", err)
 	}
 
-	return &task, nil
+	return &instance, nil
 }
 
-// =============================================================================
-// Dependencies
-// =============================================================================
-
-// AddDependency adds a dependency between two tasks.
-func (c *Client) AddDependency(ctx context.Context, childID, parentID string) error {
-	body := addDependencyRequest{
-		ParentID: parentID,
-	}
-
-	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+childID+"/deps"), body)
+// GetSpec retrieves a spec by ID.
+func (c *Client) GetSpec(ctx context.Context, id string) (*Spec, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/specs/"+id), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
 		if isConnectionRefused(err) {
-			return ErrServerNotRunning
+			return nil, ErrServerNotRunning
 		}
-		return fmt.Errorf("add dependency failed: %w", err)
+		return nil, fmt.Errorf("get spec failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		return parseErrorResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
 	}
 
-	// Drain and discard response body
-	io.Copy(io.Discard, resp.Body)
+	var spec Spec
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec response: %w", err)
+	}
 
-	return nil
+	return &spec, nil
 }
 
-// RemoveDependency removes a dependency between two tasks.
-func (c *Client) RemoveDependency(ctx context.Context, childID, parentID string) error {
-	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/tasks/"+childID+"/deps/"+parentID), nil)
+// GetTaskTimeline retrieves how long a task spent in each status.
+func (c *Client) GetTaskTimeline(ctx context.Context, id string) (*domain.TaskTimeline, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+id+"/timeline"), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
 		if isConnectionRefused(err) {
-			return ErrServerNotRunning
+			return nil, ErrServerNotRunning
 		}
-		return fmt.Errorf("remove dependency failed: %w", err)
+		return nil, fmt.Errorf("get task timeline failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		return parseErrorResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
 	}
 
-	return nil
+	var timeline domain.TaskTimeline
+	if err := json.NewDecoder(resp.Body).Decode(&timeline); err != nil {
+		return nil, fmt.Errorf("failed to decode task timeline response: %w", err)
+	}
+
+	return &timeline, nil
 }
 
-// ListDependencies lists dependencies for a task.
-func (c *Client) ListDependencies(ctx context.Context, taskID string) ([]domain.Dependency, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/deps"), nil)
+// GetTimeReport retrieves the time spent on tasks per agent and per spec.
+func (c *Client) GetTimeReport(ctx context.Context) (*domain.TimeReport, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/reports/time"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -468,7 +1295,7 @@ func (c *Client) ListDependencies(ctx context.Context, taskID string) ([]domain.
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("list dependencies failed: %w", err)
+		return nil, fmt.Errorf("get time report failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -476,21 +1303,32 @@ func (c *Client) ListDependencies(ctx context.Context, taskID string) ([]domain.
 		return nil, parseErrorResponse(resp)
 	}
 
-	var deps []domain.Dependency
-	if err := json.NewDecoder(resp.Body).Decode(&deps); err != nil {
-		return nil, fmt.Errorf("failed to decode dependencies response: %w", err)
+	var report domain.TimeReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode time report response: %w", err)
 	}
 
-	return deps, nil
+	return &report, nil
 }
 
-// =============================================================================
-// Audit
-// =============================================================================
+// ListAgents lists the agents seen in the project with the tasks they hold,
+// most recently seen first, narrowed by filter.
+func (c *Client) ListAgents(ctx context.Context, filter AgentFilter) ([]*domain.Agent, error) {
+	path := c.projectPath("/agents")
 
-// GetTaskHistory retrieves the audit history for a task.
-func (c *Client) GetTaskHistory(ctx context.Context, taskID string) ([]domain.AuditEntry, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/history"), nil)
+	params := url.Values{}
+	if filter.State != "" {
+		params.Set("state", filter.State)
+	}
+	if filter.StaleAfter > 0 {
+		params.Set("stale_after", filter.StaleAfter.String())
+	}
+
+	if len(params) > 0 {
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -500,7 +1338,7 @@ func (c *Client) GetTaskHistory(ctx context.Context, taskID string) ([]domain.Au
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("get task history failed: %w", err)
+		return nil, fmt.Errorf("list agents failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -508,28 +1346,29 @@ func (c *Client) GetTaskHistory(ctx context.Context, taskID string) ([]domain.Au
 		return nil, parseErrorResponse(resp)
 	}
 
-	var entries []domain.AuditEntry
-	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
-		return nil, fmt.Errorf("failed to decode audit entries response: %w", err)
+	var agents []*domain.Agent
+	if err := json.NewDecoder(resp.Body).Decode(&agents); err != nil {
+		return nil, fmt.Errorf("failed to decode agents response: %w", err)
 	}
 
-	return entries, nil
+	return agents, nil
 }
 
-// =============================================================================
-// Specs
-// =============================================================================
-
-// CreateSpec creates a new spec.
-func (c *Client) CreateSpec(ctx context.Context, title, description string) (*Spec, error) {
-	body := createSpecRequest{
-		Title: title,
+// RegisterAgent registers the client's agent with a name, capabilities and
+// metadata, replacing any earlier registration.
+func (c *Client) RegisterAgent(ctx context.Context, input AgentRegistration) (*domain.Agent, error) {
+	body := registerAgentRequest{
+		Capabilities: input.Capabilities,
+		Metadata:     input.Metadata,
 	}
-	if description != "" {
-		body.Description = &description
+	if input.Name != "" {
+		body.Name = &input.Name
+	}
+	if input.WIPLimit > 0 {
+		body.WIPLimit = &input.WIPLimit
 	}
 
-	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/specs"), body)
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/agents"), body)
 	if err != nil {
 		return nil, err
 	}
@@ -539,25 +1378,25 @@ func (c *Client) CreateSpec(ctx context.Context, title, description string) (*Sp
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("create spec failed: %w", err)
+		return nil, fmt.Errorf("register agent failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		return nil, parseErrorResponse(resp)
 	}
 
-	var spec Spec
-	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
-		return nil, fmt.Errorf("failed to decode spec response: %w", err)
+	var agent domain.Agent
+	if err := json.NewDecoder(resp.Body).Decode(&agent); err != nil {
+		return nil, fmt.Errorf("failed to decode agent response: %w", err)
 	}
 
-	return &spec, nil
+	return &agent, nil
 }
 
-// GetSpec retrieves a spec by ID.
-func (c *Client) GetSpec(ctx context.Context, id string) (*Spec, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/specs/"+id), nil)
+// GetSpecProgress retrieves the progress report and burndown of a spec.
+func (c *Client) GetSpecProgress(ctx context.Context, id string) (*domain.SpecProgress, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/specs/"+id+"/progress"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -567,7 +1406,7 @@ func (c *Client) GetSpec(ctx context.Context, id string) (*Spec, error) {
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("get spec failed: %w", err)
+		return nil, fmt.Errorf("get spec progress failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -575,18 +1414,16 @@ func (c *Client) GetSpec(ctx context.Context, id string) (*Spec, error) {
 		return nil, parseErrorResponse(resp)
 	}
 
-	var spec Spec
-	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
-		return nil, fmt.Errorf("failed to decode spec response: %w", err)
+	var progress domain.SpecProgress
+	if err := json.NewDecoder(resp.Body).Decode(&progress); err != nil {
+		return nil, fmt.Errorf("failed to decode spec progress response: %w", err)
 	}
 
-	return &spec, nil
+	return &progress, nil
 }
 
 // ListSpecs lists specs with optional filtering.
 func (c *Client) ListSpecs(ctx context.Context, status string, page, perPage int) (*SpecListResponse, error) {
-	path := c.projectPath("/specs")
-
 	params := url.Values{}
 	if status != "" {
 		params.Set("status", status)
@@ -594,9 +1431,23 @@ func (c *Client) ListSpecs(ctx context.Context, status string, page, perPage int
 	params.Set("page", strconv.Itoa(page))
 	params.Set("per_page", strconv.Itoa(perPage))
 
-	if len(params) > 0 {
-		path = path + "?" + params.Encode()
-	}
+	return c.listSpecs(ctx, params)
+}
+
+// ListOverdueSpecs lists the unfinished specs past their due date, earliest
+// due first.
+func (c *Client) ListOverdueSpecs(ctx context.Context, page, perPage int) (*SpecListResponse, error) {
+	params := url.Values{}
+	params.Set("overdue", "true")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	return c.listSpecs(ctx, params)
+}
+
+// listSpecs lists specs matching the query parameters.
+func (c *Client) listSpecs(ctx context.Context, params url.Values) (*SpecListResponse, error) {
+	path := c.projectPath("/specs") + "?" + params.Encode()
 
 	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -723,6 +1574,7 @@ func (c *Client) UpdateSpec(ctx context.Context, id string, updates SpecUpdates)
 	body := updateSpecRequest{
 		Title:       updates.Title,
 		Description: updates.Description,
+		DueAt:       updates.DueAt,
 	}
 
 	req, err := c.newJSONRequest(ctx, http.MethodPatch, c.projectPath("/specs/"+id), body)
@@ -751,7 +1603,7 @@ func (c *Client) UpdateSpec(ctx context.Context, id string, updates SpecUpdates)
 	return &spec, nil
 }
 
-// DeleteSpec deletes a spec.
+// DeleteSpec moves a spec to the trash.
 func (c *Client) DeleteSpec(ctx context.Context, id string) error {
 	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/specs/"+id), nil)
 	if err != nil {
@@ -908,6 +1760,164 @@ func (c *Client) ListSpecDependencies(ctx context.Context, specID string) ([]Spe
 	return deps, nil
 }
 
+// =============================================================================
+// Schedules
+// =============================================================================
+
+// CreateSchedule creates a schedule that creates a task each time its cron
+// expression is due.
+func (c *Client) CreateSchedule(ctx context.Context, input ScheduleInput) (*domain.Schedule, error) {
+	body := createScheduleRequest{
+		Cron:     input.Cron,
+		CatchUp:  input.CatchUp,
+		Title:    input.Title,
+		Priority: input.Priority,
+	}
+	if input.Description != "" {
+		body.Description = &input.Description
+	}
+	if input.Estimate > 0 {
+		body.Estimate = &input.Estimate
+	}
+	if input.SpecID != "" {
+		body.SpecID = &input.SpecID
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/schedules"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("create schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var schedule domain.Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule response: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// ListSchedules lists the schedules of the project, soonest first.
+func (c *Client) ListSchedules(ctx context.Context) ([]*domain.Schedule, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/schedules"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list schedules failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var schedules []*domain.Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedules); err != nil {
+		return nil, fmt.Errorf("failed to decode schedules response: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// DeleteSchedule removes a schedule. The tasks it created are kept.
+func (c *Client) DeleteSchedule(ctx context.Context, id string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/schedules/"+id), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return ErrServerNotRunning
+		}
+		return fmt.Errorf("delete schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RunSchedule creates the task of a schedule right away, without moving its
+// next run.
+func (c *Client) RunSchedule(ctx context.Context, id string) (*domain.Task, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/schedules/"+id+"/run"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("run schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var task domain.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// SignalEvent signals an event, returning the tasks deferred until it to the
+// ready queue. It returns those tasks.
+func (c *Client) SignalEvent(ctx context.Context, name string) ([]*domain.Task, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/events/"+name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("signal event failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var tasks []*domain.Task
+	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode tasks response: %w", err)
+	}
+
+	return tasks, nil
+}
+
 // =============================================================================
 // Helper Methods
 // =============================================================================
@@ -956,16 +1966,51 @@ var _ interface {
 	GetTask(ctx context.Context, id string) (*domain.Task, error)
 	ListTasks(ctx context.Context, status string, page, perPage int) (*TaskListResponse, error)
 	ListReadyTasks(ctx context.Context, page, perPage int) (*TaskListResponse, error)
+	ListOverdueTasks(ctx context.Context, page, perPage int) (*TaskListResponse, error)
+	ListQuarantinedTasks(ctx context.Context, page, perPage int) (*TaskListResponse, error)
+	ListOverdueSpecs(ctx context.Context, page, perPage int) (*SpecListResponse, error)
 	UpdateTask(ctx context.Context, id string, updates TaskUpdates) (*domain.Task, error)
 	DeleteTask(ctx context.Context, id string) error
+	ListChildren(ctx context.Context, taskID string) ([]domain.Task, error)
+	GetTaskTree(ctx context.Context, taskID string) (*domain.TaskTree, error)
 	ClaimTask(ctx context.Context, id string) (*domain.Task, error)
 	CompleteTask(ctx context.Context, id string) (*domain.Task, error)
+	ApproveTask(ctx context.Context, id string) (*domain.Task, error)
+	RejectTask(ctx context.Context, id, reason string) (*domain.Task, error)
 	ReleaseTask(ctx context.Context, id string, force bool) (*domain.Task, error)
-	BlockTask(ctx context.Context, id string) (*domain.Task, error)
+	HandoffTask(ctx context.Context, id, agent string, force bool) (*domain.Task, error)
+	BlockTask(ctx context.Context, id string, opts BlockOptions) (*domain.Task, error)
 	UnblockTask(ctx context.Context, id string) (*domain.Task, error)
+	CancelTask(ctx context.Context, id, reason string) (*domain.Task, error)
+	ReopenTask(ctx context.Context, id string) (*domain.Task, error)
+	TransitionTask(ctx context.Context, id, status string) (*domain.Task, error)
 	AddDependency(ctx context.Context, childID, parentID string) error
 	RemoveDependency(ctx context.Context, childID, parentID string) error
-	ListDependencies(ctx context.Context, taskID string) ([]domain.Dependency, error)
+	ListDependencies(ctx context.Context, taskID string, transitive bool) ([]domain.Dependency, error)
+	ListDependents(ctx context.Context, taskID string, transitive bool) ([]domain.Dependency, error)
+	GetWorkflow(ctx context.Context) (*domain.Workflow, error)
+	SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error)
+	GetSettings(ctx context.Context) (*domain.ProjectSettings, error)
+	UpdateSettings(ctx context.Context, updates map[string]interface{}) (*domain.ProjectSettings, error)
+	GetGraph(ctx context.Context, filter GraphFilter) (*domain.Graph, error)
+	GetAnalysis(ctx context.Context, filter AnalysisFilter) (*domain.ScheduleAnalysis, error)
+	GetSpecProgress(ctx context.Context, id string) (*domain.SpecProgress, error)
+	GetTaskTimeline(ctx context.Context, id string) (*domain.TaskTimeline, error)
+	GetTimeReport(ctx context.Context) (*domain.TimeReport, error)
+	ListMyTasks(ctx context.Context, status string, page, perPage int) (*TaskListResponse, error)
+	ListAgents(ctx context.Context, filter AgentFilter) ([]*domain.Agent, error)
+	RegisterAgent(ctx context.Context, input AgentRegistration) (*domain.Agent, error)
+	InstantiateSpec(ctx context.Context, template *domain.SpecTemplate, vars map[string]string) (*SpecInstance, error)
+	CreateSchedule(ctx context.Context, input ScheduleInput) (*domain.Schedule, error)
+	ListSchedules(ctx context.Context) ([]*domain.Schedule, error)
+	DeleteSchedule(ctx context.Context, id string) error
+	RunSchedule(ctx context.Context, id string) (*domain.Task, error)
+	SignalEvent(ctx context.Context, name string) ([]*domain.Task, error)
+	ListTrash(ctx context.Context) (*Trash, error)
+	RestoreTask(ctx context.Context, id string) (*domain.Task, error)
+	RestoreSpec(ctx context.Context, id string) (*Spec, error)
+	PurgeTrash(ctx context.Context, id string) error
+	EmptyTrash(ctx context.Context) (int, error)
 	GetTaskHistory(ctx context.Context, taskID string) ([]domain.AuditEntry, error)
 } = (*Client)(nil)
 
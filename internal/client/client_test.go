@@ -600,6 +600,32 @@ func TestUpdateTask_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateTask_Assignee(t *testing.T) {
+	var receivedReq updateTaskRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&receivedReq)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&domain.Task{ID: "task-123", Status: domain.StatusOpen, Assignee: receivedReq.Assignee})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	assignee := "@go"
+	task, err := c.UpdateTask(context.Background(), "task-123", TaskUpdates{Assignee: &assignee})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receivedReq.Assignee == nil || *receivedReq.Assignee != "@go" {
+		t.Errorf("expected request assignee '@go', got %v", receivedReq.Assignee)
+	}
+	if task.Assignee == nil || *task.Assignee != "@go" {
+		t.Errorf("expected task assignee '@go', got %v", task.Assignee)
+	}
+}
+
 func TestDeleteTask_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -800,6 +826,46 @@ func TestReleaseTask_Success(t *testing.T) {
 	}
 }
 
+func TestHandoffTask(t *testing.T) {
+	var receivedQuery url.Values
+	var receivedBody handoffTaskRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/handoff" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/handoff, got %s", r.URL.Path)
+		}
+		receivedQuery = r.URL.Query()
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+
+		claimedBy := receivedBody.Agent
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&domain.Task{
+			ID:        "task-123",
+			Title:     "Task",
+			Status:    domain.StatusInProgress,
+			ClaimedBy: &claimedBy,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+	ctx := context.Background()
+
+	task, err := c.HandoffTask(ctx, "task-123", "agent-2", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receivedBody.Agent != "agent-2" || receivedQuery.Get("force") != "true" {
+		t.Errorf("expected agent-2 with force=true, got %+v and %q", receivedBody, receivedQuery.Get("force"))
+	}
+	if task.ClaimedBy == nil || *task.ClaimedBy != "agent-2" {
+		t.Errorf("expected the task claimed by agent-2, got %+v", task.ClaimedBy)
+	}
+}
+
 func TestReleaseTask_WithForce(t *testing.T) {
 	var receivedQuery url.Values
 
@@ -853,7 +919,7 @@ func TestBlockTask_Success(t *testing.T) {
 	c := newTestClient(server, "test-project", "agent")
 	ctx := context.Background()
 
-	task, err := c.BlockTask(ctx, "task-123")
+	task, err := c.BlockTask(ctx, "task-123", BlockOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1010,7 +1076,7 @@ func TestListDependencies_Success(t *testing.T) {
 	c := newTestClient(server, "test-project", "agent")
 	ctx := context.Background()
 
-	result, err := c.ListDependencies(ctx, "task-123")
+	result, err := c.ListDependencies(ctx, "task-123", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1058,6 +1124,304 @@ func TestGetTaskHistory_Success(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Link Tests
+// =============================================================================
+
+func TestAddLink_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/links" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/links, got %s", r.URL.Path)
+		}
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["type"] != "pr" {
+			t.Errorf("expected type 'pr', got %q", body["type"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(domain.TaskLink{
+			ID: 1, TaskID: "task-123", Type: domain.LinkTypePR, Value: body["value"], CreatedBy: "agent",
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	link, err := c.AddLink(context.Background(), "task-123", domain.LinkTypePR, "https://example.com/pr/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.Value != "https://example.com/pr/1" {
+		t.Errorf("expected value to round-trip, got %s", link.Value)
+	}
+}
+
+func TestListLinks_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]domain.TaskLink{
+			{ID: 1, TaskID: "task-123", Type: domain.LinkTypeCommit, Value: "abc"},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	links, err := c.ListLinks(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 1 {
+		t.Errorf("expected 1 link, got %d", len(links))
+	}
+}
+
+// =============================================================================
+// Comment Tests
+// =============================================================================
+
+func TestAddComment_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/comments" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/comments, got %s", r.URL.Path)
+		}
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(domain.Comment{
+			ID: 1, TaskID: "task-123", Body: body["body"], Author: r.Header.Get("X-Airyra-Agent"),
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent-1")
+
+	comment, err := c.AddComment(context.Background(), "task-123", "note")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment.Body != "note" {
+		t.Errorf("expected body 'note', got %s", comment.Body)
+	}
+	if comment.Author != "agent-1" {
+		t.Errorf("expected author 'agent-1', got %s", comment.Author)
+	}
+}
+
+func TestListComments_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]domain.Comment{
+			{ID: 1, TaskID: "task-123", Body: "first", Author: "agent-1"},
+			{ID: 2, TaskID: "task-123", Body: "second", Author: "agent-2"},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	comments, err := c.ListComments(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Errorf("expected 2 comments, got %d", len(comments))
+	}
+}
+
+// =============================================================================
+// Workflow Tests
+// =============================================================================
+
+func TestTransitionTask_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/transition" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/transition, got %s", r.URL.Path)
+		}
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(domain.Task{ID: "task-123", Status: domain.TaskStatus(body["status"])})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	task, err := c.TransitionTask(context.Background(), "task-123", "review")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != "review" {
+		t.Errorf("expected status review, got %s", task.Status)
+	}
+}
+
+func TestRejectTask_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/reject" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/reject, got %s", r.URL.Path)
+		}
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["reason"] != "missing tests" {
+			t.Errorf("expected reason 'missing tests', got %q", body["reason"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(domain.Task{ID: "task-123", Status: domain.StatusOpen})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	task, err := c.RejectTask(context.Background(), "task-123", "missing tests")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != domain.StatusOpen {
+		t.Errorf("expected status open, got %s", task.Status)
+	}
+}
+
+func TestCancelTask_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/cancel" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/cancel, got %s", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["reason"]; ok {
+			t.Errorf("expected empty reason to be omitted, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(domain.Task{ID: "task-123", Status: domain.StatusCancelled})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	task, err := c.CancelTask(context.Background(), "task-123", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != domain.StatusCancelled {
+		t.Errorf("expected status cancelled, got %s", task.Status)
+	}
+}
+
+func TestListTrash_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/projects/test-project/trash" {
+			t.Errorf("expected path /v1/projects/test-project/trash, got %s", r.URL.Path)
+		}
+
+		deletedAt := "2024-01-02T00:00:00Z"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"tasks": []domain.Task{{ID: "task-123", Title: "Deleted task"}},
+			"specs": []Spec{{ID: "sp-1234", Title: "Deleted spec", DeletedAt: &deletedAt}},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	trash, err := c.ListTrash(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trash.Tasks) != 1 || trash.Tasks[0].ID != "task-123" {
+		t.Errorf("expected deleted task task-123, got %+v", trash.Tasks)
+	}
+	if len(trash.Specs) != 1 || trash.Specs[0].DeletedAt == nil {
+		t.Errorf("expected deleted spec with deleted_at, got %+v", trash.Specs)
+	}
+}
+
+func TestEmptyTrash_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/projects/test-project/trash" {
+			t.Errorf("expected path /v1/projects/test-project/trash, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"purged": 3})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	purged, err := c.EmptyTrash(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 3 {
+		t.Errorf("expected 3 purged items, got %d", purged)
+	}
+}
+
+func TestSetWorkflow_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/projects/test-project/workflow" {
+			t.Errorf("expected path /v1/projects/test-project/workflow, got %s", r.URL.Path)
+		}
+
+		var workflow domain.Workflow
+		json.NewDecoder(r.Body).Decode(&workflow)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(workflow)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	workflow, err := c.SetWorkflow(context.Background(), domain.DefaultWorkflow())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(workflow.States) != 5 {
+		t.Errorf("expected 5 states, got %d", len(workflow.States))
+	}
+}
+
 // =============================================================================
 // System Tests
 // =============================================================================
@@ -1194,6 +1558,39 @@ func TestParseError_NotOwner(t *testing.T) {
 	}
 }
 
+func TestParseError_SelfReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/approve" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/approve, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "SELF_REVIEW",
+				"message": "Task must be reviewed by a different agent",
+				"context": map[string]interface{}{
+					"claimed_by": "agent",
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	_, err := c.ApproveTask(context.Background(), "task-123")
+
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) {
+		t.Fatalf("expected DomainError, got %T", err)
+	}
+	if domainErr.Code != domain.ErrCodeSelfReview {
+		t.Errorf("expected code SELF_REVIEW, got %s", domainErr.Code)
+	}
+}
+
 func TestParseError_CycleDetected(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -1253,3 +1650,316 @@ func newTestClient(server *httptest.Server, project, agentID string) *Client {
 	c.baseURL = server.URL
 	return c
 }
+
+func TestGetTaskTree_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/tree" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/tree, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(domain.TaskTree{
+			Task: &domain.Task{ID: "task-123", Title: "Parent"},
+			Children: []*domain.TaskTree{
+				{Task: &domain.Task{ID: "task-456", Title: "Child"}, Children: []*domain.TaskTree{}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	tree, err := c.GetTaskTree(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.ID != "task-123" || len(tree.Children) != 1 || tree.Children[0].ID != "task-456" {
+		t.Errorf("unexpected tree: %+v", tree)
+	}
+}
+
+func TestCompleteTask_ChildrenNotDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":{"code":"CHILDREN_NOT_DONE","message":"Task task-123 has 1 unfinished subtask(s)","context":{"id":"task-123","children":["task-456"]}}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	_, err := c.CompleteTask(context.Background(), "task-123")
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeChildrenNotDone {
+		t.Fatalf("expected CHILDREN_NOT_DONE error, got %v", err)
+	}
+	if children, _ := domainErr.Context["children"].([]string); len(children) != 1 || children[0] != "task-456" {
+		t.Errorf("expected unfinished child task-456, got %v", domainErr.Context["children"])
+	}
+}
+
+func TestInstantiateSpec_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/specs/instantiate" {
+			t.Errorf("expected POST /v1/projects/test-project/specs/instantiate, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body instantiateSpecRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Template == nil || body.Template.Title != "Add {{name}} endpoint" || body.Vars["name"] != "users" {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(SpecInstance{
+			Spec:  &Spec{ID: "sp-1", Title: "Add users endpoint", TaskCount: 1},
+			Tasks: []*domain.Task{{ID: "ar-1", Title: "Design users"}},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	template := &domain.SpecTemplate{
+		Title: "Add {{name}} endpoint",
+		Tasks: []domain.TemplateTask{{Key: "design", Title: "Design {{name}}"}},
+	}
+	instance, err := c.InstantiateSpec(context.Background(), template, map[string]string{"name": "users"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instance.Spec.ID != "sp-1" || len(instance.Tasks) != 1 || instance.Tasks[0].ID != "ar-1" {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+}
+
+func TestCreateSchedule_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/schedules" {
+			t.Errorf("expected POST /v1/projects/test-project/schedules, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body createScheduleRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Cron != "0 9 * * mon" || body.CatchUp != "skip" || body.Description != nil || body.Estimate == nil || *body.Estimate != 30 {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(domain.Schedule{ID: "sc-1", Cron: body.Cron, CatchUp: domain.CatchUpSkip, Title: body.Title})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	schedule, err := c.CreateSchedule(context.Background(), ScheduleInput{
+		Cron:     "0 9 * * mon",
+		CatchUp:  "skip",
+		Title:    "Rotate fixtures",
+		Estimate: 30,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.ID != "sc-1" || schedule.CatchUp != domain.CatchUpSkip {
+		t.Errorf("unexpected schedule: %+v", schedule)
+	}
+}
+
+func TestListMyTasks_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks" || r.URL.Query().Get("claimed_by") != "me" ||
+			r.URL.Query().Get("status") != "in_progress" {
+			t.Errorf("expected GET /v1/projects/test-project/tasks?claimed_by=me&status=in_progress, got %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":"ar-1","title":"Mine","status":"in_progress"}],` +
+			`"pagination":{"page":1,"per_page":50,"total":1,"total_pages":1}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	tasks, err := c.ListMyTasks(context.Background(), "in_progress", 1, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks.Data) != 1 || tasks.Data[0].ID != "ar-1" {
+		t.Errorf("unexpected tasks: %+v", tasks.Data)
+	}
+}
+
+func TestListAgents_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/agents" || r.URL.Query().Get("state") != "stale" ||
+			r.URL.Query().Get("stale_after") != "30m0s" {
+			t.Errorf("expected GET /v1/projects/test-project/agents?state=stale&stale_after=30m0s, got %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]domain.Agent{{ID: "worker-1", State: domain.AgentStale}})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	agents, err := c.ListAgents(context.Background(), AgentFilter{State: "stale", StaleAfter: 30 * time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(agents) != 1 || agents[0].ID != "worker-1" {
+		t.Errorf("unexpected agents: %+v", agents)
+	}
+}
+
+func TestRegisterAgent_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/agents" {
+			t.Errorf("expected POST /v1/projects/test-project/agents, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body registerAgentRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Name == nil || *body.Name != "Builder" || len(body.Capabilities) != 1 || body.Metadata["host"] != "ci-3" ||
+			body.WIPLimit == nil || *body.WIPLimit != 2 {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(domain.Agent{ID: "agent", Name: body.Name, Capabilities: body.Capabilities})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	agent, err := c.RegisterAgent(context.Background(), AgentRegistration{
+		Name:         "Builder",
+		Capabilities: []string{"go"},
+		Metadata:     map[string]string{"host": "ci-3"},
+		WIPLimit:     2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if agent.ID != "agent" || agent.Name == nil || *agent.Name != "Builder" {
+		t.Errorf("unexpected agent: %+v", agent)
+	}
+}
+
+func TestRunSchedule_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/schedules/sc-missing/run" {
+			t.Errorf("expected POST /v1/projects/test-project/schedules/sc-missing/run, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"SCHEDULE_NOT_FOUND","message":"Schedule sc-missing not found"}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	_, err := c.RunSchedule(context.Background(), "sc-missing")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "sc-missing") {
+		t.Errorf("expected the error to name the schedule, got %v", err)
+	}
+}
+
+func TestSignalEvent_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/events/deploy-done" {
+			t.Errorf("expected POST /v1/projects/test-project/events/deploy-done, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]domain.Task{{ID: "ar-1", Title: "Smoke test", Status: domain.StatusOpen}})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	tasks, err := c.SignalEvent(context.Background(), "deploy-done")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "ar-1" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestListOverdueTasks_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks" || r.URL.Query().Get("overdue") != "true" {
+			t.Errorf("expected GET /v1/projects/test-project/tasks?overdue=true, got %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":       []map[string]interface{}{{"id": "ar-1", "title": "Late", "due_at": "2026-01-01T00:00:00Z"}},
+			"pagination": map[string]interface{}{"page": 1, "per_page": 50, "total": 1, "total_pages": 1},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	resp, err := c.ListOverdueTasks(context.Background(), 1, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].DueAt == nil {
+		t.Errorf("expected one overdue task with a due date, got %+v", resp.Data)
+	}
+}
+
+func TestListQuarantinedTasks_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks" || r.URL.Query().Get("quarantined") != "true" {
+			t.Errorf("expected GET /v1/projects/test-project/tasks?quarantined=true, got %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":       []map[string]interface{}{{"id": "ar-1", "title": "Poison", "status": "blocked", "attempts": 3, "quarantined_at": "2026-01-01T00:00:00Z"}},
+			"pagination": map[string]interface{}{"page": 1, "per_page": 50, "total": 1, "total_pages": 1},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	resp, err := c.ListQuarantinedTasks(context.Background(), 1, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].QuarantinedAt == nil || resp.Data[0].Attempts != 3 {
+		t.Errorf("expected one quarantined task with 3 attempts, got %+v", resp.Data)
+	}
+}
+
+func TestGetTaskTimeline_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/ar-1/timeline" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/ar-1/timeline, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"task_id":        "ar-1",
+			"periods":        []map[string]interface{}{{"status": "in_progress", "start": "2026-01-01T00:00:00Z", "seconds": 600}},
+			"worked_seconds": 600,
+			"claims":         1,
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "test-project", "agent")
+
+	timeline, err := c.GetTaskTimeline(context.Background(), "ar-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeline.WorkedSeconds != 600 || len(timeline.Periods) != 1 || timeline.Claims != 1 {
+		t.Errorf("unexpected timeline: %+v", timeline)
+	}
+}
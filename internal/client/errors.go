@@ -62,6 +62,15 @@ func mapAPIErrorToDomain(statusCode int, apiErr *APIError) error {
 		claimedBy, _ := apiErr.Context["claimed_by"].(string)
 		return domain.NewNotOwnerError(claimedBy)
 
+	case statusCode == http.StatusForbidden && apiErr.Code == string(domain.ErrCodeSelfReview):
+		claimedBy, _ := apiErr.Context["claimed_by"].(string)
+		return domain.NewSelfReviewError(claimedBy)
+
+	case statusCode == http.StatusConflict && apiErr.Code == string(domain.ErrCodeChildrenNotDone):
+		taskID, _ := apiErr.Context["id"].(string)
+		children := extractStringSlice(apiErr.Context, "children")
+		return domain.NewChildrenNotDoneError(taskID, children)
+
 	case statusCode == http.StatusBadRequest && apiErr.Code == string(domain.ErrCodeInvalidTransition):
 		from, _ := apiErr.Context["from"].(string)
 		to, _ := apiErr.Context["to"].(string)
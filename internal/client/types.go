@@ -1,6 +1,10 @@
 package client
 
-import "github.com/airyra/airyra/internal/domain"
+import (
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+)
 
 // TaskListResponse represents a paginated list of tasks.
 type TaskListResponse struct {
@@ -21,6 +25,26 @@ type TaskUpdates struct {
 	Title       *string
 	Description *string
 	Priority    *int
+	// Estimate is the expected effort in minutes; zero clears it.
+	Estimate *int
+	// DueAt is the due date as an RFC 3339 time; an empty string clears it.
+	DueAt *string
+	// DeferUntil is the RFC 3339 time the task is deferred until; an empty
+	// string clears it.
+	DeferUntil *string
+	// DeferEvent is the event the task is deferred until; an empty string
+	// clears it.
+	DeferEvent *string
+	// Assignee is the agent or @group that may claim the task; an empty
+	// string clears it.
+	Assignee *string
+}
+
+// BlockOptions contains optional details recorded when blocking a task.
+type BlockOptions struct {
+	Reason      string
+	BlockedBy   string
+	AutoUnblock bool
 }
 
 // paginatedTaskResponse is the raw JSON structure for paginated task responses.
@@ -46,6 +70,33 @@ type createTaskRequest struct {
 	SpecID      *string `json:"spec_id,omitempty"`
 }
 
+// blockTaskRequest is the JSON request body for blocking a task.
+type blockTaskRequest struct {
+	Reason      *string `json:"reason,omitempty"`
+	BlockedBy   *string `json:"blocked_by,omitempty"`
+	AutoUnblock bool    `json:"auto_unblock,omitempty"`
+}
+
+// cancelTaskRequest is the JSON request body for cancelling a task.
+type cancelTaskRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// rejectTaskRequest is the JSON request body for rejecting a task under review.
+type rejectTaskRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handoffTaskRequest is the JSON request body for handing a task to another agent.
+type handoffTaskRequest struct {
+	Agent string `json:"agent"`
+}
+
+// transitionTaskRequest is the JSON request body for moving a task to a workflow state.
+type transitionTaskRequest struct {
+	Status string `json:"status"`
+}
+
 // Spec represents an epic-like entity for grouping related tasks.
 type Spec struct {
 	ID          string  `json:"id"`
@@ -54,8 +105,73 @@ type Spec struct {
 	Status      string  `json:"status"`
 	TaskCount   int     `json:"task_count"`
 	DoneCount   int     `json:"done_count"`
+	DueAt       *string `json:"due_at,omitempty"`
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   string  `json:"updated_at"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
+}
+
+// SpecInstance is a spec created from a template, with its tasks in template
+// order.
+type SpecInstance struct {
+	Spec  *Spec          `json:"spec"`
+	Tasks []*domain.Task `json:"tasks"`
+}
+
+// GraphFilter narrows the project graph. Empty fields are not applied.
+type GraphFilter struct {
+	// SpecID limits the graph to a spec and its tasks.
+	SpecID string
+	// TaskID limits the graph to a task and its ancestors, descendants or both,
+	// as selected by Direction (default both).
+	TaskID    string
+	Direction string
+}
+
+// AnalysisFilter narrows a schedule analysis. Empty fields are not applied.
+type AnalysisFilter struct {
+	// SpecID limits the analysis to the tasks of a spec.
+	SpecID string
+	// Limit caps the number of blocking tasks listed (server default 10).
+	Limit int
+}
+
+// AgentFilter narrows an agent listing. Empty fields are not applied.
+type AgentFilter struct {
+	// State keeps the agents that are active, idle or stale.
+	State string
+	// StaleAfter is how long an agent may go unseen before it is stale
+	// (server default 15 minutes).
+	StaleAfter time.Duration
+}
+
+// AgentRegistration contains the details an agent registers with.
+type AgentRegistration struct {
+	Name         string
+	Capabilities []string
+	Metadata     map[string]string
+	// WIPLimit caps the in-progress tasks of the agent; zero means only the
+	// project's limits apply.
+	WIPLimit int
+}
+
+// registerAgentRequest is the JSON request body for registering an agent.
+type registerAgentRequest struct {
+	Name         *string           `json:"name,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	WIPLimit     *int              `json:"wip_limit,omitempty"`
+}
+
+// Trash lists the deleted tasks and specs of a project.
+type Trash struct {
+	Tasks []*domain.Task `json:"tasks"`
+	Specs []*Spec        `json:"specs"`
+}
+
+// emptyTrashResponse is the JSON response returned when emptying the trash.
+type emptyTrashResponse struct {
+	Purged int `json:"purged"`
 }
 
 // SpecListResponse represents a paginated list of specs.
@@ -68,6 +184,8 @@ type SpecListResponse struct {
 type SpecUpdates struct {
 	Title       *string
 	Description *string
+	// DueAt is the due date as an RFC 3339 time; an empty string clears it.
+	DueAt *string
 }
 
 // SpecDependency represents a dependency relationship between specs.
@@ -88,10 +206,18 @@ type createSpecRequest struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// instantiateSpecRequest is the JSON request body for creating a spec from a
+// template.
+type instantiateSpecRequest struct {
+	Template *domain.SpecTemplate `json:"template"`
+	Vars     map[string]string    `json:"vars,omitempty"`
+}
+
 // updateSpecRequest is the JSON request body for updating a spec.
 type updateSpecRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
 }
 
 // addSpecDependencyRequest is the JSON request body for adding a spec dependency.
@@ -99,11 +225,40 @@ type addSpecDependencyRequest struct {
 	ParentID string `json:"parent_id"`
 }
 
+// ScheduleInput contains the fields of a new schedule. Empty fields take the
+// server defaults.
+type ScheduleInput struct {
+	Cron        string
+	CatchUp     string
+	Title       string
+	Description string
+	Priority    *int
+	// Estimate is the expected effort in minutes; zero means no estimate.
+	Estimate int
+	SpecID   string
+}
+
+// createScheduleRequest is the JSON request body for creating a schedule.
+type createScheduleRequest struct {
+	Cron        string  `json:"cron"`
+	CatchUp     string  `json:"catch_up,omitempty"`
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	Priority    *int    `json:"priority,omitempty"`
+	Estimate    *int    `json:"estimate,omitempty"`
+	SpecID      *string `json:"spec_id,omitempty"`
+}
+
 // updateTaskRequest is the JSON request body for updating a task.
 type updateTaskRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Priority    *int    `json:"priority,omitempty"`
+	Estimate    *int    `json:"estimate,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
+	DeferUntil  *string `json:"defer_until,omitempty"`
+	DeferEvent  *string `json:"defer_event,omitempty"`
+	Assignee    *string `json:"assignee,omitempty"`
 }
 
 // addDependencyRequest is the JSON request body for adding a dependency.
@@ -111,7 +266,18 @@ type addDependencyRequest struct {
 	ParentID string `json:"parent_id"`
 }
 
+// addLinkRequest is the JSON request body for linking a task.
+type addLinkRequest struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
 // healthResponse is the JSON response for the health endpoint.
 type healthResponse struct {
 	Status string `json:"status"`
 }
+
+// addCommentRequest is the JSON request body for commenting on a task.
+type addCommentRequest struct {
+	Body string `json:"body"`
+}
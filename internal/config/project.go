@@ -56,18 +56,27 @@ func DiscoverProjectConfig() (*ProjectConfig, error) {
 
 // discoverProjectConfigFrom searches for airyra.toml starting from the given directory
 func discoverProjectConfigFrom(startDir string) (*ProjectConfig, error) {
+	dir, err := findProjectDir(startDir)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProjectConfig(filepath.Join(dir, ConfigFileName))
+}
+
+// findProjectDir returns the nearest directory containing airyra.toml,
+// starting from the given directory and traversing up the tree.
+func findProjectDir(startDir string) (string, error) {
 	dir := startDir
 
 	for {
-		configPath := filepath.Join(dir, ConfigFileName)
-		if _, err := os.Stat(configPath); err == nil {
-			return ParseProjectConfig(configPath)
+		if _, err := os.Stat(filepath.Join(dir, ConfigFileName)); err == nil {
+			return dir, nil
 		}
 
 		parent := filepath.Dir(dir)
 		if parent == dir {
 			// Reached filesystem root
-			return nil, errors.New("No airyra.toml found. Run 'ar init <name>' to create one.")
+			return "", errors.New("No airyra.toml found. Run 'ar init <name>' to create one.")
 		}
 		dir = parent
 	}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const (
+	// TemplatesDir is the directory holding spec templates, both inside the
+	// global config directory and in the project's .airyra directory.
+	TemplatesDir = "templates"
+
+	// TemplateExt is the file extension of spec templates.
+	TemplateExt = ".json"
+)
+
+// templateName matches valid template names, which must not leave the
+// templates directory.
+var templateName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// FindTemplate returns the path of the spec template with the given name.
+// Templates are looked up in .airyra/templates next to the project's
+// airyra.toml first, then in ~/.airyra/templates.
+func FindTemplate(name string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return findTemplateFrom(cwd, homeDir, name)
+}
+
+// findTemplateFrom looks up a template from the given working and home
+// directories. A missing airyra.toml only skips the project templates.
+func findTemplateFrom(startDir, homeDir, name string) (string, error) {
+	if !templateName.MatchString(name) {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+
+	var dirs []string
+	if projectDir, err := findProjectDir(startDir); err == nil {
+		dirs = append(dirs, filepath.Join(projectDir, GlobalConfigDir, TemplatesDir))
+	}
+	dirs = append(dirs, filepath.Join(homeDir, GlobalConfigDir, TemplatesDir))
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name+TemplateExt)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("template %q not found in %s", name, joinDirs(dirs))
+}
+
+// joinDirs lists directories for an error message.
+func joinDirs(dirs []string) string {
+	if len(dirs) == 1 {
+		return dirs[0]
+	}
+	return dirs[0] + " or " + dirs[1]
+}
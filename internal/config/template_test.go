@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, dir, name string) string {
+	t.Helper()
+	templatesDir := filepath.Join(dir, GlobalConfigDir, TemplatesDir)
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatalf("failed to create templates directory: %v", err)
+	}
+	path := filepath.Join(templatesDir, name+TemplateExt)
+	if err := os.WriteFile(path, []byte(`{"title": "Spec"}`), 0644); err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	return path
+}
+
+func TestFindTemplate_ProjectBeforeGlobal(t *testing.T) {
+	homeDir := t.TempDir()
+	projectDir := t.TempDir()
+	childDir := filepath.Join(projectDir, "child")
+	if err := os.Mkdir(childDir, 0755); err != nil {
+		t.Fatalf("failed to create child directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ConfigFileName), []byte(`project = "app"`), 0644); err != nil {
+		t.Fatalf("failed to create test config: %v", err)
+	}
+
+	global := writeTemplate(t, homeDir, "add-endpoint")
+	writeTemplate(t, homeDir, "release")
+	project := writeTemplate(t, projectDir, "add-endpoint")
+
+	path, err := findTemplateFrom(childDir, homeDir, "add-endpoint")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != project {
+		t.Errorf("expected the project template %s, got %s (global %s)", project, path, global)
+	}
+
+	path, err = findTemplateFrom(childDir, homeDir, "release")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join(homeDir, GlobalConfigDir, TemplatesDir, "release.json") {
+		t.Errorf("expected the global template, got %s", path)
+	}
+}
+
+func TestFindTemplate_OutsideProject(t *testing.T) {
+	homeDir := t.TempDir()
+	global := writeTemplate(t, homeDir, "release")
+
+	path, err := findTemplateFrom(t.TempDir(), homeDir, "release")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != global {
+		t.Errorf("expected %s, got %s", global, path)
+	}
+}
+
+func TestFindTemplate_NotFound(t *testing.T) {
+	_, err := findTemplateFrom(t.TempDir(), t.TempDir(), "missing")
+	if err == nil || !strings.Contains(err.Error(), `template "missing" not found`) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestFindTemplate_InvalidName(t *testing.T) {
+	homeDir := t.TempDir()
+	writeTemplate(t, homeDir, "release")
+
+	for _, name := range []string{"", "../release", "templates/release", ".hidden"} {
+		if _, err := findTemplateFrom(t.TempDir(), homeDir, name); err == nil {
+			t.Errorf("expected an error for template name %q", name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// AgentState tells whether an agent is working, waiting for work or gone.
+type AgentState string
+
+const (
+	// AgentActive is an agent seen recently that holds claimed tasks.
+	AgentActive AgentState = "active"
+	// AgentIdle is an agent seen recently that holds no tasks.
+	AgentIdle AgentState = "idle"
+	// AgentStale is an agent not seen for longer than the stale window. A
+	// stale agent that holds tasks is most likely a dead worker.
+	AgentStale AgentState = "stale"
+)
+
+// IsValid checks if the agent state is one of the defined states.
+func (s AgentState) IsValid() bool {
+	return s == AgentActive || s == AgentIdle || s == AgentStale
+}
+
+// DefaultAgentStaleAfter is how long an agent may go unseen before it is
+// considered stale.
+const DefaultAgentStaleAfter = 15 * time.Minute
+
+// Agent is a worker known to a project. Agents are identified by the
+// X-Airyra-Agent header; every request records the agent as seen, and
+// registering adds a name, capabilities and metadata.
+type Agent struct {
+	ID           string            `json:"id"`
+	Name         *string           `json:"name,omitempty"`
+	Capabilities []string          `json:"capabilities"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	// WIPLimit caps the in-progress tasks the agent may hold, below the
+	// project's agent_wip_limit setting. It is omitted when not set.
+	WIPLimit *int `json:"wip_limit,omitempty"`
+	// RegisteredAt is omitted for agents that were seen but never registered.
+	RegisteredAt *time.Time `json:"registered_at,omitempty"`
+	FirstSeenAt  time.Time  `json:"first_seen_at"`
+	LastSeenAt   time.Time  `json:"last_seen_at"`
+	State        AgentState `json:"state"`
+	// Claims lists the in-progress tasks the agent holds, oldest claim first.
+	Claims []AgentClaim `json:"claims"`
+}
+
+// AgentClaim is a task held by an agent.
+type AgentClaim struct {
+	TaskID    string     `json:"task_id"`
+	Title     string     `json:"title"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+}
+
+// StateAt returns the state of the agent at now: stale once it has not been
+// seen for longer than staleAfter, otherwise active while it holds claims
+// and idle when it holds none.
+func (a *Agent) StateAt(now time.Time, staleAfter time.Duration) AgentState {
+	if now.Sub(a.LastSeenAt) > staleAfter {
+		return AgentStale
+	}
+	if len(a.Claims) > 0 {
+		return AgentActive
+	}
+	return AgentIdle
+}
+
+// WIPLimits caps the in-progress tasks a claim may add to. Zero means no
+// limit.
+type WIPLimits struct {
+	// Agent caps the tasks held by the claiming agent.
+	Agent int
+	// Project caps the tasks in progress across the project.
+	Project int
+}
+
+// WIPLimitsFor returns the limits that apply to an agent: the tighter of the
+// project's per-agent limit and the agent's own, and the project's limit.
+// agentLimit is nil for agents without a limit of their own.
+func WIPLimitsFor(settings *ProjectSettings, agentLimit *int) WIPLimits {
+	limits := WIPLimits{Agent: settings.AgentWIPLimit, Project: settings.ProjectWIPLimit}
+	if agentLimit != nil && *agentLimit > 0 && (limits.Agent == 0 || *agentLimit < limits.Agent) {
+		limits.Agent = *agentLimit
+	}
+	return limits
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgent_StateAt(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	claims := []AgentClaim{{TaskID: "ar-1", Title: "Fix login"}}
+
+	tests := []struct {
+		name     string
+		lastSeen time.Time
+		claims   []AgentClaim
+		expected AgentState
+	}{
+		{"seen recently with claims", now.Add(-time.Minute), claims, AgentActive},
+		{"seen recently without claims", now.Add(-time.Minute), nil, AgentIdle},
+		{"seen at the edge of the window", now.Add(-DefaultAgentStaleAfter), nil, AgentIdle},
+		{"gone with claims", now.Add(-time.Hour), claims, AgentStale},
+		{"gone without claims", now.Add(-time.Hour), nil, AgentStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &Agent{LastSeenAt: tt.lastSeen, Claims: tt.claims}
+			if got := agent.StateAt(now, DefaultAgentStaleAfter); got != tt.expected {
+				t.Errorf("StateAt() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAgentState_IsValid(t *testing.T) {
+	for _, state := range []AgentState{AgentActive, AgentIdle, AgentStale} {
+		if !state.IsValid() {
+			t.Errorf("expected %q to be valid", state)
+		}
+	}
+	if AgentState("busy").IsValid() {
+		t.Error("expected busy to be invalid")
+	}
+}
+
+func TestWIPLimitsFor(t *testing.T) {
+	two, five := 2, 5
+
+	tests := []struct {
+		name       string
+		settings   ProjectSettings
+		agentLimit *int
+		expected   WIPLimits
+	}{
+		{"no limits", ProjectSettings{}, nil, WIPLimits{}},
+		{"project limits only", ProjectSettings{AgentWIPLimit: 3, ProjectWIPLimit: 10}, nil, WIPLimits{Agent: 3, Project: 10}},
+		{"agent limit alone", ProjectSettings{}, &five, WIPLimits{Agent: 5}},
+		{"agent limit is tighter", ProjectSettings{AgentWIPLimit: 3}, &two, WIPLimits{Agent: 2}},
+		{"agent limit cannot raise the project's", ProjectSettings{AgentWIPLimit: 3}, &five, WIPLimits{Agent: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WIPLimitsFor(&tt.settings, tt.agentLimit); got != tt.expected {
+				t.Errorf("WIPLimitsFor() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
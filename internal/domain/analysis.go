@@ -0,0 +1,186 @@
+package domain
+
+import "sort"
+
+// AnalysisTask is an unfinished task in a schedule analysis.
+type AnalysisTask struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Estimate *int   `json:"estimate,omitempty"`
+}
+
+// FanOut reports how much unfinished work waits on a task.
+type FanOut struct {
+	AnalysisTask
+	// Direct counts the unfinished tasks that depend on the task directly.
+	Direct int `json:"direct"`
+	// Downstream counts the unfinished tasks that depend on the task directly
+	// or transitively.
+	Downstream int `json:"downstream"`
+}
+
+// ScheduleAnalysis describes what gates delivery of the unfinished tasks.
+type ScheduleAnalysis struct {
+	// Remaining counts the unfinished tasks.
+	Remaining int `json:"remaining"`
+	// Parallelism counts the unfinished tasks that wait on no unfinished
+	// dependency and can therefore be worked on at the same time.
+	Parallelism int `json:"parallelism"`
+	// CriticalPath is the longest chain of unfinished dependencies, from the
+	// task to start with to the unfinished leaf it gates.
+	CriticalPath []AnalysisTask `json:"critical_path"`
+	// PathEstimate is the effort of the critical path in minutes, counting
+	// tasks without an estimate at the average estimate. It is omitted when
+	// no task has an estimate and the path is the one with the most tasks.
+	PathEstimate int `json:"path_estimate,omitempty"`
+	// BlockingFanOut lists the tasks that unblock the most downstream work.
+	BlockingFanOut []FanOut `json:"blocking_fan_out"`
+}
+
+// AnalyzeSchedule analyzes the unfinished tasks of a graph and their
+// dependency edges. Tasks whose status is accepted by finished are left out,
+// together with their edges. At most fanOutLimit blocking tasks are listed.
+func AnalyzeSchedule(graph *Graph, finished func(status string) bool, fanOutLimit int) *ScheduleAnalysis {
+	analysis := &ScheduleAnalysis{CriticalPath: []AnalysisTask{}, BlockingFanOut: []FanOut{}}
+
+	var tasks []GraphNode
+	index := make(map[string]int)
+	for _, n := range graph.Nodes {
+		if n.Kind == GraphNodeTask && !finished(n.Status) {
+			index[n.ID] = len(tasks)
+			tasks = append(tasks, n)
+		}
+	}
+	analysis.Remaining = len(tasks)
+	if len(tasks) == 0 {
+		return analysis
+	}
+
+	dependents := make([][]int, len(tasks))
+	waitingOn := make([]int, len(tasks))
+	for _, e := range graph.Edges {
+		if e.Kind != GraphEdgeDependency {
+			continue
+		}
+		from, fromOK := index[e.From]
+		to, toOK := index[e.To]
+		if !fromOK || !toOK {
+			continue
+		}
+		dependents[from] = append(dependents[from], to)
+		waitingOn[to]++
+	}
+
+	// Topological order; tasks on a dependency cycle are never reached
+	var order []int
+	remaining := append([]int(nil), waitingOn...)
+	for i, w := range waitingOn {
+		if w == 0 {
+			order = append(order, i)
+			analysis.Parallelism++
+		}
+	}
+	for k := 0; k < len(order); k++ {
+		for _, d := range dependents[order[k]] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				order = append(order, d)
+			}
+		}
+	}
+
+	// Weigh tasks by estimate when any task has one, by count otherwise
+	estimated, total := 0, 0
+	for _, t := range tasks {
+		if t.Estimate != nil {
+			estimated++
+			total += *t.Estimate
+		}
+	}
+	weight := func(t GraphNode) int {
+		switch {
+		case estimated == 0:
+			return 1
+		case t.Estimate != nil:
+			return *t.Estimate
+		default:
+			return (total + estimated/2) / estimated
+		}
+	}
+
+	// Longest path ending at each task
+	length := make([]int, len(tasks))
+	previous := make([]int, len(tasks))
+	for i := range previous {
+		previous[i] = -1
+	}
+	for _, i := range order {
+		length[i] += weight(tasks[i])
+		for _, d := range dependents[i] {
+			if length[i] > length[d] {
+				length[d] = length[i]
+				previous[d] = i
+			}
+		}
+	}
+	end := -1
+	for _, i := range order {
+		if end == -1 || length[i] > length[end] {
+			end = i
+		}
+	}
+	if end != -1 {
+		for i := end; i != -1; i = previous[i] {
+			analysis.CriticalPath = append(analysis.CriticalPath, analysisTask(tasks[i]))
+		}
+		for l, r := 0, len(analysis.CriticalPath)-1; l < r; l, r = l+1, r-1 {
+			analysis.CriticalPath[l], analysis.CriticalPath[r] = analysis.CriticalPath[r], analysis.CriticalPath[l]
+		}
+		if estimated > 0 {
+			analysis.PathEstimate = length[end]
+		}
+	}
+
+	// Downstream sets, built from the leaves up
+	downstream := make([]map[int]bool, len(tasks))
+	for k := len(order) - 1; k >= 0; k-- {
+		i := order[k]
+		downstream[i] = make(map[int]bool)
+		for _, d := range dependents[i] {
+			downstream[i][d] = true
+			for dd := range downstream[d] {
+				downstream[i][dd] = true
+			}
+		}
+	}
+
+	var fanOut []FanOut
+	for _, i := range order {
+		if len(downstream[i]) == 0 {
+			continue
+		}
+		fanOut = append(fanOut, FanOut{
+			AnalysisTask: analysisTask(tasks[i]),
+			Direct:       len(dependents[i]),
+			Downstream:   len(downstream[i]),
+		})
+	}
+	sort.SliceStable(fanOut, func(a, b int) bool {
+		if fanOut[a].Downstream != fanOut[b].Downstream {
+			return fanOut[a].Downstream > fanOut[b].Downstream
+		}
+		return fanOut[a].Direct > fanOut[b].Direct
+	})
+	if fanOutLimit > 0 && len(fanOut) > fanOutLimit {
+		fanOut = fanOut[:fanOutLimit]
+	}
+	analysis.BlockingFanOut = append(analysis.BlockingFanOut, fanOut...)
+
+	return analysis
+}
+
+// analysisTask converts a task node for the analysis.
+func analysisTask(n GraphNode) AnalysisTask {
+	return AnalysisTask{ID: n.ID, Title: n.Title, Status: n.Status, Estimate: n.Estimate}
+}
@@ -0,0 +1,101 @@
+package domain
+
+import "testing"
+
+func analysisIDs(tasks []AnalysisTask) []string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func isDoneStatus(status string) bool {
+	return status == "done"
+}
+
+func TestAnalyzeSchedule(t *testing.T) {
+	// a -> b -> c -> d, a -> e, f alone; a is done
+	g := &Graph{
+		Nodes: []GraphNode{
+			{ID: "a", Kind: GraphNodeTask, Status: "done"},
+			{ID: "b", Kind: GraphNodeTask, Status: "open"},
+			{ID: "c", Kind: GraphNodeTask, Status: "open"},
+			{ID: "d", Kind: GraphNodeTask, Status: "open"},
+			{ID: "e", Kind: GraphNodeTask, Status: "open"},
+			{ID: "f", Kind: GraphNodeTask, Status: "in_progress"},
+			{ID: "sp-1", Kind: GraphNodeSpec, Status: "active"},
+		},
+		Edges: []GraphEdge{
+			{From: "a", To: "b", Kind: GraphEdgeDependency},
+			{From: "b", To: "c", Kind: GraphEdgeDependency},
+			{From: "c", To: "d", Kind: GraphEdgeDependency},
+			{From: "a", To: "e", Kind: GraphEdgeDependency},
+			{From: "b", To: "f", Kind: GraphEdgeSubtask},
+		},
+	}
+
+	analysis := AnalyzeSchedule(g, isDoneStatus, 10)
+
+	if analysis.Remaining != 5 {
+		t.Errorf("Remaining = %d, want 5", analysis.Remaining)
+	}
+	// b, e and f wait on nothing unfinished
+	if analysis.Parallelism != 3 {
+		t.Errorf("Parallelism = %d, want 3", analysis.Parallelism)
+	}
+	if got := analysisIDs(analysis.CriticalPath); len(got) != 3 || got[0] != "b" || got[1] != "c" || got[2] != "d" {
+		t.Errorf("CriticalPath = %v, want [b c d]", got)
+	}
+	if analysis.PathEstimate != 0 {
+		t.Errorf("PathEstimate = %d, want 0 without estimates", analysis.PathEstimate)
+	}
+	if len(analysis.BlockingFanOut) != 2 {
+		t.Fatalf("BlockingFanOut = %+v, want b and c", analysis.BlockingFanOut)
+	}
+	if top := analysis.BlockingFanOut[0]; top.ID != "b" || top.Direct != 1 || top.Downstream != 2 {
+		t.Errorf("top blocker = %+v, want b with 1 direct and 2 downstream", top)
+	}
+}
+
+func TestAnalyzeSchedule_Estimates(t *testing.T) {
+	hour, day := 60, 480
+	// a -> b and c -> d; the a chain has more effort, c is unestimated
+	g := &Graph{
+		Nodes: []GraphNode{
+			{ID: "a", Kind: GraphNodeTask, Status: "open", Estimate: &day},
+			{ID: "b", Kind: GraphNodeTask, Status: "open", Estimate: &hour},
+			{ID: "c", Kind: GraphNodeTask, Status: "open"},
+			{ID: "d", Kind: GraphNodeTask, Status: "open", Estimate: &hour},
+		},
+		Edges: []GraphEdge{
+			{From: "c", To: "d", Kind: GraphEdgeDependency},
+			{From: "a", To: "b", Kind: GraphEdgeDependency},
+		},
+	}
+
+	analysis := AnalyzeSchedule(g, isDoneStatus, 1)
+
+	if got := analysisIDs(analysis.CriticalPath); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("CriticalPath = %v, want [a b]", got)
+	}
+	if analysis.PathEstimate != day+hour {
+		t.Errorf("PathEstimate = %d, want %d", analysis.PathEstimate, day+hour)
+	}
+	if len(analysis.BlockingFanOut) != 1 {
+		t.Errorf("expected fan-out limited to 1, got %d", len(analysis.BlockingFanOut))
+	}
+}
+
+func TestAnalyzeSchedule_Empty(t *testing.T) {
+	done := &Graph{Nodes: []GraphNode{{ID: "a", Kind: GraphNodeTask, Status: "done"}}}
+
+	analysis := AnalyzeSchedule(done, isDoneStatus, 10)
+
+	if analysis.Remaining != 0 || analysis.Parallelism != 0 {
+		t.Errorf("expected nothing remaining, got %+v", analysis)
+	}
+	if analysis.CriticalPath == nil || analysis.BlockingFanOut == nil {
+		t.Error("expected empty, non-nil lists")
+	}
+}
@@ -11,6 +11,18 @@ const (
 	ActionDelete  AuditAction = "delete"
 	ActionClaim   AuditAction = "claim"
 	ActionRelease AuditAction = "release"
+	// ActionHandoff is recorded when an in-progress task passes from one
+	// agent to another. The entry's field is claimed_by.
+	ActionHandoff AuditAction = "handoff"
+	// ActionOverdue is recorded by the server when a task or spec passes its
+	// due date unfinished. Entries for specs carry spec_id instead of task_id.
+	ActionOverdue AuditAction = "overdue"
+	// ActionQuarantine is recorded when a task released too many times is
+	// blocked automatically. The entry's field is status.
+	ActionQuarantine AuditAction = "quarantine"
+	// ActionSignal is recorded when a signalled event returns a task that was
+	// deferred until it to the ready queue. The entry's field is defer_event.
+	ActionSignal AuditAction = "signal"
 )
 
 // ValidAuditActions contains all valid audit action values.
@@ -20,6 +32,10 @@ var ValidAuditActions = []AuditAction{
 	ActionDelete,
 	ActionClaim,
 	ActionRelease,
+	ActionHandoff,
+	ActionOverdue,
+	ActionQuarantine,
+	ActionSignal,
 }
 
 // IsValid checks if the action is a valid audit action.
@@ -35,7 +51,8 @@ func (a AuditAction) IsValid() bool {
 // AuditEntry represents a single change in the audit log.
 type AuditEntry struct {
 	ID        int64       `json:"id"`
-	TaskID    string      `json:"task_id"`
+	TaskID    string      `json:"task_id,omitempty"`
+	SpecID    *string     `json:"spec_id,omitempty"` // set instead of TaskID on entries about a spec
 	Action    AuditAction `json:"action"`
 	Field     *string     `json:"field,omitempty"`
 	OldValue  *string     `json:"old_value,omitempty"`
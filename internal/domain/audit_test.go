@@ -16,6 +16,10 @@ func TestAuditAction_IsValid(t *testing.T) {
 		{"ActionDelete is valid", ActionDelete, true},
 		{"ActionClaim is valid", ActionClaim, true},
 		{"ActionRelease is valid", ActionRelease, true},
+		{"ActionHandoff is valid", ActionHandoff, true},
+		{"ActionOverdue is valid", ActionOverdue, true},
+		{"ActionQuarantine is valid", ActionQuarantine, true},
+		{"ActionSignal is valid", ActionSignal, true},
 		{"empty string is invalid", AuditAction(""), false},
 		{"random string is invalid", AuditAction("random"), false},
 	}
@@ -30,7 +34,7 @@ func TestAuditAction_IsValid(t *testing.T) {
 }
 
 func TestValidAuditActions_ContainsAllActions(t *testing.T) {
-	expected := []AuditAction{ActionCreate, ActionUpdate, ActionDelete, ActionClaim, ActionRelease}
+	expected := []AuditAction{ActionCreate, ActionUpdate, ActionDelete, ActionClaim, ActionRelease, ActionHandoff, ActionOverdue, ActionQuarantine, ActionSignal}
 	if len(ValidAuditActions) != len(expected) {
 		t.Errorf("ValidAuditActions has %d items, want %d", len(ValidAuditActions), len(expected))
 	}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Comment is a work note left on a task by an agent.
+type Comment struct {
+	ID        int64     `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Body      string    `json:"body"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewComment creates a new comment with the given parameters.
+func NewComment(taskID, body, author string) Comment {
+	return Comment{
+		TaskID:    taskID,
+		Body:      body,
+		Author:    author,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"@every 5m",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Wednesday 2026-01-07 10:30
+	from := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 7, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 7, 10, 45, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2026, 1, 8, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 feb *", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches
+		{"0 0 20 * fri", time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 1, 7, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+			if got := cron.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Next_Location(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	cron, _ := ParseCron("0 9 * * *")
+
+	got := cron.Next(time.Date(2026, 1, 7, 6, 0, 0, 0, time.UTC).In(loc))
+	if want := time.Date(2026, 1, 7, 9, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
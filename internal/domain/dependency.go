@@ -1,10 +1,20 @@
 package domain
 
+import (
+	"regexp"
+	"strings"
+)
+
 // Dependency represents a dependency relationship between tasks.
 // The child task depends on the parent task (child is blocked until parent is done).
 type Dependency struct {
 	ChildID  string `json:"child_id"`
 	ParentID string `json:"parent_id"`
+	// Project is set when the parent task lives in another project.
+	Project string `json:"project,omitempty"`
+	// Depth is set by transitive queries: 1 for an edge of the queried task,
+	// 2 for an edge one task further away, and so on.
+	Depth int `json:"depth,omitempty"`
 }
 
 // NewDependency creates a new dependency relationship.
@@ -14,3 +24,35 @@ func NewDependency(childID, parentID string) Dependency {
 		ParentID: parentID,
 	}
 }
+
+// ParentRef returns the task reference of the parent task, qualified with its
+// project when it lives in another project.
+func (d Dependency) ParentRef() string {
+	return TaskRef(d.Project, d.ParentID)
+}
+
+// Valid project name pattern: alphanumeric, hyphens, underscores, 1-64 chars.
+var validProjectName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ValidProjectName checks if a project name is valid.
+func ValidProjectName(name string) bool {
+	return validProjectName.MatchString(name)
+}
+
+// TaskRef formats a reference to a task: "project/task-id" for a task in
+// another project, the bare task ID when project is empty.
+func TaskRef(project, taskID string) string {
+	if project == "" {
+		return taskID
+	}
+	return project + "/" + taskID
+}
+
+// ParseTaskRef splits a task reference into its project, empty for a task in
+// the current project, and task ID.
+func ParseTaskRef(ref string) (project, taskID string) {
+	if project, taskID, ok := strings.Cut(ref, "/"); ok {
+		return project, taskID
+	}
+	return "", ref
+}
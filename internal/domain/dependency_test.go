@@ -29,3 +29,37 @@ func TestDependency_Fields(t *testing.T) {
 		t.Errorf("Dependency.ParentID = %v, want %v", dep.ParentID, "ar-bbbb")
 	}
 }
+
+func TestParseTaskRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		project string
+		taskID  string
+	}{
+		{"ar-1234", "", "ar-1234"},
+		{"backend/ar-1234", "backend", "ar-1234"},
+	}
+
+	for _, tt := range tests {
+		project, taskID := ParseTaskRef(tt.ref)
+		if project != tt.project || taskID != tt.taskID {
+			t.Errorf("ParseTaskRef(%q) = %q, %q, want %q, %q", tt.ref, project, taskID, tt.project, tt.taskID)
+		}
+		if got := TaskRef(project, taskID); got != tt.ref {
+			t.Errorf("TaskRef(%q, %q) = %q, want %q", project, taskID, got, tt.ref)
+		}
+	}
+}
+
+func TestValidProjectName(t *testing.T) {
+	for _, name := range []string{"backend", "web-app", "app_2"} {
+		if !ValidProjectName(name) {
+			t.Errorf("ValidProjectName(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"", "a/b", "with space"} {
+		if ValidProjectName(name) {
+			t.Errorf("ValidProjectName(%q) = true, want false", name)
+		}
+	}
+}
@@ -19,6 +19,11 @@ const (
 	ErrCodeSpecAlreadyCancelled   ErrorCode = "SPEC_ALREADY_CANCELLED"
 	ErrCodeSpecNotCancelled       ErrorCode = "SPEC_NOT_CANCELLED"
 	ErrCodeSpecDepNotFound        ErrorCode = "SPEC_DEPENDENCY_NOT_FOUND"
+	ErrCodeSelfReview             ErrorCode = "SELF_REVIEW"
+	ErrCodeChildrenNotDone        ErrorCode = "CHILDREN_NOT_DONE"
+	ErrCodeScheduleNotFound       ErrorCode = "SCHEDULE_NOT_FOUND"
+	ErrCodeWIPLimitExceeded       ErrorCode = "WIP_LIMIT_EXCEEDED"
+	ErrCodeNotAssignee            ErrorCode = "NOT_ASSIGNEE"
 )
 
 // DomainError represents an error in the domain layer with context.
@@ -53,6 +58,25 @@ func NewAlreadyClaimedError(claimedBy string, claimedAt string) *DomainError {
 	}
 }
 
+// NewWIPLimitExceededError creates a work-in-progress limit exceeded error.
+// scope is "agent" when the claiming agent holds too many tasks and "project"
+// when the project does.
+func NewWIPLimitExceededError(scope string, limit, held int) *DomainError {
+	message := fmt.Sprintf("Agent already holds %d in-progress tasks (limit %d)", held, limit)
+	if scope == "project" {
+		message = fmt.Sprintf("Project already has %d in-progress tasks (limit %d)", held, limit)
+	}
+	return &DomainError{
+		Code:    ErrCodeWIPLimitExceeded,
+		Message: message,
+		Context: map[string]interface{}{
+			"scope": scope,
+			"limit": limit,
+			"held":  held,
+		},
+	}
+}
+
 // NewNotOwnerError creates a not owner error.
 func NewNotOwnerError(claimedBy string) *DomainError {
 	return &DomainError{
@@ -62,6 +86,16 @@ func NewNotOwnerError(claimedBy string) *DomainError {
 	}
 }
 
+// NewNotAssigneeError creates an error for claiming a task assigned to
+// another agent or group.
+func NewNotAssigneeError(assignee string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeNotAssignee,
+		Message: fmt.Sprintf("Task is assigned to %s", assignee),
+		Context: map[string]interface{}{"assignee": assignee},
+	}
+}
+
 // NewInvalidTransitionError creates an invalid status transition error.
 func NewInvalidTransitionError(from, to TaskStatus) *DomainError {
 	return &DomainError{
@@ -74,6 +108,21 @@ func NewInvalidTransitionError(from, to TaskStatus) *DomainError {
 	}
 }
 
+// NewReviewTransitionError creates an error for moving a task out of review
+// other than by the review command that must be used instead, approve or
+// reject.
+func NewReviewTransitionError(to TaskStatus, command string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeInvalidTransition,
+		Message: fmt.Sprintf("Cannot transition from %s to %s; use %s", StatusInReview, to, command),
+		Context: map[string]interface{}{
+			"from": string(StatusInReview),
+			"to":   string(to),
+			"use":  command,
+		},
+	}
+}
+
 // NewValidationError creates a validation error.
 func NewValidationError(details []string) *DomainError {
 	return &DomainError{
@@ -131,6 +180,15 @@ func NewSpecNotFoundError(specID string) *DomainError {
 	}
 }
 
+// NewScheduleNotFoundError creates a schedule not found error.
+func NewScheduleNotFoundError(scheduleID string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeScheduleNotFound,
+		Message: fmt.Sprintf("Schedule %s not found", scheduleID),
+		Context: map[string]interface{}{"id": scheduleID},
+	}
+}
+
 // NewSpecAlreadyCancelledError creates a spec already cancelled error.
 func NewSpecAlreadyCancelledError(specID string) *DomainError {
 	return &DomainError{
@@ -160,3 +218,25 @@ func NewSpecDependencyNotFoundError(childID, parentID string) *DomainError {
 		},
 	}
 }
+
+// NewSelfReviewError creates an error for an agent reviewing its own task.
+func NewSelfReviewError(agentID string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeSelfReview,
+		Message: "Task must be reviewed by a different agent",
+		Context: map[string]interface{}{"claimed_by": agentID},
+	}
+}
+
+// NewChildrenNotDoneError creates an error for completing a task whose
+// subtasks are not finished.
+func NewChildrenNotDoneError(taskID string, children []string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeChildrenNotDone,
+		Message: fmt.Sprintf("Task %s has %d unfinished subtask(s)", taskID, len(children)),
+		Context: map[string]interface{}{
+			"id":       taskID,
+			"children": children,
+		},
+	}
+}
@@ -32,6 +32,42 @@ func TestNewTaskNotFoundError(t *testing.T) {
 	}
 }
 
+func TestNewWIPLimitExceededError(t *testing.T) {
+	err := NewWIPLimitExceededError("agent", 3, 3)
+
+	if err.Code != ErrCodeWIPLimitExceeded {
+		t.Errorf("Code = %v, want %v", err.Code, ErrCodeWIPLimitExceeded)
+	}
+	if err.Context["scope"] != "agent" || err.Context["limit"] != 3 || err.Context["held"] != 3 {
+		t.Errorf("Context = %v, want scope agent, limit 3 and held 3", err.Context)
+	}
+	if project := NewWIPLimitExceededError("project", 10, 10); project.Message == err.Message {
+		t.Errorf("expected the message to name the project, got %q", project.Message)
+	}
+}
+
+func TestNewReviewTransitionError(t *testing.T) {
+	err := NewReviewTransitionError(StatusOpen, "reject")
+
+	if err.Code != ErrCodeInvalidTransition {
+		t.Errorf("Code = %v, want %v", err.Code, ErrCodeInvalidTransition)
+	}
+	if err.Context["from"] != "in_review" || err.Context["to"] != "open" || err.Context["use"] != "reject" {
+		t.Errorf("Context = %v, want from in_review, to open and use reject", err.Context)
+	}
+}
+
+func TestNewNotAssigneeError(t *testing.T) {
+	err := NewNotAssigneeError("@go")
+
+	if err.Code != ErrCodeNotAssignee {
+		t.Errorf("Code = %v, want %v", err.Code, ErrCodeNotAssignee)
+	}
+	if err.Context["assignee"] != "@go" {
+		t.Errorf("Context[assignee] = %v, want @go", err.Context["assignee"])
+	}
+}
+
 func TestNewAlreadyClaimedError(t *testing.T) {
 	claimedBy := "agent-1"
 	claimedAt := "2024-01-15T10:00:00Z"
@@ -0,0 +1,127 @@
+package domain
+
+// GraphNodeKind identifies what a graph node represents.
+type GraphNodeKind string
+
+const (
+	GraphNodeTask GraphNodeKind = "task"
+	GraphNodeSpec GraphNodeKind = "spec"
+)
+
+// GraphEdgeKind identifies the relationship a graph edge represents.
+// Edges point downstream: from the task or spec that comes first to the one
+// that waits on it, and from a parent task to its subtasks.
+type GraphEdgeKind string
+
+const (
+	// GraphEdgeDependency points from a task to a task that depends on it.
+	GraphEdgeDependency GraphEdgeKind = "dependency"
+	// GraphEdgeSpecDependency points from a spec to a spec that depends on it.
+	GraphEdgeSpecDependency GraphEdgeKind = "spec_dependency"
+	// GraphEdgeSubtask points from a parent task to one of its subtasks.
+	GraphEdgeSubtask GraphEdgeKind = "subtask"
+)
+
+// GraphDirection selects which side of a task a graph is narrowed to.
+type GraphDirection string
+
+const (
+	GraphAncestors   GraphDirection = "ancestors"
+	GraphDescendants GraphDirection = "descendants"
+	GraphBoth        GraphDirection = "both"
+)
+
+// IsValid checks if the direction is known.
+func (d GraphDirection) IsValid() bool {
+	return d == GraphAncestors || d == GraphDescendants || d == GraphBoth
+}
+
+// GraphNode is a task or spec in the project graph.
+type GraphNode struct {
+	ID       string        `json:"id"`
+	Kind     GraphNodeKind `json:"kind"`
+	Title    string        `json:"title"`
+	Status   string        `json:"status"`
+	SpecID   *string       `json:"spec_id,omitempty"`
+	Estimate *int          `json:"estimate,omitempty"` // task effort in minutes
+}
+
+// GraphEdge is a directed relationship between two graph nodes.
+type GraphEdge struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Kind GraphEdgeKind `json:"kind"`
+}
+
+// Graph holds the tasks and specs of a project and the relationships between them.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Node returns the node with the given ID.
+func (g *Graph) Node(id string) (GraphNode, bool) {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return GraphNode{}, false
+}
+
+// Subgraph returns the nodes accepted by keep and the edges between them.
+func (g *Graph) Subgraph(keep func(GraphNode) bool) *Graph {
+	sub := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	kept := make(map[string]bool)
+	for _, n := range g.Nodes {
+		if keep(n) {
+			sub.Nodes = append(sub.Nodes, n)
+			kept[n.ID] = true
+		}
+	}
+	for _, e := range g.Edges {
+		if kept[e.From] && kept[e.To] {
+			sub.Edges = append(sub.Edges, e)
+		}
+	}
+	return sub
+}
+
+// ForSpec narrows the graph to a spec and its tasks.
+func (g *Graph) ForSpec(specID string) *Graph {
+	return g.Subgraph(func(n GraphNode) bool {
+		return n.ID == specID || (n.SpecID != nil && *n.SpecID == specID)
+	})
+}
+
+// Around narrows the graph to a node and the nodes upstream (ancestors),
+// downstream (descendants) or on both sides of it.
+func (g *Graph) Around(id string, direction GraphDirection) *Graph {
+	reached := map[string]bool{id: true}
+	if direction == GraphAncestors || direction == GraphBoth {
+		g.walk(id, reached, func(e GraphEdge) (string, string) { return e.To, e.From })
+	}
+	if direction == GraphDescendants || direction == GraphBoth {
+		g.walk(id, reached, func(e GraphEdge) (string, string) { return e.From, e.To })
+	}
+	return g.Subgraph(func(n GraphNode) bool { return reached[n.ID] })
+}
+
+// walk marks every node reachable from start, following each edge from the
+// first to the second ID returned by ends.
+func (g *Graph) walk(start string, reached map[string]bool, ends func(GraphEdge) (string, string)) {
+	queue := []string{start}
+	seen := map[string]bool{start: true}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges {
+			from, to := ends(e)
+			if from == current && !seen[to] {
+				seen[to] = true
+				reached[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+}
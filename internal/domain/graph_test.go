@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func graphNodeIDs(g *Graph) map[string]bool {
+	ids := make(map[string]bool)
+	for _, n := range g.Nodes {
+		ids[n.ID] = true
+	}
+	return ids
+}
+
+func TestGraph_Around(t *testing.T) {
+	// a -> b -> c, b has subtask d, e is unrelated
+	g := &Graph{
+		Nodes: []GraphNode{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}},
+		Edges: []GraphEdge{
+			{From: "a", To: "b", Kind: GraphEdgeDependency},
+			{From: "b", To: "c", Kind: GraphEdgeDependency},
+			{From: "b", To: "d", Kind: GraphEdgeSubtask},
+		},
+	}
+
+	ancestors := graphNodeIDs(g.Around("c", GraphAncestors))
+	if len(ancestors) != 3 || !ancestors["a"] || !ancestors["b"] || !ancestors["c"] {
+		t.Errorf("ancestors of c = %v, want a, b, c", ancestors)
+	}
+
+	descendants := g.Around("b", GraphDescendants)
+	ids := graphNodeIDs(descendants)
+	if len(ids) != 3 || !ids["b"] || !ids["c"] || !ids["d"] {
+		t.Errorf("descendants of b = %v, want b, c, d", ids)
+	}
+	if len(descendants.Edges) != 2 {
+		t.Errorf("expected 2 edges among descendants, got %d", len(descendants.Edges))
+	}
+
+	both := graphNodeIDs(g.Around("b", GraphBoth))
+	if len(both) != 4 || both["e"] {
+		t.Errorf("both sides of b = %v, want a, b, c, d", both)
+	}
+}
+
+func TestGraph_ForSpec(t *testing.T) {
+	spec := "sp-1"
+	g := &Graph{
+		Nodes: []GraphNode{
+			{ID: "sp-1", Kind: GraphNodeSpec},
+			{ID: "a", Kind: GraphNodeTask, SpecID: &spec},
+			{ID: "b", Kind: GraphNodeTask},
+		},
+		Edges: []GraphEdge{{From: "a", To: "b", Kind: GraphEdgeDependency}},
+	}
+
+	sub := g.ForSpec("sp-1")
+	ids := graphNodeIDs(sub)
+	if len(ids) != 2 || !ids["sp-1"] || !ids["a"] {
+		t.Errorf("spec graph = %v, want sp-1, a", ids)
+	}
+	if len(sub.Edges) != 0 {
+		t.Errorf("expected edges leaving the spec to be dropped, got %v", sub.Edges)
+	}
+}
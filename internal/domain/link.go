@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// LinkType represents the kind of external artifact a task is linked to.
+type LinkType string
+
+const (
+	LinkTypeCommit LinkType = "commit"
+	LinkTypeBranch LinkType = "branch"
+	LinkTypePR     LinkType = "pr"
+	LinkTypeFile   LinkType = "file"
+)
+
+// ValidLinkTypes contains all valid link type values.
+var ValidLinkTypes = []LinkType{LinkTypeCommit, LinkTypeBranch, LinkTypePR, LinkTypeFile}
+
+// IsValid checks if the link type is a valid link type.
+func (t LinkType) IsValid() bool {
+	for _, v := range ValidLinkTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskLink associates a task with a commit, branch, pull request or file.
+type TaskLink struct {
+	ID        int64     `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Type      LinkType  `json:"type"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestLinkType_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		linkType LinkType
+		want     bool
+	}{
+		{"commit is valid", LinkTypeCommit, true},
+		{"branch is valid", LinkTypeBranch, true},
+		{"pr is valid", LinkTypePR, true},
+		{"file is valid", LinkTypeFile, true},
+		{"empty string is invalid", LinkType(""), false},
+		{"unknown type is invalid", LinkType("ticket"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.linkType.IsValid(); got != tt.want {
+				t.Errorf("LinkType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
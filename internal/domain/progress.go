@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DateFormat is the layout of the calendar days in progress reports.
+const DateFormat = "2006-01-02"
+
+// DailyCount is the number of tasks completed on a day.
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// BurndownPoint is the number of unfinished tasks at the end of a day.
+type BurndownPoint struct {
+	Date      string `json:"date"`
+	Remaining int    `json:"remaining"`
+}
+
+// SpecProgress reports how far the tasks of a spec have come.
+type SpecProgress struct {
+	SpecID string `json:"spec_id"`
+	// Total, Done and Remaining count the tasks of the spec, leaving out
+	// cancelled tasks like the spec's task_count and done_count.
+	Total     int `json:"total"`
+	Done      int `json:"done"`
+	Remaining int `json:"remaining"`
+	// Percent is the share of done tasks, from 0 to 100.
+	Percent float64 `json:"percent_complete"`
+	// ByStatus counts the tasks of the spec by status, including cancelled tasks.
+	ByStatus map[string]int `json:"by_status"`
+	// CompletedPerDay lists the days on which tasks were completed.
+	CompletedPerDay []DailyCount `json:"completed_per_day"`
+	// Velocity is the average number of tasks completed per day since the
+	// first completion.
+	Velocity float64 `json:"velocity"`
+	// EstimatedCompletion is the day the remaining tasks are expected to be
+	// done at the current velocity. It is omitted when nothing remains or no
+	// task was completed yet.
+	EstimatedCompletion *string `json:"estimated_completion,omitempty"`
+	// Burndown lists the unfinished tasks at the end of each day, from the
+	// day the spec was created until today.
+	Burndown []BurndownPoint `json:"burndown"`
+}
+
+// ComputeSpecProgress builds the progress report of a spec from its task
+// counts by status and the completion times of its done tasks. Days are
+// calendar days in UTC, up to and including now.
+func ComputeSpecProgress(spec *Spec, byStatus map[string]int, completions []time.Time, now time.Time) *SpecProgress {
+	progress := &SpecProgress{
+		SpecID:          spec.ID,
+		Total:           spec.TaskCount,
+		Done:            spec.DoneCount,
+		Remaining:       spec.TaskCount - spec.DoneCount,
+		ByStatus:        byStatus,
+		CompletedPerDay: []DailyCount{},
+		Burndown:        []BurndownPoint{},
+	}
+	if progress.ByStatus == nil {
+		progress.ByStatus = map[string]int{}
+	}
+	if progress.Total > 0 {
+		progress.Percent = math.Round(float64(progress.Done)*1000/float64(progress.Total)) / 10
+	}
+
+	today := day(now)
+	perDay := make(map[string]int)
+	var first time.Time
+	for _, c := range completions {
+		d := day(c)
+		perDay[d.Format(DateFormat)]++
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+	}
+	for date, count := range perDay {
+		progress.CompletedPerDay = append(progress.CompletedPerDay, DailyCount{Date: date, Count: count})
+	}
+	sort.Slice(progress.CompletedPerDay, func(i, j int) bool {
+		return progress.CompletedPerDay[i].Date < progress.CompletedPerDay[j].Date
+	})
+
+	if !first.IsZero() && !first.After(today) {
+		days := int(today.Sub(first).Hours()/24) + 1
+		progress.Velocity = math.Round(float64(len(completions))*100/float64(days)) / 100
+		if progress.Remaining > 0 && progress.Velocity > 0 {
+			eta := today.AddDate(0, 0, int(math.Ceil(float64(progress.Remaining)/progress.Velocity)))
+			date := eta.Format(DateFormat)
+			progress.EstimatedCompletion = &date
+		}
+	}
+
+	// Tasks completed before the spec was created start the burndown earlier
+	start := day(spec.CreatedAt)
+	if !first.IsZero() && first.Before(start) {
+		start = first
+	}
+	remaining := progress.Total
+	for d := start; !d.After(today); d = d.AddDate(0, 0, 1) {
+		date := d.Format(DateFormat)
+		remaining -= perDay[date]
+		progress.Burndown = append(progress.Burndown, BurndownPoint{Date: date, Remaining: remaining})
+	}
+
+	return progress
+}
+
+// day truncates a time to the start of its calendar day in UTC.
+func day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
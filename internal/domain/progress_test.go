@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeSpecProgress(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)
+	spec := &Spec{ID: "sp-1", TaskCount: 10, DoneCount: 4, CreatedAt: created}
+	completions := []time.Time{
+		time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 3, 11, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC),
+	}
+
+	progress := ComputeSpecProgress(spec, map[string]int{"done": 4, "open": 6}, completions, now)
+
+	if progress.Remaining != 6 || progress.Percent != 40 {
+		t.Errorf("Remaining = %d, Percent = %v; want 6 and 40", progress.Remaining, progress.Percent)
+	}
+	if len(progress.CompletedPerDay) != 3 || progress.CompletedPerDay[0] != (DailyCount{Date: "2026-03-02", Count: 2}) {
+		t.Errorf("CompletedPerDay = %+v, want 3 days starting with 2 on 2026-03-02", progress.CompletedPerDay)
+	}
+	// 4 tasks over the 3 days since the first completion
+	if progress.Velocity != 1.33 {
+		t.Errorf("Velocity = %v, want 1.33", progress.Velocity)
+	}
+	if progress.EstimatedCompletion == nil || *progress.EstimatedCompletion != "2026-03-09" {
+		t.Errorf("EstimatedCompletion = %v, want 2026-03-09", progress.EstimatedCompletion)
+	}
+
+	want := []BurndownPoint{
+		{Date: "2026-03-01", Remaining: 10},
+		{Date: "2026-03-02", Remaining: 8},
+		{Date: "2026-03-03", Remaining: 7},
+		{Date: "2026-03-04", Remaining: 6},
+	}
+	if len(progress.Burndown) != len(want) {
+		t.Fatalf("Burndown = %+v, want %+v", progress.Burndown, want)
+	}
+	for i := range want {
+		if progress.Burndown[i] != want[i] {
+			t.Errorf("Burndown[%d] = %+v, want %+v", i, progress.Burndown[i], want[i])
+		}
+	}
+}
+
+func TestComputeSpecProgress_NothingDone(t *testing.T) {
+	now := time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)
+	spec := &Spec{ID: "sp-1", CreatedAt: now}
+
+	progress := ComputeSpecProgress(spec, nil, nil, now)
+
+	if progress.Percent != 0 || progress.Velocity != 0 || progress.EstimatedCompletion != nil {
+		t.Errorf("expected no progress, got %+v", progress)
+	}
+	if progress.ByStatus == nil || progress.CompletedPerDay == nil || len(progress.Burndown) != 1 {
+		t.Errorf("expected empty, non-nil lists and a single burndown day, got %+v", progress)
+	}
+}
@@ -0,0 +1,107 @@
+package domain
+
+import "time"
+
+// CatchUpPolicy decides which runs a schedule makes up for when the server
+// was not running at their time.
+type CatchUpPolicy string
+
+const (
+	// CatchUpOnce creates a single task for all the missed runs (the default).
+	CatchUpOnce CatchUpPolicy = "once"
+	// CatchUpAll creates a task for each missed run, up to MaxCatchUpRuns.
+	CatchUpAll CatchUpPolicy = "all"
+	// CatchUpSkip creates no task for missed runs.
+	CatchUpSkip CatchUpPolicy = "skip"
+)
+
+// IsValid checks if the catch-up policy is one of the defined policies.
+func (p CatchUpPolicy) IsValid() bool {
+	return p == CatchUpOnce || p == CatchUpAll || p == CatchUpSkip
+}
+
+const (
+	// MaxCatchUpRuns caps the tasks created for the missed runs of a schedule
+	// with the CatchUpAll policy; the most recent runs are kept.
+	MaxCatchUpRuns = 10
+
+	// ScheduleGrace is how late a run may be created and still count as on
+	// time rather than missed.
+	ScheduleGrace = 5 * time.Minute
+
+	// SchedulerAgentID is the agent recorded for the server's background
+	// work: tasks created by schedules and overdue events.
+	SchedulerAgentID = "airyra-scheduler"
+)
+
+// Schedule creates a task each time its cron expression is due. The title and
+// description of the tasks may reference the day of the run as {{date}}.
+type Schedule struct {
+	ID          string        `json:"id"`
+	Cron        string        `json:"cron"`
+	CatchUp     CatchUpPolicy `json:"catch_up"`
+	Title       string        `json:"title"`
+	Description *string       `json:"description,omitempty"`
+	Priority    int           `json:"priority"`
+	Estimate    *int          `json:"estimate,omitempty"` // expected effort in minutes
+	SpecID      *string       `json:"spec_id,omitempty"`
+	NextRunAt   time.Time     `json:"next_run_at"`
+	LastRunAt   *time.Time    `json:"last_run_at,omitempty"`
+	LastTaskID  *string       `json:"last_task_id,omitempty"`
+	CreatedBy   string        `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// DueRuns returns the runs to create tasks for at now, following the
+// schedule's catch-up policy, and the first run after now. Runs are computed
+// in now's location. When no run is due, it returns no runs and NextRunAt.
+func (s *Schedule) DueRuns(cron *CronSchedule, now time.Time) (runs []time.Time, next time.Time) {
+	// Only the most recent runs are kept, whatever the downtime
+	next = s.NextRunAt.In(now.Location())
+	var missed []time.Time
+	for !next.IsZero() && !next.After(now) {
+		missed = append(missed, next)
+		if len(missed) > MaxCatchUpRuns {
+			missed = missed[1:]
+		}
+		next = cron.Next(next)
+	}
+	if len(missed) == 0 {
+		return nil, s.NextRunAt
+	}
+
+	latest := missed[len(missed)-1]
+	switch s.CatchUp {
+	case CatchUpAll:
+		return missed, next
+	case CatchUpSkip:
+		if now.Sub(latest) > ScheduleGrace {
+			return nil, next
+		}
+		return []time.Time{latest}, next
+	default:
+		return []time.Time{latest}, next
+	}
+}
+
+// Render returns the title and description of the task created for a run,
+// with {{date}} replaced by the day of the run.
+func (s *Schedule) Render(run time.Time) (string, *string) {
+	vars := map[string]string{"date": run.Format(DateFormat)}
+	substitute := func(text string) string {
+		return templateVar.ReplaceAllStringFunc(text, func(ref string) string {
+			if value, ok := vars[templateVar.FindStringSubmatch(ref)[1]]; ok {
+				return value
+			}
+			return ref
+		})
+	}
+
+	title := substitute(s.Title)
+	if s.Description == nil {
+		return title, nil
+	}
+	description := substitute(*s.Description)
+	return title, &description
+}
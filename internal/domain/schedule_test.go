@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSchedule_DueRuns(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * *")
+	day := func(d, hour int) time.Time { return time.Date(2026, 1, d, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		policy   CatchUpPolicy
+		now      time.Time
+		wantRuns []time.Time
+	}{
+		{"not due", CatchUpOnce, day(1, 8), nil},
+		{"on time", CatchUpOnce, day(1, 9), []time.Time{day(1, 9)}},
+		{"on time skip", CatchUpSkip, day(1, 9).Add(ScheduleGrace), []time.Time{day(1, 9)}},
+		{"missed once", CatchUpOnce, day(4, 12), []time.Time{day(4, 9)}},
+		{"missed all", CatchUpAll, day(4, 12), []time.Time{day(1, 9), day(2, 9), day(3, 9), day(4, 9)}},
+		{"missed skip", CatchUpSkip, day(4, 12), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schedule{CatchUp: tt.policy, NextRunAt: day(1, 9)}
+			runs, next := s.DueRuns(cron, tt.now)
+			if !reflect.DeepEqual(runs, tt.wantRuns) {
+				t.Errorf("DueRuns() runs = %v, want %v", runs, tt.wantRuns)
+			}
+			wantNext := cron.Next(tt.now)
+			if tt.wantRuns == nil && tt.policy != CatchUpSkip {
+				wantNext = s.NextRunAt
+			}
+			if !next.Equal(wantNext) {
+				t.Errorf("DueRuns() next = %v, want %v", next, wantNext)
+			}
+		})
+	}
+}
+
+func TestSchedule_DueRuns_CapsCatchUp(t *testing.T) {
+	cron, _ := ParseCron("* * * * *")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &Schedule{CatchUp: CatchUpAll, NextRunAt: start}
+
+	now := start.Add(24 * time.Hour)
+	runs, _ := s.DueRuns(cron, now)
+	if len(runs) != MaxCatchUpRuns {
+		t.Fatalf("expected %d runs, got %d", MaxCatchUpRuns, len(runs))
+	}
+	if !runs[len(runs)-1].Equal(now) {
+		t.Errorf("expected the most recent runs to be kept, last run %v", runs[len(runs)-1])
+	}
+}
+
+func TestSchedule_Render(t *testing.T) {
+	description := "Fixtures as of {{date}}, see {{ticket}}"
+	s := &Schedule{Title: "Rotate test fixtures ({{ date }})", Description: &description}
+
+	title, desc := s.Render(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC))
+	if title != "Rotate test fixtures (2026-01-05)" {
+		t.Errorf("title = %q", title)
+	}
+	if *desc != "Fixtures as of 2026-01-05, see {{ticket}}" {
+		t.Errorf("description = %q", *desc)
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+// ProjectSettings holds the behavior switches of a project.
+type ProjectSettings struct {
+	// RequireChildrenDone prevents a task from completing while any of its
+	// subtasks is neither done nor cancelled.
+	RequireChildrenDone bool `json:"require_children_done"`
+	// AutoCompleteParents completes a task once its last unfinished subtask
+	// is done.
+	AutoCompleteParents bool `json:"auto_complete_parents"`
+	// SpecDependenciesGateTasks keeps the tasks of a spec out of the ready
+	// queue while any spec it depends on is unfinished.
+	SpecDependenciesGateTasks bool `json:"spec_dependencies_gate_tasks"`
+	// AgentWIPLimit caps the in-progress tasks each agent may hold; zero
+	// means no limit. Agents may register a lower limit of their own.
+	AgentWIPLimit int `json:"agent_wip_limit"`
+	// ProjectWIPLimit caps the in-progress tasks of the whole project; zero
+	// means no limit.
+	ProjectWIPLimit int `json:"project_wip_limit"`
+	// MaxAttempts quarantines a task once claims of it have ended in a
+	// release this many times; zero means tasks are never quarantined.
+	MaxAttempts int `json:"max_attempts"`
+	// Admins lists the agents that may force operations on tasks claimed by
+	// other agents, such as handing them off.
+	Admins []string `json:"admins"`
+}
+
+// Validate checks the settings and returns the problems found.
+func (s *ProjectSettings) Validate() []string {
+	var errors []string
+	if s.AgentWIPLimit < 0 {
+		errors = append(errors, "agent_wip_limit cannot be negative")
+	}
+	if s.ProjectWIPLimit < 0 {
+		errors = append(errors, "project_wip_limit cannot be negative")
+	}
+	if s.MaxAttempts < 0 {
+		errors = append(errors, "max_attempts cannot be negative")
+	}
+	for _, admin := range s.Admins {
+		if admin == "" {
+			errors = append(errors, "admins cannot contain an empty agent ID")
+			break
+		}
+	}
+	return errors
+}
+
+// IsAdmin checks if the agent is one of the project admins.
+func (s *ProjectSettings) IsAdmin(agentID string) bool {
+	for _, admin := range s.Admins {
+		if admin == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultProjectSettings returns the settings of projects that have not changed them.
+func DefaultProjectSettings() *ProjectSettings {
+	return &ProjectSettings{
+		RequireChildrenDone: true,
+		AutoCompleteParents: true,
+		Admins:              []string{},
+	}
+}
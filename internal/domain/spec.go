@@ -37,8 +37,10 @@ type Spec struct {
 	ManualStatus *string    `json:"manual_status,omitempty"` // Only "cancelled" or nil
 	TaskCount    int        `json:"task_count"`
 	DoneCount    int        `json:"done_count"`
+	DueAt        *time.Time `json:"due_at,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
 }
 
 // ComputeStatus calculates the spec status based on task counts and manual status.
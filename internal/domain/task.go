@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/airyra/airyra/pkg/idgen"
 )
@@ -14,6 +17,9 @@ const (
 	StatusInProgress TaskStatus = "in_progress"
 	StatusBlocked    TaskStatus = "blocked"
 	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+	// StatusInReview is the optional review phase between in_progress and done.
+	StatusInReview TaskStatus = "in_review"
 )
 
 // Priority constants for convenience.
@@ -25,32 +31,73 @@ const (
 	PriorityLowest   = 4
 )
 
-// ValidStatuses contains all valid task status values.
-var ValidStatuses = []TaskStatus{StatusOpen, StatusInProgress, StatusBlocked, StatusDone}
+// DueSoonWindow is how close to its due date a ready task moves ahead of the
+// rest of the ready queue.
+const DueSoonWindow = 24 * time.Hour
 
-// IsValid checks if the status is a valid task status.
+// IsValid checks if the status is a well-formed status name: a lowercase
+// letter followed by lowercase letters, digits or underscores. Which
+// statuses exist is defined by the project's Workflow.
 func (s TaskStatus) IsValid() bool {
-	for _, v := range ValidStatuses {
-		if s == v {
-			return true
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
 		}
 	}
-	return false
+	return true
+}
+
+// BlockerKind describes what a blocked task is waiting on.
+type BlockerKind string
+
+const (
+	BlockerTask BlockerKind = "task"
+	BlockerSpec BlockerKind = "spec"
+	BlockerURL  BlockerKind = "url"
+)
+
+// ClassifyBlocker reports whether a blocked-by reference names a task, a spec or a URL.
+func ClassifyBlocker(ref string) BlockerKind {
+	switch {
+	case strings.Contains(ref, "://"):
+		return BlockerURL
+	case strings.HasPrefix(ref, "sp-"):
+		return BlockerSpec
+	default:
+		return BlockerTask
+	}
 }
 
 // Task represents a unit of work in the system.
 type Task struct {
-	ID          string     `json:"id"`
-	ParentID    *string    `json:"parent_id,omitempty"`
-	SpecID      *string    `json:"spec_id,omitempty"`
-	Title       string     `json:"title"`
-	Description *string    `json:"description,omitempty"`
-	Status      TaskStatus `json:"status"`
-	Priority    int        `json:"priority"`
-	ClaimedBy   *string    `json:"claimed_by,omitempty"`
-	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID                string     `json:"id"`
+	ParentID          *string    `json:"parent_id,omitempty"`
+	SpecID            *string    `json:"spec_id,omitempty"`
+	Title             string     `json:"title"`
+	Description       *string    `json:"description,omitempty"`
+	Status            TaskStatus `json:"status"`
+	Priority          int        `json:"priority"`
+	EffectivePriority *int       `json:"effective_priority,omitempty"` // inherited from dependents; ready listings only
+	EffectiveDueAt    *time.Time `json:"effective_due_at,omitempty"`   // inherited from dependents; ready listings only
+	ClaimedBy         *string    `json:"claimed_by,omitempty"`
+	ClaimedAt         *time.Time `json:"claimed_at,omitempty"`
+	BlockReason       *string    `json:"block_reason,omitempty"`
+	BlockedBy         *string    `json:"blocked_by,omitempty"`
+	AutoUnblock       bool       `json:"auto_unblock,omitempty"`
+	Estimate          *int       `json:"estimate,omitempty"` // expected effort in minutes
+	DueAt             *time.Time `json:"due_at,omitempty"`
+	DeferUntil        *time.Time `json:"defer_until,omitempty"` // kept out of the ready queue until then
+	DeferEvent        *string    `json:"defer_event,omitempty"` // kept out of the ready queue until the event is signalled
+	Assignee          *string    `json:"assignee,omitempty"`    // only this agent or @group may claim the task
+	Attempts          int        `json:"attempts,omitempty"`    // claims that ended in a release since the last quarantine
+	QuarantinedAt     *time.Time `json:"quarantined_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	WaitingOnSpecs    []string   `json:"waiting_on_specs,omitempty"` // unfinished specs gating readiness; task details only
 }
 
 // ValidPriority checks if the priority value is within valid range (0-4).
@@ -93,3 +140,82 @@ func (t *Task) SetParentID(parentID string) {
 func (t *Task) SetSpecID(specID string) {
 	t.SpecID = &specID
 }
+
+// ClearBlock removes the block reason and blocking reference from the task.
+// A quarantined task leaves quarantine with its attempts reset.
+func (t *Task) ClearBlock() {
+	t.BlockReason = nil
+	t.BlockedBy = nil
+	t.AutoUnblock = false
+	if t.QuarantinedAt != nil {
+		t.QuarantinedAt = nil
+		t.Attempts = 0
+	}
+}
+
+// RecordFailedAttempt counts a claim of the task that ended in a release.
+// Once the task has failed maxAttempts times it is quarantined: blocked with
+// a reason so no agent picks it up again until someone unblocks it. It
+// reports whether the task was quarantined; a maxAttempts of zero never
+// quarantines.
+func (t *Task) RecordFailedAttempt(maxAttempts int, now time.Time) bool {
+	t.Attempts++
+	if maxAttempts <= 0 || t.Attempts < maxAttempts {
+		return false
+	}
+
+	reason := fmt.Sprintf("quarantined after %d failed attempts", t.Attempts)
+	t.Status = StatusBlocked
+	t.BlockReason = &reason
+	t.BlockedBy = nil
+	t.AutoUnblock = false
+	t.QuarantinedAt = &now
+	return true
+}
+
+// AssigneeGroupPrefix marks an assignee that names a group of agents: an
+// assignee of "@go" lets any agent registered with the go capability claim
+// the task.
+const AssigneeGroupPrefix = "@"
+
+// IsAssignedTo reports whether an agent with the given capabilities may claim
+// the task. Unassigned tasks may be claimed by any agent.
+func (t *Task) IsAssignedTo(agentID string, capabilities []string) bool {
+	if t.Assignee == nil || *t.Assignee == agentID {
+		return true
+	}
+	group, ok := strings.CutPrefix(*t.Assignee, AssigneeGroupPrefix)
+	if !ok {
+		return false
+	}
+	for _, capability := range capabilities {
+		if capability == group {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeferred reports whether the task is deferred until after now or until
+// an event that has not been signalled yet.
+func (t *Task) IsDeferred(now time.Time) bool {
+	return t.DeferEvent != nil || (t.DeferUntil != nil && t.DeferUntil.After(now))
+}
+
+// EventNameRule describes the names allowed for events that tasks are
+// deferred until.
+const EventNameRule = "event names may only contain letters, digits, '-', '_', '.' and ':'"
+
+// ValidEventName checks if a name can be used for an event that tasks are
+// deferred until. Event names are used in URL paths as they are.
+func ValidEventName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.:", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -15,9 +15,11 @@ func TestTaskStatus_IsValid(t *testing.T) {
 		{"StatusInProgress is valid", StatusInProgress, true},
 		{"StatusBlocked is valid", StatusBlocked, true},
 		{"StatusDone is valid", StatusDone, true},
+		{"custom workflow state is valid", TaskStatus("in_review"), true},
 		{"empty string is invalid", TaskStatus(""), false},
-		{"random string is invalid", TaskStatus("random"), false},
-		{"similar but wrong is invalid", TaskStatus("Open"), false},
+		{"uppercase is invalid", TaskStatus("Open"), false},
+		{"spaces are invalid", TaskStatus("in review"), false},
+		{"leading digit is invalid", TaskStatus("1st"), false},
 	}
 
 	for _, tt := range tests {
@@ -29,21 +31,21 @@ func TestTaskStatus_IsValid(t *testing.T) {
 	}
 }
 
-func TestValidStatuses_ContainsAllStatuses(t *testing.T) {
-	expected := []TaskStatus{StatusOpen, StatusInProgress, StatusBlocked, StatusDone}
-	if len(ValidStatuses) != len(expected) {
-		t.Errorf("ValidStatuses has %d items, want %d", len(ValidStatuses), len(expected))
+func TestCoreStatuses_ContainsAllStatuses(t *testing.T) {
+	expected := []TaskStatus{StatusOpen, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}
+	if len(CoreStatuses) != len(expected) {
+		t.Errorf("CoreStatuses has %d items, want %d", len(CoreStatuses), len(expected))
 	}
 	for _, s := range expected {
 		found := false
-		for _, v := range ValidStatuses {
+		for _, v := range CoreStatuses {
 			if v == s {
 				found = true
 				break
 			}
 		}
 		if !found {
-			t.Errorf("ValidStatuses does not contain %s", s)
+			t.Errorf("CoreStatuses does not contain %s", s)
 		}
 	}
 }
@@ -172,3 +174,123 @@ func TestTask_SetParentID(t *testing.T) {
 		t.Errorf("SetParentID() ParentID = %v, want %v", *task.ParentID, parentID)
 	}
 }
+
+func TestClassifyBlocker(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want BlockerKind
+	}{
+		{"ar-1a2b", BlockerTask},
+		{"sp-1a2b", BlockerSpec},
+		{"https://github.com/org/repo/issues/1", BlockerURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			if got := ClassifyBlocker(tt.ref); got != tt.want {
+				t.Errorf("ClassifyBlocker(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_IsAssignedTo(t *testing.T) {
+	agent, group := "agent-1", "@go"
+
+	tests := []struct {
+		name         string
+		assignee     *string
+		capabilities []string
+		expected     bool
+	}{
+		{"unassigned", nil, nil, true},
+		{"assigned to the agent", &agent, nil, true},
+		{"assigned to a group the agent is in", &group, []string{"sql", "go"}, true},
+		{"assigned to a group the agent is not in", &group, []string{"sql"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Assignee: tt.assignee}
+			if got := task.IsAssignedTo("agent-1", tt.capabilities); got != tt.expected {
+				t.Errorf("IsAssignedTo() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+	other := "agent-2"
+	if (&Task{Assignee: &other}).IsAssignedTo("agent-1", []string{"agent-2"}) {
+		t.Error("expected a task assigned to another agent not to be claimable")
+	}
+}
+
+func TestTask_RecordFailedAttempt(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	task := &Task{Status: StatusOpen}
+
+	if task.RecordFailedAttempt(2, now) {
+		t.Fatal("expected the first failed attempt not to quarantine the task")
+	}
+	if !task.RecordFailedAttempt(2, now) {
+		t.Fatal("expected the second failed attempt to quarantine the task")
+	}
+	if task.Status != StatusBlocked || task.QuarantinedAt == nil || !task.QuarantinedAt.Equal(now) {
+		t.Errorf("expected a blocked, quarantined task, got status %s, quarantined at %v", task.Status, task.QuarantinedAt)
+	}
+	if task.BlockReason == nil || *task.BlockReason != "quarantined after 2 failed attempts" {
+		t.Errorf("unexpected block reason %v", task.BlockReason)
+	}
+
+	task.ClearBlock()
+	if task.QuarantinedAt != nil || task.Attempts != 0 {
+		t.Errorf("expected unblocking to reset the quarantine, got attempts %d, quarantined at %v", task.Attempts, task.QuarantinedAt)
+	}
+
+	unlimited := &Task{Status: StatusOpen, Attempts: 10}
+	if unlimited.RecordFailedAttempt(0, now) || unlimited.Attempts != 11 {
+		t.Errorf("expected attempts to be counted without quarantine, got %d", unlimited.Attempts)
+	}
+}
+
+func TestTask_IsDeferred(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	event := "deploy-done"
+
+	tests := []struct {
+		name       string
+		deferUntil *time.Time
+		deferEvent *string
+		expected   bool
+	}{
+		{"not deferred", nil, nil, false},
+		{"deferred until later", &later, nil, true},
+		{"deferral passed", &earlier, nil, false},
+		{"deferred until now", &now, nil, false},
+		{"waiting on an event", nil, &event, true},
+		{"waiting on an event after the time passed", &earlier, &event, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{DeferUntil: tt.deferUntil, DeferEvent: tt.deferEvent}
+			if got := task.IsDeferred(now); got != tt.expected {
+				t.Errorf("IsDeferred() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidEventName(t *testing.T) {
+	for _, name := range []string{"deploy-done", "release:v1.2", "migration_42"} {
+		if !ValidEventName(name) {
+			t.Errorf("ValidEventName(%q) = false, expected true", name)
+		}
+	}
+	for _, name := range []string{"", "deploy/done", "deploy done", "deploy?done", "50%"} {
+		if ValidEventName(name) {
+			t.Errorf("ValidEventName(%q) = true, expected false", name)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SpecTemplate describes a spec together with its tasks and their
+// dependencies, to be created in one go. Titles and descriptions may
+// reference variables as {{name}}.
+type SpecTemplate struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	// Vars holds the default values of variables.
+	Vars  map[string]string `json:"vars,omitempty"`
+	Tasks []TemplateTask    `json:"tasks"`
+}
+
+// TemplateTask is a task of a spec template. DependsOn lists the keys of the
+// template tasks it depends on.
+type TemplateTask struct {
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	Description *string  `json:"description,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Estimate    *int     `json:"estimate,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty"`
+}
+
+// SpecInstance is a spec created from a template, with its tasks in template
+// order.
+type SpecInstance struct {
+	Spec  *Spec   `json:"spec"`
+	Tasks []*Task `json:"tasks"`
+}
+
+// templateVar matches a variable reference such as {{name}} or {{ name }}.
+var templateVar = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// Render substitutes the variables of the template, taking values from vars
+// before the template defaults. It returns the variables that have no value.
+func (t *SpecTemplate) Render(vars map[string]string) (*SpecTemplate, []string) {
+	missing := make(map[string]bool)
+	substitute := func(s string) string {
+		return templateVar.ReplaceAllStringFunc(s, func(ref string) string {
+			name := templateVar.FindStringSubmatch(ref)[1]
+			if value, ok := vars[name]; ok {
+				return value
+			}
+			if value, ok := t.Vars[name]; ok {
+				return value
+			}
+			missing[name] = true
+			return ref
+		})
+	}
+	substitutePtr := func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		value := substitute(*s)
+		return &value
+	}
+
+	rendered := &SpecTemplate{
+		Title:       substitute(t.Title),
+		Description: substitutePtr(t.Description),
+		Tasks:       make([]TemplateTask, len(t.Tasks)),
+	}
+	for i, task := range t.Tasks {
+		task.Title = substitute(task.Title)
+		task.Description = substitutePtr(task.Description)
+		rendered.Tasks[i] = task
+	}
+
+	if len(missing) == 0 {
+		return rendered, nil
+	}
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return nil, names
+}
+
+// Validate checks the template and returns any problems found.
+func (t *SpecTemplate) Validate() []string {
+	var errors []string
+
+	if t.Title == "" {
+		errors = append(errors, "template title is required")
+	}
+
+	keys := make(map[string]bool)
+	for i, task := range t.Tasks {
+		if task.Key == "" {
+			errors = append(errors, fmt.Sprintf("task %d: key is required", i+1))
+			continue
+		}
+		if keys[task.Key] {
+			errors = append(errors, fmt.Sprintf("task %s is defined more than once", task.Key))
+		}
+		keys[task.Key] = true
+	}
+
+	for _, task := range t.Tasks {
+		name := task.Key
+		if name == "" {
+			name = task.Title
+		}
+		if task.Title == "" {
+			errors = append(errors, fmt.Sprintf("task %s: title is required", name))
+		}
+		if task.Priority != nil && !ValidPriority(*task.Priority) {
+			errors = append(errors, fmt.Sprintf("task %s: priority must be between 0 and 4", name))
+		}
+		if task.Estimate != nil && *task.Estimate < 0 {
+			errors = append(errors, fmt.Sprintf("task %s: estimate cannot be negative", name))
+		}
+		for _, dep := range task.DependsOn {
+			if dep == task.Key {
+				errors = append(errors, fmt.Sprintf("task %s cannot depend on itself", name))
+			} else if !keys[dep] {
+				errors = append(errors, fmt.Sprintf("task %s depends on undefined task %s", name, dep))
+			}
+		}
+	}
+
+	if len(errors) == 0 {
+		if cycle := t.findCycle(); cycle != nil {
+			errors = append(errors, "task dependencies form a cycle: "+strings.Join(cycle, " -> "))
+		}
+	}
+
+	return errors
+}
+
+// findCycle returns the keys of a dependency cycle between the template
+// tasks, or nil when there is none.
+func (t *SpecTemplate) findCycle() []string {
+	dependsOn := make(map[string][]string, len(t.Tasks))
+	for _, task := range t.Tasks {
+		dependsOn[task.Key] = task.DependsOn
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(t.Tasks))
+	var path []string
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		switch state[key] {
+		case visiting:
+			for i, k := range path {
+				if k == key {
+					return append(append([]string{}, path[i:]...), key)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[key] = visiting
+		path = append(path, key)
+		for _, dep := range dependsOn[key] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+		return nil
+	}
+
+	for _, task := range t.Tasks {
+		if cycle := visit(task.Key); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
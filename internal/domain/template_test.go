@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func endpointTemplate() *SpecTemplate {
+	description := "Expose {{name}} over HTTP"
+	return &SpecTemplate{
+		Title:       "Add {{ name }} endpoint",
+		Description: &description,
+		Vars:        map[string]string{"version": "v1"},
+		Tasks: []TemplateTask{
+			{Key: "design", Title: "Design /{{version}}/{{name}}"},
+			{Key: "implement", Title: "Implement {{name}}", DependsOn: []string{"design"}},
+			{Key: "test", Title: "Test {{name}}", DependsOn: []string{"implement"}},
+		},
+	}
+}
+
+func TestSpecTemplate_Render(t *testing.T) {
+	rendered, missing := endpointTemplate().Render(map[string]string{"name": "users"})
+	if missing != nil {
+		t.Fatalf("Render() missing = %v, want none", missing)
+	}
+
+	if rendered.Title != "Add users endpoint" {
+		t.Errorf("Title = %q, want %q", rendered.Title, "Add users endpoint")
+	}
+	if *rendered.Description != "Expose users over HTTP" {
+		t.Errorf("Description = %q, want %q", *rendered.Description, "Expose users over HTTP")
+	}
+	if rendered.Tasks[0].Title != "Design /v1/users" {
+		t.Errorf("Tasks[0].Title = %q, want the default version", rendered.Tasks[0].Title)
+	}
+	if !reflect.DeepEqual(rendered.Tasks[1].DependsOn, []string{"design"}) {
+		t.Errorf("Tasks[1].DependsOn = %v, want [design]", rendered.Tasks[1].DependsOn)
+	}
+
+	rendered, _ = endpointTemplate().Render(map[string]string{"name": "users", "version": "v2"})
+	if rendered.Tasks[0].Title != "Design /v2/users" {
+		t.Errorf("Tasks[0].Title = %q, want the given version to override the default", rendered.Tasks[0].Title)
+	}
+}
+
+func TestSpecTemplate_Render_Missing(t *testing.T) {
+	tmpl := endpointTemplate()
+	tmpl.Tasks = append(tmpl.Tasks, TemplateTask{Key: "docs", Title: "Document {{name}} for {{audience}}"})
+
+	if _, missing := tmpl.Render(nil); !reflect.DeepEqual(missing, []string{"audience", "name"}) {
+		t.Errorf("Render() missing = %v, want [audience name]", missing)
+	}
+}
+
+func TestSpecTemplate_Validate(t *testing.T) {
+	priority := 7
+	tests := []struct {
+		name    string
+		modify  func(tmpl *SpecTemplate)
+		wantErr bool
+	}{
+		{"valid", func(tmpl *SpecTemplate) {}, false},
+		{"no tasks", func(tmpl *SpecTemplate) { tmpl.Tasks = nil }, false},
+		{"missing title", func(tmpl *SpecTemplate) { tmpl.Title = "" }, true},
+		{"missing key", func(tmpl *SpecTemplate) { tmpl.Tasks[0].Key = "" }, true},
+		{"duplicate key", func(tmpl *SpecTemplate) { tmpl.Tasks[1].Key = "design" }, true},
+		{"missing task title", func(tmpl *SpecTemplate) { tmpl.Tasks[0].Title = "" }, true},
+		{"invalid priority", func(tmpl *SpecTemplate) { tmpl.Tasks[0].Priority = &priority }, true},
+		{"undefined dependency", func(tmpl *SpecTemplate) { tmpl.Tasks[0].DependsOn = []string{"review"} }, true},
+		{"self dependency", func(tmpl *SpecTemplate) { tmpl.Tasks[0].DependsOn = []string{"design"} }, true},
+		{"cycle", func(tmpl *SpecTemplate) { tmpl.Tasks[0].DependsOn = []string{"test"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := endpointTemplate()
+			tt.modify(tmpl)
+			errors := tmpl.Validate()
+			if (len(errors) > 0) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecTemplate_Validate_CyclePath(t *testing.T) {
+	tmpl := endpointTemplate()
+	tmpl.Tasks[0].DependsOn = []string{"test"}
+
+	want := []string{"task dependencies form a cycle: design -> test -> implement -> design"}
+	if errors := tmpl.Validate(); !reflect.DeepEqual(errors, want) {
+		t.Errorf("Validate() = %v, want %v", errors, want)
+	}
+}
@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// StatusPeriod is a stretch of time a task spent in one status.
+type StatusPeriod struct {
+	Status TaskStatus `json:"status"`
+	Start  time.Time  `json:"start"`
+	// End is omitted for the period of the task's current status.
+	End     *time.Time `json:"end,omitempty"`
+	Seconds int64      `json:"seconds"`
+	// Action and Agent tell how and by whom the task entered the status; for
+	// an in_progress period the agent is the one working on the task. Both are
+	// omitted for the period that starts when the task is created.
+	Action AuditAction `json:"action,omitempty"`
+	Agent  string      `json:"agent,omitempty"`
+}
+
+// TaskTimeline reports how long a task spent in each status, rebuilt from
+// the status changes in the audit log.
+type TaskTimeline struct {
+	TaskID string `json:"task_id"`
+	// Estimate is the expected effort in minutes.
+	Estimate *int           `json:"estimate,omitempty"`
+	Periods  []StatusPeriod `json:"periods"`
+	// TimeInStatus totals the seconds spent in each status.
+	TimeInStatus map[string]int64 `json:"time_in_status"`
+	// WorkedSeconds is the time spent in_progress and BlockedSeconds the time
+	// spent blocked.
+	WorkedSeconds  int64 `json:"worked_seconds"`
+	BlockedSeconds int64 `json:"blocked_seconds"`
+	// CycleSeconds is the time from the first claim until the task was last
+	// done. It is omitted while the task is not done.
+	CycleSeconds *int64 `json:"cycle_seconds,omitempty"`
+	Claims       int    `json:"claims"`
+	Releases     int    `json:"releases"`
+	Handoffs     int    `json:"handoffs"`
+}
+
+// BuildTaskTimeline builds the timeline of a task from its status changes and
+// handoffs, oldest first. Any change into in_progress other than a handoff
+// counts as a claim, and a handoff starts a new in_progress period worked on
+// by the receiving agent. isDone reports whether a status is a done state.
+// The current period runs until now.
+func BuildTaskTimeline(task *Task, changes []*AuditEntry, isDone func(TaskStatus) bool, now time.Time) *TaskTimeline {
+	timeline := &TaskTimeline{
+		TaskID:       task.ID,
+		Estimate:     task.Estimate,
+		Periods:      []StatusPeriod{},
+		TimeInStatus: map[string]int64{},
+	}
+
+	current := StatusPeriod{Status: task.Status, Start: task.CreatedAt}
+	if len(changes) > 0 && changes[0].Action != ActionHandoff && changes[0].OldValue != nil {
+		current.Status = TaskStatus(*changes[0].OldValue)
+	}
+
+	var firstClaim, lastDone *time.Time
+	for _, change := range changes {
+		if change.NewValue == nil {
+			continue
+		}
+		end := change.ChangedAt
+		current.End = &end
+		timeline.add(current, end)
+
+		status, agent := TaskStatus(*change.NewValue), change.ChangedBy
+		switch change.Action {
+		case ActionRelease:
+			timeline.Releases++
+		case ActionHandoff:
+			timeline.Handoffs++
+			status, agent = StatusInProgress, *change.NewValue
+		default:
+			// Entering in_progress claims the task, whether by a claim or by
+			// a workflow move
+			if status == StatusInProgress {
+				timeline.Claims++
+				if firstClaim == nil {
+					firstClaim = &end
+				}
+			}
+		}
+		current = StatusPeriod{
+			Status: status,
+			Start:  change.ChangedAt,
+			Action: change.Action,
+			Agent:  agent,
+		}
+		if isDone(current.Status) {
+			lastDone = &end
+		}
+	}
+	timeline.add(current, now)
+
+	if isDone(current.Status) && firstClaim != nil && lastDone != nil {
+		cycle := seconds(*firstClaim, *lastDone)
+		timeline.CycleSeconds = &cycle
+	}
+
+	return timeline
+}
+
+// add appends a period that lasted until end and adds it to the totals.
+func (t *TaskTimeline) add(period StatusPeriod, end time.Time) {
+	period.Seconds = seconds(period.Start, end)
+	t.TimeInStatus[string(period.Status)] += period.Seconds
+	switch period.Status {
+	case StatusInProgress:
+		t.WorkedSeconds += period.Seconds
+	case StatusBlocked:
+		t.BlockedSeconds += period.Seconds
+	}
+	t.Periods = append(t.Periods, period)
+}
+
+// seconds returns the whole seconds from start to end, or zero if end is
+// before start.
+func seconds(start, end time.Time) int64 {
+	if end.Before(start) {
+		return 0
+	}
+	return int64(end.Sub(start) / time.Second)
+}
+
+// Effort totals the tracked time of a group of tasks.
+type Effort struct {
+	// Tasks counts the tasks that were worked on and Done the done ones.
+	Tasks          int   `json:"tasks"`
+	Done           int   `json:"done"`
+	WorkedSeconds  int64 `json:"worked_seconds"`
+	BlockedSeconds int64 `json:"blocked_seconds"`
+	Releases       int   `json:"releases"`
+	// EstimateMinutes and EstimatedWorkedSeconds total the estimates and the
+	// worked time of the done tasks with an estimate, so they can be compared.
+	EstimateMinutes        int   `json:"estimate_minutes"`
+	EstimatedWorkedSeconds int64 `json:"estimated_worked_seconds"`
+}
+
+// AgentEffort is the effort of one agent.
+type AgentEffort struct {
+	Agent string `json:"agent"`
+	Effort
+}
+
+// SpecEffort is the effort spent on the tasks of one spec.
+type SpecEffort struct {
+	SpecID string `json:"spec_id"`
+	Effort
+}
+
+// TimeReport aggregates task timelines per agent and per spec.
+type TimeReport struct {
+	Total  Effort        `json:"total"`
+	Agents []AgentEffort `json:"agents"`
+	Specs  []SpecEffort  `json:"specs"`
+}
+
+// BuildTimeReport aggregates the timelines of tasks, keyed by task ID. Agents
+// are credited with the time their claims were in progress, the releases of
+// their claims and the blocked periods they started. A done task is credited
+// to the agent who last worked on it. Agents are listed by worked time, most
+// first, and specs by ID.
+func BuildTimeReport(tasks []*Task, timelines map[string]*TaskTimeline, isDone func(TaskStatus) bool) *TimeReport {
+	report := &TimeReport{Agents: []AgentEffort{}, Specs: []SpecEffort{}}
+	agents := map[string]*Effort{}
+	specs := map[string]*Effort{}
+	agent := func(name string) *Effort {
+		if agents[name] == nil {
+			agents[name] = &Effort{}
+		}
+		return agents[name]
+	}
+
+	for _, task := range tasks {
+		timeline, ok := timelines[task.ID]
+		if !ok {
+			continue
+		}
+		done := isDone(task.Status)
+
+		groups := []*Effort{&report.Total}
+		if task.SpecID != nil {
+			if specs[*task.SpecID] == nil {
+				specs[*task.SpecID] = &Effort{}
+			}
+			groups = append(groups, specs[*task.SpecID])
+		}
+		for _, group := range groups {
+			group.addTask(timeline, done)
+		}
+
+		worked := map[string]bool{}
+		lastWorker := ""
+		for i, period := range timeline.Periods {
+			switch period.Status {
+			case StatusInProgress:
+				if period.Agent == "" {
+					continue
+				}
+				effort := agent(period.Agent)
+				effort.WorkedSeconds += period.Seconds
+				if !worked[period.Agent] {
+					worked[period.Agent] = true
+					effort.Tasks++
+				}
+				lastWorker = period.Agent
+				if i+1 < len(timeline.Periods) && timeline.Periods[i+1].Action == ActionRelease {
+					effort.Releases++
+				}
+			case StatusBlocked:
+				if period.Agent != "" {
+					agent(period.Agent).BlockedSeconds += period.Seconds
+				}
+			}
+		}
+		if done && lastWorker != "" {
+			effort := agent(lastWorker)
+			effort.Done++
+			if task.Estimate != nil {
+				effort.EstimateMinutes += *task.Estimate
+				effort.EstimatedWorkedSeconds += timeline.WorkedSeconds
+			}
+		}
+	}
+
+	for name, effort := range agents {
+		report.Agents = append(report.Agents, AgentEffort{Agent: name, Effort: *effort})
+	}
+	sort.Slice(report.Agents, func(i, j int) bool {
+		a, b := report.Agents[i], report.Agents[j]
+		if a.WorkedSeconds != b.WorkedSeconds {
+			return a.WorkedSeconds > b.WorkedSeconds
+		}
+		return a.Agent < b.Agent
+	})
+	for id, effort := range specs {
+		report.Specs = append(report.Specs, SpecEffort{SpecID: id, Effort: *effort})
+	}
+	sort.Slice(report.Specs, func(i, j int) bool {
+		return report.Specs[i].SpecID < report.Specs[j].SpecID
+	})
+
+	return report
+}
+
+// addTask adds the timeline of a task to the effort.
+func (e *Effort) addTask(timeline *TaskTimeline, done bool) {
+	if timeline.WorkedSeconds > 0 || timeline.Claims > 0 {
+		e.Tasks++
+	}
+	e.WorkedSeconds += timeline.WorkedSeconds
+	e.BlockedSeconds += timeline.BlockedSeconds
+	e.Releases += timeline.Releases
+	if done {
+		e.Done++
+		if timeline.Estimate != nil {
+			e.EstimateMinutes += *timeline.Estimate
+			e.EstimatedWorkedSeconds += timeline.WorkedSeconds
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func statusChange(action AuditAction, from, to TaskStatus, at time.Time, agent string) *AuditEntry {
+	field, oldValue, newValue := "status", string(from), string(to)
+	return &AuditEntry{
+		Action:    action,
+		Field:     &field,
+		OldValue:  &oldValue,
+		NewValue:  &newValue,
+		ChangedAt: at,
+		ChangedBy: agent,
+	}
+}
+
+func isDoneState(s TaskStatus) bool {
+	return s == StatusDone
+}
+
+func TestBuildTaskTimeline(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return created.Add(time.Duration(minutes) * time.Minute) }
+	estimate := 60
+	task := &Task{ID: "ar-1", Status: StatusDone, Estimate: &estimate, CreatedAt: created}
+	changes := []*AuditEntry{
+		statusChange("claim", StatusOpen, StatusInProgress, at(10), "alice"),
+		statusChange("release", StatusInProgress, StatusOpen, at(40), "alice"),
+		statusChange("claim", StatusOpen, StatusInProgress, at(50), "bob"),
+		statusChange("block", StatusInProgress, StatusBlocked, at(70), "bob"),
+		statusChange("unblock", StatusBlocked, StatusOpen, at(100), "bob"),
+		statusChange("claim", StatusOpen, StatusInProgress, at(100), "bob"),
+		statusChange("done", StatusInProgress, StatusDone, at(120), "bob"),
+	}
+
+	timeline := BuildTaskTimeline(task, changes, isDoneState, at(200))
+
+	if len(timeline.Periods) != 8 {
+		t.Fatalf("expected 8 periods, got %+v", timeline.Periods)
+	}
+	if first := timeline.Periods[0]; first.Status != StatusOpen || first.Seconds != 600 || first.Agent != "" {
+		t.Errorf("first period = %+v, want 10 minutes open since creation", first)
+	}
+	if last := timeline.Periods[7]; last.Status != StatusDone || last.End != nil || last.Seconds != 80*60 {
+		t.Errorf("last period = %+v, want the current done period up to now", last)
+	}
+	// 30 minutes by alice, 20 + 20 by bob
+	if timeline.WorkedSeconds != 70*60 {
+		t.Errorf("WorkedSeconds = %d, want %d", timeline.WorkedSeconds, 70*60)
+	}
+	if timeline.BlockedSeconds != 30*60 || timeline.TimeInStatus["blocked"] != 30*60 {
+		t.Errorf("BlockedSeconds = %d, want %d", timeline.BlockedSeconds, 30*60)
+	}
+	if timeline.Claims != 3 || timeline.Releases != 1 {
+		t.Errorf("Claims = %d, Releases = %d; want 3 and 1", timeline.Claims, timeline.Releases)
+	}
+	if timeline.CycleSeconds == nil || *timeline.CycleSeconds != 110*60 {
+		t.Errorf("CycleSeconds = %v, want %d", timeline.CycleSeconds, 110*60)
+	}
+}
+
+func TestBuildTaskTimeline_Handoff(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return created.Add(time.Duration(minutes) * time.Minute) }
+	task := &Task{ID: "ar-1", Status: StatusDone, CreatedAt: created}
+	field, from, to := "claimed_by", "alice", "bob"
+	changes := []*AuditEntry{
+		statusChange("claim", StatusOpen, StatusInProgress, at(0), "alice"),
+		{Action: ActionHandoff, Field: &field, OldValue: &from, NewValue: &to, ChangedAt: at(20), ChangedBy: "alice"},
+		statusChange("done", StatusInProgress, StatusDone, at(50), "bob"),
+	}
+
+	timeline := BuildTaskTimeline(task, changes, isDoneState, at(60))
+
+	if len(timeline.Periods) != 4 {
+		t.Fatalf("expected 4 periods, got %+v", timeline.Periods)
+	}
+	if p := timeline.Periods[1]; p.Status != StatusInProgress || p.Agent != "alice" || p.Seconds != 20*60 {
+		t.Errorf("second period = %+v, want 20 minutes in progress by alice", p)
+	}
+	if p := timeline.Periods[2]; p.Status != StatusInProgress || p.Agent != "bob" || p.Action != ActionHandoff || p.Seconds != 30*60 {
+		t.Errorf("third period = %+v, want 30 minutes in progress by bob after the handoff", p)
+	}
+	if timeline.WorkedSeconds != 50*60 || timeline.Claims != 1 || timeline.Handoffs != 1 {
+		t.Errorf("WorkedSeconds = %d, Claims = %d, Handoffs = %d; want %d, 1 and 1",
+			timeline.WorkedSeconds, timeline.Claims, timeline.Handoffs, 50*60)
+	}
+}
+
+func TestBuildTaskTimeline_MoveIntoInProgressClaims(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return created.Add(time.Duration(minutes) * time.Minute) }
+	task := &Task{ID: "ar-1", Status: StatusDone, CreatedAt: created}
+	changes := []*AuditEntry{
+		statusChange("transition", StatusOpen, StatusInProgress, at(10), "alice"),
+		statusChange("done", StatusInProgress, StatusDone, at(40), "alice"),
+	}
+
+	timeline := BuildTaskTimeline(task, changes, isDoneState, at(60))
+
+	if timeline.Claims != 1 || timeline.WorkedSeconds != 30*60 {
+		t.Errorf("Claims = %d, WorkedSeconds = %d; want 1 and %d", timeline.Claims, timeline.WorkedSeconds, 30*60)
+	}
+	if timeline.CycleSeconds == nil || *timeline.CycleSeconds != 30*60 {
+		t.Errorf("CycleSeconds = %v, want %d", timeline.CycleSeconds, 30*60)
+	}
+}
+
+func TestBuildTaskTimeline_NoChanges(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	task := &Task{ID: "ar-1", Status: StatusOpen, CreatedAt: created}
+
+	timeline := BuildTaskTimeline(task, nil, isDoneState, created.Add(time.Hour))
+
+	if len(timeline.Periods) != 1 || timeline.Periods[0].Seconds != 3600 {
+		t.Errorf("expected one open period of an hour, got %+v", timeline.Periods)
+	}
+	if timeline.CycleSeconds != nil {
+		t.Errorf("expected no cycle time for an unfinished task, got %d", *timeline.CycleSeconds)
+	}
+}
+
+func TestBuildTimeReport(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return created.Add(time.Duration(minutes) * time.Minute) }
+	specID := "sp-1"
+	estimate := 60
+	tasks := []*Task{
+		{ID: "ar-1", SpecID: &specID, Status: StatusDone, Estimate: &estimate, CreatedAt: created},
+		{ID: "ar-2", SpecID: &specID, Status: StatusInProgress, CreatedAt: created},
+		{ID: "ar-3", Status: StatusOpen, CreatedAt: created},
+	}
+	now := at(300)
+	timelines := map[string]*TaskTimeline{
+		"ar-1": BuildTaskTimeline(tasks[0], []*AuditEntry{
+			statusChange("claim", StatusOpen, StatusInProgress, at(0), "alice"),
+			statusChange("release", StatusInProgress, StatusOpen, at(30), "alice"),
+			statusChange("claim", StatusOpen, StatusInProgress, at(30), "bob"),
+			statusChange("done", StatusInProgress, StatusDone, at(120), "bob"),
+		}, isDoneState, now),
+		"ar-2": BuildTaskTimeline(tasks[1], []*AuditEntry{
+			statusChange("claim", StatusOpen, StatusInProgress, at(240), "alice"),
+		}, isDoneState, now),
+		"ar-3": BuildTaskTimeline(tasks[2], nil, isDoneState, now),
+	}
+
+	report := BuildTimeReport(tasks, timelines, isDoneState)
+
+	want := Effort{Tasks: 2, Done: 1, WorkedSeconds: 180 * 60, Releases: 1, EstimateMinutes: 60, EstimatedWorkedSeconds: 120 * 60}
+	if report.Total != want {
+		t.Errorf("Total = %+v, want %+v", report.Total, want)
+	}
+	if len(report.Specs) != 1 || report.Specs[0].SpecID != "sp-1" || report.Specs[0].Effort != want {
+		t.Errorf("Specs = %+v, want sp-1 with the total effort", report.Specs)
+	}
+
+	if len(report.Agents) != 2 {
+		t.Fatalf("expected 2 agents, got %+v", report.Agents)
+	}
+	alice, bob := report.Agents[0], report.Agents[1]
+	if alice.Agent != "alice" || alice.WorkedSeconds != 90*60 || alice.Tasks != 2 || alice.Releases != 1 || alice.Done != 0 {
+		t.Errorf("alice = %+v, want 90 minutes on 2 tasks with 1 release", alice)
+	}
+	if bob.Agent != "bob" || bob.WorkedSeconds != 90*60 || bob.Done != 1 || bob.EstimateMinutes != 60 || bob.EstimatedWorkedSeconds != 120*60 {
+		t.Errorf("bob = %+v, want 90 minutes and the done task", bob)
+	}
+}
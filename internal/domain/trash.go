@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// DefaultTrashRetention is how long deleted tasks and specs stay in the trash
+// before they are purged automatically.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// Trash lists the deleted tasks and specs of a project that can still be restored.
+type Trash struct {
+	Tasks []*Task `json:"tasks"`
+	Specs []*Spec `json:"specs"`
+}
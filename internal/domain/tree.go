@@ -0,0 +1,29 @@
+package domain
+
+// TaskTree is a task together with its subtasks, recursively.
+type TaskTree struct {
+	*Task
+	Children []*TaskTree `json:"children"`
+}
+
+// BuildTaskTree arranges a task and its descendants into a tree. Descendants
+// keep their relative order under each parent.
+func BuildTaskTree(root *Task, descendants []*Task) *TaskTree {
+	byParent := make(map[string][]*Task)
+	for _, t := range descendants {
+		if t.ParentID != nil {
+			byParent[*t.ParentID] = append(byParent[*t.ParentID], t)
+		}
+	}
+
+	var build func(task *Task) *TaskTree
+	build = func(task *Task) *TaskTree {
+		node := &TaskTree{Task: task, Children: []*TaskTree{}}
+		for _, child := range byParent[task.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	return build(root)
+}
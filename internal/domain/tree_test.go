@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestBuildTaskTree(t *testing.T) {
+	root := &Task{ID: "ar-1"}
+	parent := func(id string) *string { return &id }
+	descendants := []*Task{
+		{ID: "ar-2", ParentID: parent("ar-1")},
+		{ID: "ar-3", ParentID: parent("ar-2")},
+		{ID: "ar-4", ParentID: parent("ar-1")},
+	}
+
+	tree := BuildTaskTree(root, descendants)
+
+	if len(tree.Children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(tree.Children))
+	}
+	if tree.Children[0].ID != "ar-2" || tree.Children[1].ID != "ar-4" {
+		t.Errorf("expected children in order ar-2, ar-4, got %s, %s", tree.Children[0].ID, tree.Children[1].ID)
+	}
+	if len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].ID != "ar-3" {
+		t.Errorf("expected ar-3 under ar-2, got %+v", tree.Children[0].Children)
+	}
+	if tree.Children[1].Children == nil {
+		t.Error("expected leaf children to be an empty slice")
+	}
+}
@@ -0,0 +1,237 @@
+package domain
+
+import "fmt"
+
+// CoreStatuses are the statuses every workflow must define. They back the
+// dedicated claim, done, release, block, unblock, cancel and reopen operations.
+var CoreStatuses = []TaskStatus{StatusOpen, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}
+
+// CancelPolicy decides how a cancelled task affects the tasks that depend on it.
+type CancelPolicy string
+
+const (
+	// CancelPolicyBlock keeps dependents of a cancelled task out of the ready queue.
+	CancelPolicyBlock CancelPolicy = "block"
+	// CancelPolicySatisfy treats a cancelled task as a satisfied dependency.
+	CancelPolicySatisfy CancelPolicy = "satisfy"
+)
+
+// IsValid checks if the cancel policy is known.
+func (p CancelPolicy) IsValid() bool {
+	return p == CancelPolicyBlock || p == CancelPolicySatisfy
+}
+
+// WorkflowState describes a task status in a project workflow.
+type WorkflowState struct {
+	Name TaskStatus `json:"name"`
+	// Done states satisfy dependencies on the task.
+	Done bool `json:"done,omitempty"`
+	// Claimable states are listed in the ready queue and can be claimed.
+	Claimable bool `json:"claimable,omitempty"`
+}
+
+// WorkflowTransition is an allowed move between two workflow states.
+type WorkflowTransition struct {
+	From TaskStatus `json:"from"`
+	To   TaskStatus `json:"to"`
+}
+
+// Workflow defines the task statuses of a project and how tasks move between them.
+type Workflow struct {
+	States      []WorkflowState      `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// DefaultWorkflow returns the workflow used by projects that have not defined their own.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		States: []WorkflowState{
+			{Name: StatusOpen, Claimable: true},
+			{Name: StatusInProgress},
+			{Name: StatusBlocked},
+			{Name: StatusDone, Done: true},
+			{Name: StatusCancelled},
+		},
+		Transitions: []WorkflowTransition{
+			{From: StatusOpen, To: StatusInProgress},
+			{From: StatusOpen, To: StatusBlocked},
+			{From: StatusInProgress, To: StatusOpen},
+			{From: StatusInProgress, To: StatusBlocked},
+			{From: StatusInProgress, To: StatusDone},
+			{From: StatusBlocked, To: StatusOpen},
+			{From: StatusOpen, To: StatusCancelled},
+			{From: StatusInProgress, To: StatusCancelled},
+			{From: StatusBlocked, To: StatusCancelled},
+			{From: StatusCancelled, To: StatusOpen},
+		},
+	}
+}
+
+// State returns the workflow state with the given name.
+func (w *Workflow) State(name TaskStatus) (WorkflowState, bool) {
+	for _, s := range w.States {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return WorkflowState{}, false
+}
+
+// HasState checks if the workflow defines the given status.
+func (w *Workflow) HasState(name TaskStatus) bool {
+	_, ok := w.State(name)
+	return ok
+}
+
+// IsDone checks if the status satisfies dependencies.
+func (w *Workflow) IsDone(name TaskStatus) bool {
+	s, ok := w.State(name)
+	return ok && s.Done
+}
+
+// IsClaimable checks if tasks in the status can be claimed.
+func (w *Workflow) IsClaimable(name TaskStatus) bool {
+	s, ok := w.State(name)
+	return ok && s.Claimable
+}
+
+// CanTransition checks if the workflow allows moving a task from one status to another.
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CancelPolicy returns how cancelled tasks affect their dependents.
+// The policy is stored as the done flag of the cancelled state.
+func (w *Workflow) CancelPolicy() CancelPolicy {
+	if w.IsDone(StatusCancelled) {
+		return CancelPolicySatisfy
+	}
+	return CancelPolicyBlock
+}
+
+// SetCancelPolicy sets how cancelled tasks affect their dependents.
+func (w *Workflow) SetCancelPolicy(policy CancelPolicy) {
+	for i := range w.States {
+		if w.States[i].Name == StatusCancelled {
+			w.States[i].Done = policy == CancelPolicySatisfy
+		}
+	}
+}
+
+// HasReview checks if completed tasks must be approved before they are done.
+func (w *Workflow) HasReview() bool {
+	return w.HasState(StatusInReview)
+}
+
+// EnableReview adds the in_review phase between in_progress and done. The
+// direct in_progress -> done transition is removed so that every task passes
+// review before it is done.
+func (w *Workflow) EnableReview() {
+	if w.HasReview() {
+		return
+	}
+
+	states := make([]WorkflowState, 0, len(w.States)+1)
+	for _, s := range w.States {
+		states = append(states, s)
+		if s.Name == StatusInProgress {
+			states = append(states, WorkflowState{Name: StatusInReview})
+		}
+	}
+	w.States = states
+
+	transitions := w.Transitions[:0]
+	for _, t := range w.Transitions {
+		if t.From != StatusInProgress || t.To != StatusDone {
+			transitions = append(transitions, t)
+		}
+	}
+	w.Transitions = append(transitions,
+		WorkflowTransition{From: StatusInProgress, To: StatusInReview},
+		WorkflowTransition{From: StatusInReview, To: StatusDone},
+		WorkflowTransition{From: StatusInReview, To: StatusOpen},
+		WorkflowTransition{From: StatusInReview, To: StatusCancelled},
+	)
+}
+
+// DisableReview removes the in_review phase and its transitions. Where
+// in_progress led to review, it leads directly to done again.
+func (w *Workflow) DisableReview() {
+	reviewed := w.CanTransition(StatusInProgress, StatusInReview)
+
+	states := w.States[:0]
+	for _, s := range w.States {
+		if s.Name != StatusInReview {
+			states = append(states, s)
+		}
+	}
+	w.States = states
+
+	transitions := w.Transitions[:0]
+	for _, t := range w.Transitions {
+		if t.From != StatusInReview && t.To != StatusInReview {
+			transitions = append(transitions, t)
+		}
+	}
+	if reviewed && !w.CanTransition(StatusInProgress, StatusDone) {
+		transitions = append(transitions, WorkflowTransition{From: StatusInProgress, To: StatusDone})
+	}
+	w.Transitions = transitions
+}
+
+// Validate checks the workflow definition and returns any problems found.
+func (w *Workflow) Validate() []string {
+	var errors []string
+
+	seen := make(map[TaskStatus]bool)
+	for _, s := range w.States {
+		if !s.Name.IsValid() {
+			errors = append(errors, fmt.Sprintf("invalid state name %q: use lowercase letters, digits and underscores", s.Name))
+			continue
+		}
+		if seen[s.Name] {
+			errors = append(errors, fmt.Sprintf("state %s is defined more than once", s.Name))
+		}
+		if s.Done && s.Claimable {
+			errors = append(errors, fmt.Sprintf("state %s cannot be both done and claimable", s.Name))
+		}
+		seen[s.Name] = true
+	}
+
+	for _, core := range CoreStatuses {
+		if !seen[core] {
+			errors = append(errors, fmt.Sprintf("workflow must define the %s state", core))
+		}
+	}
+
+	if w.HasState(StatusOpen) && !w.IsClaimable(StatusOpen) {
+		errors = append(errors, "state open must be claimable")
+	}
+	if w.HasState(StatusDone) && !w.IsDone(StatusDone) {
+		errors = append(errors, "state done must be a done state")
+	}
+	if w.IsDone(StatusInProgress) || w.IsClaimable(StatusInProgress) {
+		errors = append(errors, "state in_progress cannot be done or claimable")
+	}
+	if w.IsClaimable(StatusCancelled) {
+		errors = append(errors, "state cancelled cannot be claimable")
+	}
+	if w.IsDone(StatusInReview) || w.IsClaimable(StatusInReview) {
+		errors = append(errors, "state in_review cannot be done or claimable")
+	}
+
+	for _, t := range w.Transitions {
+		if !seen[t.From] || !seen[t.To] {
+			errors = append(errors, fmt.Sprintf("transition %s -> %s references an undefined state", t.From, t.To))
+		} else if t.From == t.To {
+			errors = append(errors, fmt.Sprintf("transition %s -> %s does not change state", t.From, t.To))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,135 @@
+package domain
+
+import "testing"
+
+func TestDefaultWorkflow_IsValid(t *testing.T) {
+	if errors := DefaultWorkflow().Validate(); len(errors) > 0 {
+		t.Errorf("DefaultWorkflow().Validate() = %v, want no errors", errors)
+	}
+}
+
+func TestWorkflow_CanTransition(t *testing.T) {
+	w := DefaultWorkflow()
+
+	tests := []struct {
+		name string
+		from TaskStatus
+		to   TaskStatus
+		want bool
+	}{
+		{"open to in_progress", StatusOpen, StatusInProgress, true},
+		{"in_progress to done", StatusInProgress, StatusDone, true},
+		{"blocked to open", StatusBlocked, StatusOpen, true},
+		{"open to done", StatusOpen, StatusDone, false},
+		{"done to open", StatusDone, StatusOpen, false},
+		{"unknown state", TaskStatus("review"), StatusDone, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("Workflow.CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	withReview := func(modify func(w *Workflow)) *Workflow {
+		w := DefaultWorkflow()
+		w.States = append(w.States, WorkflowState{Name: "review"})
+		w.Transitions = append(w.Transitions,
+			WorkflowTransition{From: StatusInProgress, To: "review"},
+			WorkflowTransition{From: "review", To: StatusDone},
+		)
+		if modify != nil {
+			modify(w)
+		}
+		return w
+	}
+
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		wantErr  bool
+	}{
+		{"custom review state", withReview(nil), false},
+		{"invalid state name", withReview(func(w *Workflow) { w.States[4].Name = "In Review" }), true},
+		{"duplicate state", withReview(func(w *Workflow) { w.States[4].Name = StatusBlocked }), true},
+		{"done and claimable", withReview(func(w *Workflow) { w.States[4] = WorkflowState{Name: "review", Done: true, Claimable: true} }), true},
+		{"missing core state", &Workflow{States: []WorkflowState{{Name: StatusOpen, Claimable: true}, {Name: StatusDone, Done: true}}}, true},
+		{"open not claimable", withReview(func(w *Workflow) { w.States[0].Claimable = false }), true},
+		{"done not done", withReview(func(w *Workflow) { w.States[3].Done = false }), true},
+		{"in_progress claimable", withReview(func(w *Workflow) { w.States[1].Claimable = true }), true},
+		{"transition to undefined state", withReview(func(w *Workflow) {
+			w.Transitions = append(w.Transitions, WorkflowTransition{From: "review", To: "qa"})
+		}), true},
+		{"self transition", withReview(func(w *Workflow) {
+			w.Transitions = append(w.Transitions, WorkflowTransition{From: "review", To: "review"})
+		}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := tt.workflow.Validate()
+			if (len(errors) > 0) != tt.wantErr {
+				t.Errorf("Workflow.Validate() = %v, wantErr %v", errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflow_EnableReview(t *testing.T) {
+	w := DefaultWorkflow()
+	w.EnableReview()
+	w.EnableReview()
+
+	if !w.HasReview() {
+		t.Fatal("expected workflow to have review after EnableReview()")
+	}
+	if len(w.States) != 6 || w.States[2].Name != StatusInReview {
+		t.Errorf("expected in_review after in_progress, got %+v", w.States)
+	}
+	if !w.CanTransition(StatusInReview, StatusDone) || !w.CanTransition(StatusInReview, StatusOpen) {
+		t.Error("expected in_review to move to done and open")
+	}
+	if w.CanTransition(StatusInProgress, StatusDone) {
+		t.Error("expected review to replace the in_progress -> done transition")
+	}
+	if errors := w.Validate(); len(errors) > 0 {
+		t.Errorf("Validate() = %v, want no errors", errors)
+	}
+
+	w.DisableReview()
+
+	if w.HasReview() {
+		t.Error("expected workflow to have no review after DisableReview()")
+	}
+	if len(w.Transitions) != len(DefaultWorkflow().Transitions) {
+		t.Errorf("expected review transitions to be removed, got %+v", w.Transitions)
+	}
+	if !w.CanTransition(StatusInProgress, StatusDone) {
+		t.Error("expected in_progress -> done to be restored")
+	}
+}
+
+func TestWorkflow_CancelPolicy(t *testing.T) {
+	w := DefaultWorkflow()
+
+	if got := w.CancelPolicy(); got != CancelPolicyBlock {
+		t.Errorf("default CancelPolicy() = %v, want %v", got, CancelPolicyBlock)
+	}
+
+	w.SetCancelPolicy(CancelPolicySatisfy)
+	if got := w.CancelPolicy(); got != CancelPolicySatisfy {
+		t.Errorf("CancelPolicy() = %v, want %v", got, CancelPolicySatisfy)
+	}
+	if !w.IsDone(StatusCancelled) {
+		t.Error("expected cancelled to satisfy dependencies with the satisfy policy")
+	}
+
+	w.SetCancelPolicy(CancelPolicyBlock)
+	if w.IsDone(StatusCancelled) {
+		t.Error("expected cancelled not to satisfy dependencies with the block policy")
+	}
+}
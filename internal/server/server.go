@@ -3,6 +3,8 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
@@ -13,7 +15,10 @@ import (
 	"time"
 
 	"github.com/airyra/airyra/internal/api"
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/service"
 	"github.com/airyra/airyra/internal/store"
+	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 const (
@@ -21,6 +26,10 @@ const (
 	DefaultAddress = "localhost:7432"
 	// DefaultShutdownTimeout is the default timeout for graceful shutdown.
 	DefaultShutdownTimeout = 30 * time.Second
+	// TrashPurgeInterval is how often expired trash is purged.
+	TrashPurgeInterval = time.Hour
+	// ScheduleInterval is how often due schedules are run.
+	ScheduleInterval = time.Minute
 )
 
 // Server manages the HTTP server lifecycle.
@@ -32,6 +41,10 @@ type Server struct {
 	addr       string
 	mu         sync.Mutex
 	started    bool
+
+	// stop is closed on shutdown to end the background loops
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 // New creates a new Server instance.
@@ -54,6 +67,7 @@ func New(addr string, manager *store.Manager) *Server {
 		manager: manager,
 		logger:  log.New(os.Stdout, "[airyra] ", log.LstdFlags),
 		addr:    addr,
+		stop:    make(chan struct{}),
 	}
 }
 
@@ -79,6 +93,9 @@ func (s *Server) Start() error {
 
 	s.logger.Printf("Server listening on %s", ln.Addr().String())
 
+	go s.purgeTrashLoop(domain.DefaultTrashRetention)
+	go s.scheduleLoop()
+
 	return s.httpServer.Serve(ln)
 }
 
@@ -94,6 +111,8 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 	s.logger.Println("Shutting down server...")
 
+	s.stopOnce.Do(func() { close(s.stop) })
+
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		return err
 	}
@@ -107,6 +126,144 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// purgeTrashLoop purges expired trash on start and then every TrashPurgeInterval
+// until the server is shut down.
+func (s *Server) purgeTrashLoop(retention time.Duration) {
+	ticker := time.NewTicker(TrashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeExpiredTrash(retention); err != nil {
+			s.logger.Printf("Warning: error purging trash: %v", err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpiredTrash permanently deletes the tasks and specs of every project
+// that have been in the trash for longer than the retention period.
+// It returns the number of tasks and specs removed.
+func (s *Server) PurgeExpiredTrash(retention time.Duration) (int, error) {
+	projects, err := s.manager.ListProjects()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().UTC().Add(-retention)
+	total := 0
+	var errs []error
+	for _, project := range projects {
+		db, err := s.manager.GetDB(project)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+
+		svc := service.NewTrashService(sqlite.NewTaskRepository(db), sqlite.NewSpecRepository(db), sqlite.NewAuditRepository(db))
+		purged, err := svc.PurgeDeletedBefore(cutoff)
+		if err != nil {
+			// Keep going: one broken project must not hold back the others
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+		}
+		if purged > 0 {
+			s.logger.Printf("Purged %d item(s) from the %s trash", purged, project)
+		}
+		total += purged
+	}
+	return total, errors.Join(errs...)
+}
+
+// scheduleLoop runs due schedules and reports overdue tasks and specs on start
+// and then every ScheduleInterval until the server is shut down. Runs missed
+// while the server was down are caught up on start according to each
+// schedule's catch-up policy.
+func (s *Server) scheduleLoop() {
+	ticker := time.NewTicker(ScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if _, err := s.RunDueSchedules(now); err != nil {
+			s.logger.Printf("Warning: error running schedules: %v", err)
+		}
+		if _, err := s.ReportOverdue(now); err != nil {
+			s.logger.Printf("Warning: error reporting overdue work: %v", err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDueSchedules creates the tasks of the schedules of every project that
+// are due at now. It returns the number of tasks created; a project that
+// fails does not stop the others, and its error is joined into the result.
+func (s *Server) RunDueSchedules(now time.Time) (int, error) {
+	projects, err := s.manager.ListProjects()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var errs []error
+	for _, project := range projects {
+		db, err := s.manager.GetDB(project)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+
+		created, err := service.NewScheduleService(db).RunDue(now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+		}
+		if created > 0 {
+			s.logger.Printf("Created %d scheduled task(s) in %s", created, project)
+		}
+		total += created
+	}
+	return total, errors.Join(errs...)
+}
+
+// ReportOverdue records an overdue event in the audit log of every project for
+// each task and spec that is overdue at now and was not reported yet. It
+// returns the number of events recorded.
+func (s *Server) ReportOverdue(now time.Time) (int, error) {
+	projects, err := s.manager.ListProjects()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var errs []error
+	for _, project := range projects {
+		db, err := s.manager.GetDB(project)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+
+		svc := service.NewOverdueService(sqlite.NewTaskRepository(db), sqlite.NewSpecRepository(db), sqlite.NewAuditRepository(db))
+		reported, err := svc.Report(now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+		}
+		if reported > 0 {
+			s.logger.Printf("%d task(s) and spec(s) became overdue in %s", reported, project)
+		}
+		total += reported
+	}
+	return total, errors.Join(errs...)
+}
+
 // Addr returns the address the server is listening on.
 // Returns empty string if the server hasn't started yet.
 func (s *Server) Addr() string {
@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/airyra/airyra/internal/domain"
 	"github.com/airyra/airyra/internal/server"
 	"github.com/airyra/airyra/internal/store"
+	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 func TestServer_StartAndShutdown(t *testing.T) {
@@ -133,3 +136,296 @@ func TestServer_DefaultAddress(t *testing.T) {
 		t.Errorf("expected default address 'localhost:7432', got %q", srv.DefaultAddr())
 	}
 }
+
+func TestServer_PurgeExpiredTrash(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "airyra-server-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manager, err := store.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err := manager.GetDB("trash-test")
+	if err != nil {
+		t.Fatalf("failed to open project: %v", err)
+	}
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	now := time.Now().UTC()
+	for _, id := range []string{"ar-0001", "ar-0002", "ar-0003"} {
+		task := domain.NewTask("Task " + id)
+		task.ID = id
+		if err := taskRepo.Create(task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+	if err := taskRepo.Delete("ar-0001", now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	if err := taskRepo.Delete("ar-0002", now); err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+
+	srv := server.New("localhost:0", manager)
+	purged, err := srv.PurgeExpiredTrash(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeExpiredTrash failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("expected 1 purged item, got %d", purged)
+	}
+
+	deleted, err := taskRepo.ListDeleted()
+	if err != nil {
+		t.Fatalf("failed to list trash: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != "ar-0002" {
+		t.Errorf("expected only the recently deleted task to remain in the trash, got %v", deleted)
+	}
+	if _, err := taskRepo.GetByID("ar-0003"); err != nil {
+		t.Errorf("live task should not be purged: %v", err)
+	}
+}
+
+func TestServer_RunDueSchedules(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "airyra-server-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manager, err := store.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err := manager.GetDB("schedule-test")
+	if err != nil {
+		t.Fatalf("failed to open project: %v", err)
+	}
+
+	// Daily at 09:00, last due three days ago: the server was down since
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	scheduleRepo := sqlite.NewScheduleRepository(db)
+	for _, schedule := range []*domain.Schedule{
+		{ID: "sc-once", Cron: "0 9 * * *", CatchUp: domain.CatchUpOnce, Title: "Rotate fixtures {{date}}", NextRunAt: time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)},
+		{ID: "sc-all", Cron: "0 9 * * *", CatchUp: domain.CatchUpAll, Title: "Report {{date}}", NextRunAt: time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)},
+		{ID: "sc-skip", Cron: "0 9 * * *", CatchUp: domain.CatchUpSkip, Title: "Standup", NextRunAt: time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)},
+		{ID: "sc-later", Cron: "0 9 * * *", CatchUp: domain.CatchUpOnce, Title: "Later", NextRunAt: time.Date(2026, 1, 11, 9, 0, 0, 0, time.UTC)},
+	} {
+		schedule.Priority = domain.PriorityNormal
+		schedule.CreatedBy = "agent-1"
+		schedule.CreatedAt, schedule.UpdatedAt = now, now
+		if err := scheduleRepo.Create(schedule); err != nil {
+			t.Fatalf("failed to create schedule: %v", err)
+		}
+	}
+
+	srv := server.New("localhost:0", manager)
+	created, err := srv.RunDueSchedules(now)
+	if err != nil {
+		t.Fatalf("RunDueSchedules failed: %v", err)
+	}
+	// One catch-up task for sc-once, four for sc-all, none for sc-skip
+	if created != 5 {
+		t.Errorf("expected 5 tasks, got %d", created)
+	}
+
+	tasks, _, err := sqlite.NewTaskRepository(db).List(sqlite.TaskFilter{}, 1, 50)
+	if err != nil {
+		t.Fatalf("failed to list tasks: %v", err)
+	}
+	titles := make(map[string]bool)
+	for _, task := range tasks {
+		titles[task.Title] = true
+	}
+	for _, title := range []string{"Rotate fixtures 2026-01-10", "Report 2026-01-07", "Report 2026-01-10"} {
+		if !titles[title] {
+			t.Errorf("expected a task titled %q, got %v", title, titles)
+		}
+	}
+
+	next := time.Date(2026, 1, 11, 9, 0, 0, 0, time.UTC)
+	for _, id := range []string{"sc-once", "sc-all", "sc-skip", "sc-later"} {
+		schedule, err := scheduleRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("failed to get schedule: %v", err)
+		}
+		if !schedule.NextRunAt.Equal(next) {
+			t.Errorf("%s: expected next run %v, got %v", id, next, schedule.NextRunAt)
+		}
+	}
+
+	created, err = srv.RunDueSchedules(now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("RunDueSchedules failed: %v", err)
+	}
+	if created != 0 {
+		t.Errorf("expected no task once the schedules have run, got %d", created)
+	}
+}
+
+func TestServer_RunDueSchedules_BrokenScheduleDoesNotStopOthers(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "airyra-server-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manager, err := store.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err := manager.GetDB("schedule-test")
+	if err != nil {
+		t.Fatalf("failed to open project: %v", err)
+	}
+
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	scheduleRepo := sqlite.NewScheduleRepository(db)
+	// The broken schedule sorts first, so an early return would skip the healthy one
+	for _, schedule := range []*domain.Schedule{
+		{ID: "sc-broken", Cron: "not a cron", CatchUp: domain.CatchUpOnce, Title: "Broken", NextRunAt: due.Add(-time.Hour)},
+		{ID: "sc-healthy", Cron: "0 9 * * *", CatchUp: domain.CatchUpOnce, Title: "Healthy", NextRunAt: due},
+	} {
+		schedule.Priority = domain.PriorityNormal
+		schedule.CreatedBy = "agent-1"
+		schedule.CreatedAt, schedule.UpdatedAt = now, now
+		if err := scheduleRepo.Create(schedule); err != nil {
+			t.Fatalf("failed to create schedule: %v", err)
+		}
+	}
+
+	srv := server.New("localhost:0", manager)
+	created, err := srv.RunDueSchedules(now)
+	if err == nil || !strings.Contains(err.Error(), "sc-broken") {
+		t.Errorf("expected an error naming the broken schedule, got %v", err)
+	}
+	if created != 1 {
+		t.Errorf("expected the healthy schedule to create 1 task, got %d", created)
+	}
+
+	tasks, _, err := sqlite.NewTaskRepository(db).List(sqlite.TaskFilter{}, 1, 50)
+	if err != nil {
+		t.Fatalf("failed to list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Healthy" {
+		t.Errorf("expected only the healthy task, got %v", tasks)
+	}
+
+	broken, err := scheduleRepo.GetByID("sc-broken")
+	if err != nil {
+		t.Fatalf("failed to get schedule: %v", err)
+	}
+	if !broken.NextRunAt.Equal(due.Add(-time.Hour)) {
+		t.Errorf("expected the broken schedule to stay due, got next run %v", broken.NextRunAt)
+	}
+}
+
+func TestServer_ReportOverdue(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "airyra-server-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manager, err := store.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err := manager.GetDB("overdue-test")
+	if err != nil {
+		t.Fatalf("failed to open project: %v", err)
+	}
+
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+	taskRepo := sqlite.NewTaskRepository(db)
+	for _, task := range []*domain.Task{
+		{ID: "ar-late", Title: "Late", Status: domain.StatusOpen, DueAt: &yesterday},
+		{ID: "ar-done", Title: "Done on time", Status: domain.StatusDone, DueAt: &yesterday},
+		{ID: "ar-soon", Title: "Not due yet", Status: domain.StatusOpen, DueAt: &tomorrow},
+		{ID: "ar-none", Title: "No due date", Status: domain.StatusOpen},
+	} {
+		task.Priority = domain.PriorityNormal
+		task.CreatedAt, task.UpdatedAt = now, now
+		if err := taskRepo.Create(task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+	spec := &domain.Spec{ID: "sp-late", Title: "Late spec", DueAt: &yesterday, CreatedAt: now, UpdatedAt: now}
+	if err := sqlite.NewSpecRepository(db).Create(spec); err != nil {
+		t.Fatalf("failed to create spec: %v", err)
+	}
+
+	srv := server.New("localhost:0", manager)
+	reported, err := srv.ReportOverdue(now)
+	if err != nil {
+		t.Fatalf("ReportOverdue failed: %v", err)
+	}
+	if reported != 2 {
+		t.Errorf("expected the late task and spec to be reported, got %d events", reported)
+	}
+
+	entries, err := sqlite.NewAuditRepository(db).ListByTaskID("ar-late")
+	if err != nil {
+		t.Fatalf("failed to list audit entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Action != domain.ActionOverdue || entries[0].ChangedBy != domain.SchedulerAgentID {
+		t.Errorf("expected an overdue event for ar-late, got %+v", entries)
+	}
+
+	// Spec events name the spec apart from tasks
+	if entries, _ := sqlite.NewAuditRepository(db).ListByTaskID("sp-late"); len(entries) != 0 {
+		t.Errorf("expected no task entries for the spec, got %+v", entries)
+	}
+	overdue := string(domain.ActionOverdue)
+	entries, _, err = sqlite.NewAuditRepository(db).Query(sqlite.AuditQueryParams{Action: &overdue, Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatalf("failed to query audit log: %v", err)
+	}
+	var specEvents int
+	for _, entry := range entries {
+		if entry.SpecID != nil && *entry.SpecID == "sp-late" && entry.TaskID == "" {
+			specEvents++
+		}
+	}
+	if specEvents != 1 {
+		t.Errorf("expected one overdue event with spec_id sp-late, got %+v", entries)
+	}
+
+	reported, err = srv.ReportOverdue(now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ReportOverdue failed: %v", err)
+	}
+	if reported != 0 {
+		t.Errorf("expected overdue work to be reported once, got %d events", reported)
+	}
+
+	// Moving the due date reports the task again once the new date passes
+	task, _ := taskRepo.GetByID("ar-late")
+	later := now.Add(time.Hour)
+	task.DueAt = &later
+	if err := taskRepo.Update(task); err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	reported, err = srv.ReportOverdue(now.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("ReportOverdue failed: %v", err)
+	}
+	if reported != 1 {
+		t.Errorf("expected the new due date to be reported, got %d events", reported)
+	}
+}
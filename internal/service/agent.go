@@ -0,0 +1,162 @@
+package service
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// AgentService handles the agent registry and presence.
+type AgentService struct {
+	agentRepo *sqlite.AgentRepository
+	taskRepo  *sqlite.TaskRepository
+}
+
+// NewAgentService creates a new AgentService for the project whose database
+// is db.
+func NewAgentService(db *sql.DB) *AgentService {
+	return &AgentService{
+		agentRepo: sqlite.NewAgentRepository(db),
+		taskRepo:  sqlite.NewTaskRepository(db),
+	}
+}
+
+// RegisterAgentInput contains the input for registering an agent.
+type RegisterAgentInput struct {
+	Name         *string
+	Capabilities []string
+	Metadata     map[string]string
+	// WIPLimit caps the in-progress tasks of the agent; nil or zero means
+	// only the project's limits apply.
+	WIPLimit *int
+}
+
+// Register registers the requesting agent, replacing the name, capabilities,
+// metadata and WIP limit of an earlier registration.
+func (s *AgentService) Register(input RegisterAgentInput, agentID string) (*domain.Agent, error) {
+	now := time.Now()
+	capabilities := input.Capabilities
+	if capabilities == nil {
+		capabilities = []string{}
+	}
+
+	wipLimit := input.WIPLimit
+	if wipLimit != nil && *wipLimit == 0 {
+		wipLimit = nil
+	}
+
+	agent := &domain.Agent{
+		ID:           agentID,
+		Name:         input.Name,
+		Capabilities: capabilities,
+		Metadata:     input.Metadata,
+		WIPLimit:     wipLimit,
+		RegisteredAt: &now,
+		FirstSeenAt:  now,
+		LastSeenAt:   now,
+	}
+	if err := s.agentRepo.Register(agent); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	registered, err := s.agentRepo.GetByID(agentID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	claims, err := s.claims()
+	if err != nil {
+		return nil, err
+	}
+	registered.Claims = claims[agentID]
+	if registered.Claims == nil {
+		registered.Claims = []domain.AgentClaim{}
+	}
+	registered.State = registered.StateAt(now, domain.DefaultAgentStaleAfter)
+	return registered, nil
+}
+
+// ListAgentsInput contains the input for listing agents.
+type ListAgentsInput struct {
+	// State keeps only the agents in the state; empty keeps all of them.
+	State domain.AgentState
+	// StaleAfter is how long an agent may go unseen before it is stale.
+	StaleAfter time.Duration
+}
+
+// List returns the agents of the project with the tasks they hold, most
+// recently seen first. Agents holding tasks claimed before presence was
+// tracked are listed as last seen when they claimed them.
+func (s *AgentService) List(input ListAgentsInput, now time.Time) ([]*domain.Agent, error) {
+	agents, err := s.agentRepo.List()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	claims, err := s.claims()
+	if err != nil {
+		return nil, err
+	}
+	for _, agent := range agents {
+		agent.Claims = claims[agent.ID]
+		if agent.Claims == nil {
+			agent.Claims = []domain.AgentClaim{}
+		}
+		delete(claims, agent.ID)
+	}
+	for id, held := range claims {
+		agent := &domain.Agent{ID: id, Capabilities: []string{}, Claims: held}
+		for _, claim := range held {
+			if claim.ClaimedAt == nil {
+				continue
+			}
+			if agent.FirstSeenAt.IsZero() || claim.ClaimedAt.Before(agent.FirstSeenAt) {
+				agent.FirstSeenAt = *claim.ClaimedAt
+			}
+			if claim.ClaimedAt.After(agent.LastSeenAt) {
+				agent.LastSeenAt = *claim.ClaimedAt
+			}
+		}
+		agents = append(agents, agent)
+	}
+	sort.SliceStable(agents, func(i, j int) bool {
+		if !agents[i].LastSeenAt.Equal(agents[j].LastSeenAt) {
+			return agents[i].LastSeenAt.After(agents[j].LastSeenAt)
+		}
+		return agents[i].ID < agents[j].ID
+	})
+
+	staleAfter := input.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = domain.DefaultAgentStaleAfter
+	}
+	filtered := []*domain.Agent{}
+	for _, agent := range agents {
+		agent.State = agent.StateAt(now, staleAfter)
+		if input.State == "" || agent.State == input.State {
+			filtered = append(filtered, agent)
+		}
+	}
+	return filtered, nil
+}
+
+// claims returns the in-progress tasks held by each agent, oldest claim
+// first, keyed by agent ID.
+func (s *AgentService) claims() (map[string][]domain.AgentClaim, error) {
+	tasks, err := s.taskRepo.ListClaimed()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	claims := map[string][]domain.AgentClaim{}
+	for _, task := range tasks {
+		claims[*task.ClaimedBy] = append(claims[*task.ClaimedBy], domain.AgentClaim{
+			TaskID:    task.ID,
+			Title:     task.Title,
+			ClaimedAt: task.ClaimedAt,
+		})
+	}
+	return claims, nil
+}
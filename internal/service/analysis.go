@@ -0,0 +1,52 @@
+package service
+
+import (
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// AnalysisService analyzes the dependency graph of a project.
+type AnalysisService struct {
+	graphRepo    *sqlite.GraphRepository
+	workflowRepo *sqlite.WorkflowRepository
+}
+
+// NewAnalysisService creates a new AnalysisService.
+func NewAnalysisService(graphRepo *sqlite.GraphRepository, workflowRepo *sqlite.WorkflowRepository) *AnalysisService {
+	return &AnalysisService{graphRepo: graphRepo, workflowRepo: workflowRepo}
+}
+
+// AnalysisInput contains the filters for a schedule analysis.
+type AnalysisInput struct {
+	// SpecID narrows the analysis to the tasks of a spec.
+	SpecID *string
+	// Limit caps the number of blocking tasks listed.
+	Limit int
+}
+
+// Schedule computes the critical path, blocking fan-out and parallelism of
+// the unfinished tasks. Tasks in a done state and cancelled tasks count as
+// finished.
+func (s *AnalysisService) Schedule(input AnalysisInput) (*domain.ScheduleAnalysis, error) {
+	graph, err := s.graphRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if input.SpecID != nil {
+		if node, ok := graph.Node(*input.SpecID); !ok || node.Kind != domain.GraphNodeSpec {
+			return nil, domain.NewSpecNotFoundError(*input.SpecID)
+		}
+		graph = graph.ForSpec(*input.SpecID)
+	}
+
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	finished := func(status string) bool {
+		return status == string(domain.StatusCancelled) || workflow.IsDone(domain.TaskStatus(status))
+	}
+	return domain.AnalyzeSchedule(graph, finished, input.Limit), nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// CommentService handles task comment business logic.
+type CommentService struct {
+	commentRepo *sqlite.CommentRepository
+	taskRepo    *sqlite.TaskRepository
+	auditRepo   *sqlite.AuditRepository
+}
+
+// NewCommentService creates a new CommentService.
+func NewCommentService(commentRepo *sqlite.CommentRepository, taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		taskRepo:    taskRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// Add adds a comment to a task, authored by the given agent.
+func (s *CommentService) Add(taskID, body, agentID string) (*domain.Comment, error) {
+	// Validate task exists
+	if _, err := s.taskRepo.GetByID(taskID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	now := time.Now().UTC()
+	comment := &domain.Comment{
+		TaskID:    taskID,
+		Body:      body,
+		Author:    agentID,
+		CreatedAt: now,
+	}
+
+	if err := s.commentRepo.Add(comment); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Log the action
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    "comment",
+		NewValue:  &body,
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+
+	return comment, nil
+}
+
+// List lists all comments on a task.
+func (s *CommentService) List(taskID string) ([]*domain.Comment, error) {
+	// Verify task exists
+	if _, err := s.taskRepo.GetByID(taskID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	comments, err := s.commentRepo.ListByTaskID(taskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return comments, nil
+}
@@ -5,27 +5,41 @@ import (
 	"time"
 
 	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store"
 	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 // DependencyService handles dependency business logic.
+// Dependencies may reference tasks in other projects as "project/task-id";
+// those projects are reached through the store manager.
 type DependencyService struct {
 	depRepo   *sqlite.DependencyRepository
 	taskRepo  *sqlite.TaskRepository
 	auditRepo *sqlite.AuditRepository
+	manager   *store.Manager
+	project   string
 }
 
-// NewDependencyService creates a new DependencyService.
-func NewDependencyService(depRepo *sqlite.DependencyRepository, taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository) *DependencyService {
+// NewDependencyService creates a new DependencyService for the tasks of project.
+func NewDependencyService(depRepo *sqlite.DependencyRepository, taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository, manager *store.Manager, project string) *DependencyService {
 	return &DependencyService{
 		depRepo:   depRepo,
 		taskRepo:  taskRepo,
 		auditRepo: auditRepo,
+		manager:   manager,
+		project:   project,
 	}
 }
 
-// Add adds a dependency (childID depends on parentID).
-func (s *DependencyService) Add(childID, parentID, agentID string) error {
+// taskNode identifies a task across projects.
+type taskNode struct {
+	project string
+	id      string
+}
+
+// Add adds a dependency (childID depends on parentRef). The parent is either a
+// task ID in the same project or a "project/task-id" reference.
+func (s *DependencyService) Add(childID, parentRef, agentID string) error {
 	// Validate child task exists
 	if _, err := s.taskRepo.GetByID(childID); err != nil {
 		if err == sql.ErrNoRows {
@@ -35,20 +49,24 @@ func (s *DependencyService) Add(childID, parentID, agentID string) error {
 	}
 
 	// Validate parent task exists
-	if _, err := s.taskRepo.GetByID(parentID); err != nil {
-		if err == sql.ErrNoRows {
-			return domain.NewTaskNotFoundError(parentID)
-		}
-		return domain.NewInternalError(err)
+	parent := s.node(parentRef)
+	if err := s.verifyNode(parent); err != nil {
+		return err
 	}
 
 	// Check for self-dependency
-	if childID == parentID {
+	if parent == (taskNode{s.project, childID}) {
 		return domain.NewValidationError([]string{"Cannot add self-dependency"})
 	}
 
 	// Check if dependency already exists
-	exists, err := s.depRepo.Exists(childID, parentID)
+	var exists bool
+	var err error
+	if parent.project == s.project {
+		exists, err = s.depRepo.Exists(childID, parent.id)
+	} else {
+		exists, err = s.depRepo.ExistsExternal(childID, parent.project, parent.id)
+	}
 	if err != nil {
 		return domain.NewInternalError(err)
 	}
@@ -56,8 +74,8 @@ func (s *DependencyService) Add(childID, parentID, agentID string) error {
 		return nil // Idempotent - already exists
 	}
 
-	// Check for cycle
-	cyclePath, err := s.depRepo.WouldCreateCycle(childID, parentID)
+	// Check for cycle, across projects
+	cyclePath, err := s.wouldCreateCycle(childID, parent)
 	if err != nil {
 		return domain.NewInternalError(err)
 	}
@@ -66,16 +84,22 @@ func (s *DependencyService) Add(childID, parentID, agentID string) error {
 	}
 
 	// Add the dependency
-	if err := s.depRepo.Add(childID, parentID); err != nil {
+	if parent.project == s.project {
+		err = s.depRepo.Add(childID, parent.id)
+	} else {
+		err = s.depRepo.AddExternal(childID, parent.project, parent.id)
+	}
+	if err != nil {
 		return domain.NewInternalError(err)
 	}
 
 	// Log the action
+	ref := s.ref(parent)
 	now := time.Now().UTC()
 	s.auditRepo.Log(&domain.AuditEntry{
 		TaskID:    childID,
 		Action:    "add_dependency",
-		NewValue:  &parentID,
+		NewValue:  &ref,
 		ChangedAt: now,
 		ChangedBy: agentID,
 	})
@@ -83,21 +107,31 @@ func (s *DependencyService) Add(childID, parentID, agentID string) error {
 	return nil
 }
 
-// Remove removes a dependency.
-func (s *DependencyService) Remove(childID, parentID, agentID string) error {
-	if err := s.depRepo.Remove(childID, parentID); err != nil {
+// Remove removes a dependency. The parent is either a task ID in the same
+// project or a "project/task-id" reference.
+func (s *DependencyService) Remove(childID, parentRef, agentID string) error {
+	parent := s.node(parentRef)
+
+	var err error
+	if parent.project == s.project {
+		err = s.depRepo.Remove(childID, parent.id)
+	} else {
+		err = s.depRepo.RemoveExternal(childID, parent.project, parent.id)
+	}
+	if err != nil {
 		if err == sql.ErrNoRows {
-			return domain.NewDependencyNotFoundError(childID, parentID)
+			return domain.NewDependencyNotFoundError(childID, parentRef)
 		}
 		return domain.NewInternalError(err)
 	}
 
 	// Log the action
+	ref := s.ref(parent)
 	now := time.Now().UTC()
 	s.auditRepo.Log(&domain.AuditEntry{
 		TaskID:    childID,
 		Action:    "remove_dependency",
-		OldValue:  &parentID,
+		OldValue:  &ref,
 		ChangedAt: now,
 		ChangedBy: agentID,
 	})
@@ -105,19 +139,245 @@ func (s *DependencyService) Remove(childID, parentID, agentID string) error {
 	return nil
 }
 
-// List lists all dependencies for a task.
-func (s *DependencyService) List(taskID string) ([]*domain.Dependency, error) {
-	// Verify task exists
+// List lists the dependencies of a task. With transitive, the dependencies
+// of the tasks it depends on are included, recursively.
+func (s *DependencyService) List(taskID string, transitive bool) ([]*domain.Dependency, error) {
+	if err := s.verifyTask(taskID); err != nil {
+		return nil, err
+	}
+
+	list := s.depRepo.ListByChild
+	if transitive {
+		list = s.depRepo.ListTransitiveByChild
+	}
+	deps, err := list(taskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Dependencies on other projects are listed but not followed
+	external, err := s.depRepo.ListExternalByChild(taskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	if transitive {
+		for _, dep := range external {
+			dep.Depth = 1
+		}
+		for _, dep := range deps {
+			further, err := s.depRepo.ListExternalByChild(dep.ParentID)
+			if err != nil {
+				return nil, domain.NewInternalError(err)
+			}
+			for _, f := range further {
+				f.Depth = dep.Depth + 1
+			}
+			external = append(external, further...)
+		}
+	}
+	return append(deps, external...), nil
+}
+
+// ListDependents lists the dependencies on a task, i.e. the tasks it blocks.
+// With transitive, the tasks blocked by those tasks are included, recursively.
+func (s *DependencyService) ListDependents(taskID string, transitive bool) ([]*domain.Dependency, error) {
+	if err := s.verifyTask(taskID); err != nil {
+		return nil, err
+	}
+
+	list := s.depRepo.ListByParent
+	if transitive {
+		list = s.depRepo.ListTransitiveByParent
+	}
+	deps, err := list(taskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return deps, nil
+}
+
+// verifyTask checks that a task exists.
+func (s *DependencyService) verifyTask(taskID string) error {
 	if _, err := s.taskRepo.GetByID(taskID); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, domain.NewTaskNotFoundError(taskID)
+			return domain.NewTaskNotFoundError(taskID)
 		}
-		return nil, domain.NewInternalError(err)
+		return domain.NewInternalError(err)
 	}
+	return nil
+}
 
-	deps, err := s.depRepo.ListByChild(taskID)
+// Waiting returns the IDs of the live tasks that depend on an unfinished task
+// in another project. Dependencies on tasks that are in the trash or no
+// longer exist do not hold tasks back.
+func (s *DependencyService) Waiting() ([]string, error) {
+	external, err := s.depRepo.ListExternal()
 	if err != nil {
 		return nil, domain.NewInternalError(err)
 	}
-	return deps, nil
+
+	byProject := make(map[string][]*domain.Dependency)
+	var projects []string
+	for _, dep := range external {
+		if _, ok := byProject[dep.Project]; !ok {
+			projects = append(projects, dep.Project)
+		}
+		byProject[dep.Project] = append(byProject[dep.Project], dep)
+	}
+
+	var waiting []string
+	seen := make(map[string]bool)
+	for _, project := range projects {
+		db, err := s.projectDB(project)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		if db == nil {
+			continue
+		}
+
+		deps := byProject[project]
+		ids := make([]string, len(deps))
+		for i, dep := range deps {
+			ids[i] = dep.ParentID
+		}
+		unfinished, err := sqlite.NewTaskRepository(db).ListUnfinished(ids)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+
+		open := make(map[string]bool, len(unfinished))
+		for _, id := range unfinished {
+			open[id] = true
+		}
+		for _, dep := range deps {
+			if open[dep.ParentID] && !seen[dep.ChildID] {
+				seen[dep.ChildID] = true
+				waiting = append(waiting, dep.ChildID)
+			}
+		}
+	}
+	return waiting, nil
+}
+
+// node resolves a task reference relative to the service's project.
+func (s *DependencyService) node(ref string) taskNode {
+	project, id := domain.ParseTaskRef(ref)
+	if project == "" {
+		project = s.project
+	}
+	return taskNode{project: project, id: id}
+}
+
+// ref formats a task reference relative to the service's project.
+func (s *DependencyService) ref(n taskNode) string {
+	if n.project == s.project {
+		return n.id
+	}
+	return domain.TaskRef(n.project, n.id)
+}
+
+// verifyNode checks that a task exists, in its own project.
+func (s *DependencyService) verifyNode(n taskNode) error {
+	if n.project == s.project {
+		return s.verifyTask(n.id)
+	}
+
+	if !domain.ValidProjectName(n.project) {
+		return domain.NewValidationError([]string{"Invalid project name in task reference: " + n.project})
+	}
+	db, err := s.projectDB(n.project)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if db == nil {
+		return domain.NewProjectNotFoundError(n.project)
+	}
+	if _, err := sqlite.NewTaskRepository(db).GetByID(n.id); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NewTaskNotFoundError(s.ref(n))
+		}
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+// projectDB returns the database of an existing project, or nil when the
+// project does not exist.
+func (s *DependencyService) projectDB(project string) (*sql.DB, error) {
+	exists, err := s.manager.HasProject(project)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return s.manager.GetDB(project)
+}
+
+// dependsOn returns the tasks a task depends on directly, in any project.
+func (s *DependencyService) dependsOn(n taskNode) ([]taskNode, error) {
+	depRepo := s.depRepo
+	if n.project != s.project {
+		db, err := s.projectDB(n.project)
+		if err != nil || db == nil {
+			return nil, err
+		}
+		depRepo = sqlite.NewDependencyRepository(db)
+	}
+
+	local, err := depRepo.ListByChild(n.id)
+	if err != nil {
+		return nil, err
+	}
+	external, err := depRepo.ListExternalByChild(n.id)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]taskNode, 0, len(local)+len(external))
+	for _, dep := range local {
+		nodes = append(nodes, taskNode{project: n.project, id: dep.ParentID})
+	}
+	for _, dep := range external {
+		nodes = append(nodes, taskNode{project: dep.Project, id: dep.ParentID})
+	}
+	return nodes, nil
+}
+
+// wouldCreateCycle checks if making childID depend on parent would create a
+// cycle, following dependencies across projects. Returns the cycle path if a
+// cycle would be created, nil otherwise.
+func (s *DependencyService) wouldCreateCycle(childID string, parent taskNode) ([]string, error) {
+	// Search the tasks parent depends on, directly or transitively, for the child
+	child := taskNode{project: s.project, id: childID}
+	visited := map[taskNode]bool{parent: true}
+	cameFrom := make(map[taskNode]taskNode)
+	queue := []taskNode{parent}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == child {
+			// Reconstruct: child -> ... -> parent -> child
+			path := []string{s.ref(current)}
+			for node := current; node != parent; {
+				node = cameFrom[node]
+				path = append(path, s.ref(node))
+			}
+			return append(path, childID), nil
+		}
+
+		next, err := s.dependsOn(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range next {
+			if !visited[n] {
+				visited[n] = true
+				cameFrom[n] = current
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return nil, nil
 }
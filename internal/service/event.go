@@ -0,0 +1,63 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// EventService signals the external events that deferred tasks wait on.
+type EventService struct {
+	db *sql.DB
+}
+
+// NewEventService creates a new EventService for the project whose database
+// is db.
+func NewEventService(db *sql.DB) *EventService {
+	return &EventService{db: db}
+}
+
+// Signal returns every task deferred until the event to the ready queue, in a
+// single transaction, and returns those tasks. Tasks also deferred to a later
+// time stay out of the queue until then. Signalling an event no task waits on
+// is not an error.
+func (s *EventService) Signal(event, agentID string) ([]*domain.Task, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	defer tx.Rollback()
+
+	taskRepo := sqlite.NewTaskRepository(tx)
+	auditRepo := sqlite.NewAuditRepository(tx)
+
+	tasks, err := taskRepo.ListDeferredOn(event)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	now := time.Now().UTC()
+	for _, task := range tasks {
+		task.DeferEvent = nil
+		task.UpdatedAt = now
+		if err := taskRepo.Update(task); err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+
+		auditRepo.Log(&domain.AuditEntry{
+			TaskID:    task.ID,
+			Action:    domain.ActionSignal,
+			Field:     strPtr("defer_event"),
+			OldValue:  &event,
+			ChangedAt: now,
+			ChangedBy: agentID,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// GraphService builds the task and spec graph of a project.
+type GraphService struct {
+	graphRepo *sqlite.GraphRepository
+}
+
+// NewGraphService creates a new GraphService.
+func NewGraphService(graphRepo *sqlite.GraphRepository) *GraphService {
+	return &GraphService{graphRepo: graphRepo}
+}
+
+// GraphInput contains the filters for reading the graph.
+type GraphInput struct {
+	// SpecID narrows the graph to a spec and its tasks.
+	SpecID *string
+	// TaskID narrows the graph to a task and the nodes in Direction from it.
+	TaskID    *string
+	Direction domain.GraphDirection
+}
+
+// Get returns the project graph, narrowed by the input filters.
+func (s *GraphService) Get(input GraphInput) (*domain.Graph, error) {
+	graph, err := s.graphRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if input.SpecID != nil {
+		if node, ok := graph.Node(*input.SpecID); !ok || node.Kind != domain.GraphNodeSpec {
+			return nil, domain.NewSpecNotFoundError(*input.SpecID)
+		}
+		graph = graph.ForSpec(*input.SpecID)
+	}
+
+	if input.TaskID != nil {
+		if node, ok := graph.Node(*input.TaskID); !ok || node.Kind != domain.GraphNodeTask {
+			return nil, domain.NewTaskNotFoundError(*input.TaskID)
+		}
+		direction := input.Direction
+		if direction == "" {
+			direction = domain.GraphBoth
+		}
+		graph = graph.Around(*input.TaskID, direction)
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// LinkService handles task link business logic.
+type LinkService struct {
+	linkRepo  *sqlite.LinkRepository
+	taskRepo  *sqlite.TaskRepository
+	auditRepo *sqlite.AuditRepository
+}
+
+// NewLinkService creates a new LinkService.
+func NewLinkService(linkRepo *sqlite.LinkRepository, taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository) *LinkService {
+	return &LinkService{
+		linkRepo:  linkRepo,
+		taskRepo:  taskRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// Add links a task to a commit, branch, pull request or file.
+// Adding a link that already exists returns the existing link.
+func (s *LinkService) Add(taskID string, linkType domain.LinkType, value, agentID string) (*domain.TaskLink, error) {
+	// Validate task exists
+	if _, err := s.taskRepo.GetByID(taskID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Idempotent - return the existing link
+	existing, err := s.linkRepo.Get(taskID, linkType, value)
+	if err == nil {
+		return existing, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, domain.NewInternalError(err)
+	}
+
+	now := time.Now().UTC()
+	link := &domain.TaskLink{
+		TaskID:    taskID,
+		Type:      linkType,
+		Value:     value,
+		CreatedAt: now,
+		CreatedBy: agentID,
+	}
+
+	if err := s.linkRepo.Add(link); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Log the action
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    "add_link",
+		Field:     strPtr(string(linkType)),
+		NewValue:  &value,
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+
+	return link, nil
+}
+
+// List lists all links for a task.
+func (s *LinkService) List(taskID string) ([]*domain.TaskLink, error) {
+	// Verify task exists
+	if _, err := s.taskRepo.GetByID(taskID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	links, err := s.linkRepo.ListByTaskID(taskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return links, nil
+}
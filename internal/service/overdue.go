@@ -0,0 +1,68 @@
+package service
+
+import (
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// OverdueService reports tasks and specs that pass their due date unfinished.
+type OverdueService struct {
+	taskRepo  *sqlite.TaskRepository
+	specRepo  *sqlite.SpecRepository
+	auditRepo *sqlite.AuditRepository
+}
+
+// NewOverdueService creates a new OverdueService.
+func NewOverdueService(taskRepo *sqlite.TaskRepository, specRepo *sqlite.SpecRepository, auditRepo *sqlite.AuditRepository) *OverdueService {
+	return &OverdueService{
+		taskRepo:  taskRepo,
+		specRepo:  specRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// Report records an overdue event in the audit log for each task and spec
+// that is overdue at now and has not been reported for its current due date.
+// Changing the due date of an overdue task or spec reports it again. It
+// returns the number of events recorded.
+func (s *OverdueService) Report(now time.Time) (int, error) {
+	tasks, err := s.taskRepo.ListUnreportedOverdue(now)
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+	specs, err := s.specRepo.ListUnreportedOverdue(now)
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+
+	reported := 0
+	for _, task := range tasks {
+		if err := s.logOverdue(&domain.AuditEntry{TaskID: task.ID}, task.DueAt, now); err != nil {
+			return reported, err
+		}
+		reported++
+	}
+	for _, spec := range specs {
+		if err := s.logOverdue(&domain.AuditEntry{SpecID: &spec.ID}, spec.DueAt, now); err != nil {
+			return reported, err
+		}
+		reported++
+	}
+	return reported, nil
+}
+
+// logOverdue records the overdue event of the task or spec that entry names.
+// The due date is the entry's new value, which tells reported due dates apart.
+func (s *OverdueService) logOverdue(entry *domain.AuditEntry, dueAt *time.Time, now time.Time) error {
+	entry.Action = domain.ActionOverdue
+	entry.Field = strPtr("due_at")
+	entry.NewValue = formatTimePtr(dueAt)
+	entry.ChangedAt = now.UTC()
+	entry.ChangedBy = domain.SchedulerAgentID
+	if err := s.auditRepo.Log(entry); err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+	"github.com/airyra/airyra/pkg/idgen"
+)
+
+// ScheduleService handles recurring task schedules. Cron expressions are
+// evaluated in the server's local time zone.
+type ScheduleService struct {
+	db           *sql.DB
+	scheduleRepo *sqlite.ScheduleRepository
+}
+
+// NewScheduleService creates a new ScheduleService for the project whose
+// database is db.
+func NewScheduleService(db *sql.DB) *ScheduleService {
+	return &ScheduleService{
+		db:           db,
+		scheduleRepo: sqlite.NewScheduleRepository(db),
+	}
+}
+
+// CreateScheduleInput contains the input for creating a schedule.
+type CreateScheduleInput struct {
+	Cron        string
+	CatchUp     domain.CatchUpPolicy
+	Title       string
+	Description *string
+	Priority    *int
+	// Estimate is the expected effort in minutes; zero means no estimate.
+	Estimate *int
+	SpecID   *string
+}
+
+// Create creates a new schedule, first due at the next match of its cron
+// expression.
+func (s *ScheduleService) Create(input CreateScheduleInput, agentID string) (*domain.Schedule, error) {
+	cron, err := domain.ParseCron(input.Cron)
+	if err != nil {
+		return nil, domain.NewValidationError([]string{err.Error()})
+	}
+
+	now := time.Now()
+	next := cron.Next(now)
+	if next.IsZero() {
+		return nil, domain.NewValidationError([]string{"cron expression " + input.Cron + " never matches"})
+	}
+
+	if input.SpecID != nil {
+		if _, err := sqlite.NewSpecRepository(s.db).GetByID(*input.SpecID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, domain.NewSpecNotFoundError(*input.SpecID)
+			}
+			return nil, domain.NewInternalError(err)
+		}
+	}
+
+	id, err := idgen.GenerateWithPrefix("sc")
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	catchUp := input.CatchUp
+	if catchUp == "" {
+		catchUp = domain.CatchUpOnce
+	}
+	priority := domain.PriorityNormal
+	if input.Priority != nil {
+		priority = *input.Priority
+	}
+
+	schedule := &domain.Schedule{
+		ID:          id,
+		Cron:        input.Cron,
+		CatchUp:     catchUp,
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    priority,
+		Estimate:    positiveOrNil(input.Estimate),
+		SpecID:      input.SpecID,
+		NextRunAt:   next.UTC(),
+		CreatedBy:   agentID,
+		CreatedAt:   now.UTC(),
+		UpdatedAt:   now.UTC(),
+	}
+
+	if err := s.scheduleRepo.Create(schedule); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return schedule, nil
+}
+
+// Get retrieves a schedule by ID.
+func (s *ScheduleService) Get(id string) (*domain.Schedule, error) {
+	schedule, err := s.scheduleRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewScheduleNotFoundError(id)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+	return schedule, nil
+}
+
+// List retrieves all schedules, soonest first.
+func (s *ScheduleService) List() ([]*domain.Schedule, error) {
+	schedules, err := s.scheduleRepo.List()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return schedules, nil
+}
+
+// Delete removes a schedule. The tasks it created are kept.
+func (s *ScheduleService) Delete(id string) error {
+	if err := s.scheduleRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NewScheduleNotFoundError(id)
+		}
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+// RunNow creates the task of a schedule right away. The schedule's next run
+// is left unchanged.
+func (s *ScheduleService) RunNow(id, agentID string) (*domain.Task, error) {
+	schedule, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.run(schedule, []time.Time{time.Now()}, schedule.NextRunAt, agentID)
+	if err != nil {
+		return nil, err
+	}
+	return tasks[0], nil
+}
+
+// RunDue creates the tasks of the schedules due at now, following their
+// catch-up policies, and moves each schedule to its next run. It returns the
+// number of tasks created. A schedule that fails is left due and does not stop
+// the others; the errors of all failed schedules are joined.
+func (s *ScheduleService) RunDue(now time.Time) (int, error) {
+	schedules, err := s.scheduleRepo.ListDue(now)
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+
+	created := 0
+	var errs []error
+	for _, schedule := range schedules {
+		cron, err := domain.ParseCron(schedule.Cron)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+			continue
+		}
+
+		runs, next := schedule.DueRuns(cron, now)
+		if next.IsZero() {
+			// Never due again; park it far ahead rather than retrying every tick
+			next = now.AddDate(100, 0, 0)
+		}
+		tasks, err := s.run(schedule, runs, next, domain.SchedulerAgentID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+			continue
+		}
+		created += len(tasks)
+	}
+	return created, errors.Join(errs...)
+}
+
+// run creates a task for each run of a schedule and records the last run and
+// the next one, in a single transaction.
+func (s *ScheduleService) run(schedule *domain.Schedule, runs []time.Time, next time.Time, agentID string) ([]*domain.Task, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	defer tx.Rollback()
+
+	taskSvc := NewTaskService(sqlite.NewTaskRepository(tx), sqlite.NewAuditRepository(tx))
+
+	tasks := make([]*domain.Task, 0, len(runs))
+	for _, run := range runs {
+		title, description := schedule.Render(run)
+		priority := schedule.Priority
+		task, err := taskSvc.Create(CreateTaskInput{
+			SpecID:      schedule.SpecID,
+			Title:       title,
+			Description: description,
+			Priority:    &priority,
+			Estimate:    schedule.Estimate,
+		}, agentID)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	var lastRunAt *time.Time
+	var lastTaskID *string
+	if len(tasks) > 0 {
+		last := runs[len(runs)-1].UTC()
+		lastRunAt = &last
+		lastTaskID = &tasks[len(tasks)-1].ID
+	}
+	if err := sqlite.NewScheduleRepository(tx).RecordRun(schedule.ID, next, lastRunAt, lastTaskID); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// SettingsService handles the project settings.
+type SettingsService struct {
+	settingsRepo *sqlite.SettingsRepository
+}
+
+// NewSettingsService creates a new SettingsService.
+func NewSettingsService(settingsRepo *sqlite.SettingsRepository) *SettingsService {
+	return &SettingsService{settingsRepo: settingsRepo}
+}
+
+// Get retrieves the project settings.
+func (s *SettingsService) Get() (*domain.ProjectSettings, error) {
+	settings, err := s.settingsRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return settings, nil
+}
+
+// Set replaces the project settings.
+func (s *SettingsService) Set(settings *domain.ProjectSettings) (*domain.ProjectSettings, error) {
+	if err := s.settingsRepo.Replace(settings); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return s.Get()
+}
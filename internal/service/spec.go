@@ -27,6 +27,8 @@ func NewSpecService(specRepo *sqlite.SpecRepository, auditRepo *sqlite.AuditRepo
 type CreateSpecInput struct {
 	Title       string
 	Description *string
+	// DueAt is the spec's due date; nil or the zero time means none.
+	DueAt *time.Time
 }
 
 // Create creates a new spec.
@@ -41,6 +43,7 @@ func (s *SpecService) Create(input CreateSpecInput, agentID string) (*domain.Spe
 		ID:          id,
 		Title:       input.Title,
 		Description: input.Description,
+		DueAt:       timeOrNil(input.DueAt),
 		TaskCount:   0,
 		DoneCount:   0,
 		CreatedAt:   now,
@@ -66,16 +69,44 @@ func (s *SpecService) Get(id string) (*domain.Spec, error) {
 	return spec, nil
 }
 
+// Progress reports the progress of a spec: its task counts, the tasks
+// completed per day according to the audit log, and a burndown until today.
+func (s *SpecService) Progress(id string) (*domain.SpecProgress, error) {
+	spec, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus, err := s.specRepo.CountTasksByStatus(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	completions, err := s.specRepo.ListCompletionTimes(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return domain.ComputeSpecProgress(spec, byStatus, completions, time.Now().UTC()), nil
+}
+
 // ListSpecsInput contains the input for listing specs.
 type ListSpecsInput struct {
-	Status  *domain.SpecStatus
+	Status *domain.SpecStatus
+	// Overdue keeps the unfinished specs past their due date.
+	Overdue bool
 	Page    int
 	PerPage int
 }
 
 // List retrieves specs with pagination.
 func (s *SpecService) List(input ListSpecsInput) ([]*domain.Spec, int, error) {
-	specs, total, err := s.specRepo.List(input.Status, input.Page, input.PerPage)
+	filter := sqlite.SpecFilter{Status: input.Status}
+	if input.Overdue {
+		now := time.Now()
+		filter.OverdueAt = &now
+	}
+
+	specs, total, err := s.specRepo.List(filter, input.Page, input.PerPage)
 	if err != nil {
 		return nil, 0, domain.NewInternalError(err)
 	}
@@ -95,6 +126,8 @@ func (s *SpecService) ListReady(page, perPage int) ([]*domain.Spec, int, error)
 type UpdateSpecInput struct {
 	Title       *string
 	Description *string
+	// DueAt sets the spec's due date; the zero time clears it.
+	DueAt *time.Time
 }
 
 // Update updates a spec.
@@ -117,6 +150,10 @@ func (s *SpecService) Update(id string, input UpdateSpecInput, agentID string) (
 		spec.Description = input.Description
 	}
 
+	if input.DueAt != nil {
+		spec.DueAt = timeOrNil(input.DueAt)
+	}
+
 	spec.UpdatedAt = now
 
 	if err := s.specRepo.Update(spec); err != nil {
@@ -174,7 +211,7 @@ func (s *SpecService) Reopen(id string, agentID string) (*domain.Spec, error) {
 	return spec, nil
 }
 
-// Delete deletes a spec.
+// Delete moves a spec to the trash.
 func (s *SpecService) Delete(id string, agentID string) error {
 	// Check if spec exists
 	_, err := s.specRepo.GetByID(id)
@@ -185,7 +222,7 @@ func (s *SpecService) Delete(id string, agentID string) error {
 		return domain.NewInternalError(err)
 	}
 
-	if err := s.specRepo.Delete(id); err != nil {
+	if err := s.specRepo.Delete(id, time.Now().UTC()); err != nil {
 		return domain.NewInternalError(err)
 	}
 
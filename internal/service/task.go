@@ -2,6 +2,7 @@ package service
 
 import (
 	"database/sql"
+	"strconv"
 	"time"
 
 	"github.com/airyra/airyra/internal/domain"
@@ -30,10 +31,28 @@ type CreateTaskInput struct {
 	Title       string
 	Description *string
 	Priority    *int
+	// Estimate is the expected effort in minutes; zero means no estimate.
+	Estimate *int
+	// DueAt is the task's due date; nil or the zero time means none.
+	DueAt *time.Time
+	// DeferUntil keeps the task out of the ready queue until then; nil or the
+	// zero time means it is not deferred.
+	DeferUntil *time.Time
+	// DeferEvent keeps the task out of the ready queue until the event is
+	// signalled; nil or empty means it waits on no event.
+	DeferEvent *string
+	// Assignee restricts who may claim the task to an agent or an @group;
+	// nil or empty means anyone may.
+	Assignee *string
 }
 
-// Create creates a new task.
+// Create creates a new task. Its parent task and spec, when given, must
+// exist and not be in the trash.
 func (s *TaskService) Create(input CreateTaskInput, agentID string) (*domain.Task, error) {
+	if err := s.checkReferences(input.ParentID, input.SpecID); err != nil {
+		return nil, err
+	}
+
 	id, err := idgen.Generate()
 	if err != nil {
 		return nil, domain.NewInternalError(err)
@@ -53,6 +72,11 @@ func (s *TaskService) Create(input CreateTaskInput, agentID string) (*domain.Tas
 		Description: input.Description,
 		Status:      domain.StatusOpen,
 		Priority:    priority,
+		Estimate:    positiveOrNil(input.Estimate),
+		DueAt:       timeOrNil(input.DueAt),
+		DeferUntil:  timeOrNil(input.DeferUntil),
+		DeferEvent:  nonEmptyOrNil(input.DeferEvent),
+		Assignee:    nonEmptyOrNil(input.Assignee),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -72,6 +96,33 @@ func (s *TaskService) Create(input CreateTaskInput, agentID string) (*domain.Tas
 	return task, nil
 }
 
+// checkReferences rejects a parent task or spec that does not exist or is in
+// the trash.
+func (s *TaskService) checkReferences(parentID, specID *string) error {
+	var errors []string
+	if parentID != nil {
+		if _, err := s.taskRepo.GetByID(*parentID); err != nil {
+			if err != sql.ErrNoRows {
+				return domain.NewInternalError(err)
+			}
+			errors = append(errors, "parent_id: task "+*parentID+" does not exist or is in the trash")
+		}
+	}
+	if specID != nil {
+		exists, err := s.taskRepo.SpecExists(*specID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+		if !exists {
+			errors = append(errors, "spec_id: spec "+*specID+" does not exist or is in the trash")
+		}
+	}
+	if len(errors) > 0 {
+		return domain.NewValidationError(errors)
+	}
+	return nil
+}
+
 // Get retrieves a task by ID.
 func (s *TaskService) Get(id string) (*domain.Task, error) {
 	task, err := s.taskRepo.GetByID(id)
@@ -84,37 +135,105 @@ func (s *TaskService) Get(id string) (*domain.Task, error) {
 	return task, nil
 }
 
+// ExplainSpecGate records on a task the unfinished specs its spec depends on,
+// which keep it out of the ready queue when the project settings gate tasks on
+// spec dependencies.
+func (s *TaskService) ExplainSpecGate(task *domain.Task, settings *domain.ProjectSettings) error {
+	if !settings.SpecDependenciesGateTasks || task.SpecID == nil {
+		return nil
+	}
+
+	specs, err := s.taskRepo.ListUnfinishedParentSpecs(task.ID)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	task.WaitingOnSpecs = specs
+	return nil
+}
+
 // ListTasksInput contains the input for listing tasks.
 type ListTasksInput struct {
-	Status  *domain.TaskStatus
-	Page    int
-	PerPage int
+	Status *domain.TaskStatus
+	// ClaimedBy keeps the tasks claimed by an agent.
+	ClaimedBy *string
+	// Overdue keeps the unfinished tasks past their due date.
+	Overdue bool
+	// Quarantined keeps the tasks quarantined after too many failed attempts.
+	Quarantined bool
+	Page        int
+	PerPage     int
 }
 
 // List retrieves tasks with pagination.
 func (s *TaskService) List(input ListTasksInput) ([]*domain.Task, int, error) {
-	tasks, total, err := s.taskRepo.List(input.Status, input.Page, input.PerPage)
+	filter := sqlite.TaskFilter{Status: input.Status, ClaimedBy: input.ClaimedBy, Quarantined: input.Quarantined}
+	if input.Overdue {
+		now := time.Now()
+		filter.OverdueAt = &now
+	}
+
+	tasks, total, err := s.taskRepo.List(filter, input.Page, input.PerPage)
 	if err != nil {
 		return nil, 0, domain.NewInternalError(err)
 	}
 	return tasks, total, nil
 }
 
-// ListReady retrieves ready tasks.
-func (s *TaskService) ListReady(page, perPage int) ([]*domain.Task, int, error) {
-	tasks, total, err := s.taskRepo.ListReady(page, perPage)
+// ListReady retrieves ready tasks, leaving out the tasks described by opts.
+func (s *TaskService) ListReady(page, perPage int, opts sqlite.ReadyOptions) ([]*domain.Task, int, error) {
+	tasks, total, err := s.taskRepo.ListReady(page, perPage, opts)
 	if err != nil {
 		return nil, 0, domain.NewInternalError(err)
 	}
 	return tasks, total, nil
 }
 
+// ListChildren retrieves the direct subtasks of a task.
+func (s *TaskService) ListChildren(id string) ([]*domain.Task, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+
+	children, err := s.taskRepo.ListChildren(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return children, nil
+}
+
+// Tree retrieves a task together with all of its subtasks, recursively.
+func (s *TaskService) Tree(id string) (*domain.TaskTree, error) {
+	task, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := s.taskRepo.ListDescendants(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return domain.BuildTaskTree(task, descendants), nil
+}
+
 // UpdateTaskInput contains the input for updating a task.
 type UpdateTaskInput struct {
 	Title       *string
 	Description *string
 	Priority    *int
 	ParentID    *string
+	// Estimate sets the expected effort in minutes; zero clears it.
+	Estimate *int
+	// DueAt sets the due date; the zero time clears it.
+	DueAt *time.Time
+	// DeferUntil sets the time the task is deferred until; the zero time
+	// clears it.
+	DeferUntil *time.Time
+	// DeferEvent sets the event the task is deferred until; an empty string
+	// clears it.
+	DeferEvent *string
+	// Assignee sets the agent or @group that may claim the task; an empty
+	// string clears it.
+	Assignee *string
 }
 
 // Update updates a task.
@@ -177,6 +296,86 @@ func (s *TaskService) Update(id string, input UpdateTaskInput, agentID string) (
 		task.Priority = *input.Priority
 	}
 
+	if input.Estimate != nil {
+		estimate := positiveOrNil(input.Estimate)
+		if !equalIntPtr(estimate, task.Estimate) {
+			s.auditRepo.Log(&domain.AuditEntry{
+				TaskID:    id,
+				Action:    "update",
+				Field:     strPtr("estimate"),
+				OldValue:  intPtrToStr(task.Estimate),
+				NewValue:  intPtrToStr(estimate),
+				ChangedAt: now,
+				ChangedBy: agentID,
+			})
+			task.Estimate = estimate
+		}
+	}
+
+	if input.DueAt != nil {
+		dueAt := timeOrNil(input.DueAt)
+		if !equalTimePtr(dueAt, task.DueAt) {
+			s.auditRepo.Log(&domain.AuditEntry{
+				TaskID:    id,
+				Action:    "update",
+				Field:     strPtr("due_at"),
+				OldValue:  formatTimePtr(task.DueAt),
+				NewValue:  formatTimePtr(dueAt),
+				ChangedAt: now,
+				ChangedBy: agentID,
+			})
+			task.DueAt = dueAt
+		}
+	}
+
+	if input.DeferUntil != nil {
+		deferUntil := timeOrNil(input.DeferUntil)
+		if !equalTimePtr(deferUntil, task.DeferUntil) {
+			s.auditRepo.Log(&domain.AuditEntry{
+				TaskID:    id,
+				Action:    "update",
+				Field:     strPtr("defer_until"),
+				OldValue:  formatTimePtr(task.DeferUntil),
+				NewValue:  formatTimePtr(deferUntil),
+				ChangedAt: now,
+				ChangedBy: agentID,
+			})
+			task.DeferUntil = deferUntil
+		}
+	}
+
+	if input.DeferEvent != nil {
+		deferEvent := nonEmptyOrNil(input.DeferEvent)
+		if !equalStrPtr(deferEvent, task.DeferEvent) {
+			s.auditRepo.Log(&domain.AuditEntry{
+				TaskID:    id,
+				Action:    "update",
+				Field:     strPtr("defer_event"),
+				OldValue:  task.DeferEvent,
+				NewValue:  deferEvent,
+				ChangedAt: now,
+				ChangedBy: agentID,
+			})
+			task.DeferEvent = deferEvent
+		}
+	}
+
+	if input.Assignee != nil {
+		assignee := nonEmptyOrNil(input.Assignee)
+		if !equalStrPtr(assignee, task.Assignee) {
+			s.auditRepo.Log(&domain.AuditEntry{
+				TaskID:    id,
+				Action:    "update",
+				Field:     strPtr("assignee"),
+				OldValue:  task.Assignee,
+				NewValue:  assignee,
+				ChangedAt: now,
+				ChangedBy: agentID,
+			})
+			task.Assignee = assignee
+		}
+	}
+
 	task.UpdatedAt = now
 
 	if err := s.taskRepo.Update(task); err != nil {
@@ -186,7 +385,7 @@ func (s *TaskService) Update(id string, input UpdateTaskInput, agentID string) (
 	return task, nil
 }
 
-// Delete deletes a task.
+// Delete moves a task and its subtasks to the trash.
 func (s *TaskService) Delete(id string, agentID string) error {
 	// Check if task exists
 	_, err := s.taskRepo.GetByID(id)
@@ -206,7 +405,7 @@ func (s *TaskService) Delete(id string, agentID string) error {
 		ChangedBy: agentID,
 	})
 
-	if err := s.taskRepo.Delete(id); err != nil {
+	if err := s.taskRepo.Delete(id, now); err != nil {
 		return domain.NewInternalError(err)
 	}
 
@@ -220,3 +419,73 @@ func strPtr(s string) *string {
 func intToStr(i int) string {
 	return string(rune('0' + i))
 }
+
+// positiveOrNil returns nil unless i points to a positive value.
+func positiveOrNil(i *int) *int {
+	if i == nil || *i <= 0 {
+		return nil
+	}
+	v := *i
+	return &v
+}
+
+// equalIntPtr checks if two optional integers are both unset or equal.
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// intPtrToStr formats an optional integer for the audit log.
+func intPtrToStr(i *int) *string {
+	if i == nil {
+		return nil
+	}
+	s := strconv.Itoa(*i)
+	return &s
+}
+
+// timeOrNil returns nil unless t points to a non-zero time, which it returns
+// in UTC so that stored times compare in order.
+func timeOrNil(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
+
+// equalTimePtr checks if two optional times are both unset or equal.
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// nonEmptyOrNil returns nil unless s points to a non-empty string.
+func nonEmptyOrNil(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+// equalStrPtr checks if two optional strings are both unset or equal.
+func equalStrPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// formatTimePtr formats an optional time for the audit log.
+func formatTimePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
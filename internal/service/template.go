@@ -0,0 +1,98 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// TemplateService creates specs from templates.
+type TemplateService struct {
+	db      *sql.DB
+	manager *store.Manager
+	project string
+}
+
+// NewTemplateService creates a new TemplateService for project, whose
+// database is db.
+func NewTemplateService(db *sql.DB, manager *store.Manager, project string) *TemplateService {
+	return &TemplateService{
+		db:      db,
+		manager: manager,
+		project: project,
+	}
+}
+
+// Instantiate renders a template with vars and creates the spec, its tasks
+// and their dependencies in a single transaction: either all of them are
+// created or none is.
+func (s *TemplateService) Instantiate(tmpl *domain.SpecTemplate, vars map[string]string, agentID string) (*domain.SpecInstance, error) {
+	rendered, missing := tmpl.Render(vars)
+	if missing != nil {
+		errors := make([]string, len(missing))
+		for i, name := range missing {
+			errors[i] = "missing template variable: " + name
+		}
+		return nil, domain.NewValidationError(errors)
+	}
+	if errors := rendered.Validate(); len(errors) > 0 {
+		return nil, domain.NewValidationError(errors)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	defer tx.Rollback()
+
+	taskRepo := sqlite.NewTaskRepository(tx)
+	auditRepo := sqlite.NewAuditRepository(tx)
+	specSvc := NewSpecService(sqlite.NewSpecRepository(tx), auditRepo)
+	taskSvc := NewTaskService(taskRepo, auditRepo)
+	depSvc := NewDependencyService(sqlite.NewDependencyRepository(tx), taskRepo, auditRepo, s.manager, s.project)
+
+	spec, err := specSvc.Create(CreateSpecInput{
+		Title:       rendered.Title,
+		Description: rendered.Description,
+	}, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &domain.SpecInstance{Tasks: make([]*domain.Task, len(rendered.Tasks))}
+	ids := make(map[string]string, len(rendered.Tasks))
+	for i, t := range rendered.Tasks {
+		task, err := taskSvc.Create(CreateTaskInput{
+			SpecID:      &spec.ID,
+			Title:       t.Title,
+			Description: t.Description,
+			Priority:    t.Priority,
+			Estimate:    t.Estimate,
+		}, agentID)
+		if err != nil {
+			return nil, err
+		}
+		instance.Tasks[i] = task
+		ids[t.Key] = task.ID
+	}
+
+	for _, t := range rendered.Tasks {
+		for _, dep := range t.DependsOn {
+			if err := depSvc.Add(ids[t.Key], ids[dep], agentID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Reload the spec for its task counts
+	if instance.Spec, err = specSvc.Get(spec.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return instance, nil
+}
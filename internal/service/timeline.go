@@ -0,0 +1,76 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// TimelineService tracks the time tasks spend in each status.
+type TimelineService struct {
+	taskRepo     *sqlite.TaskRepository
+	auditRepo    *sqlite.AuditRepository
+	workflowRepo *sqlite.WorkflowRepository
+}
+
+// NewTimelineService creates a new TimelineService.
+func NewTimelineService(taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository, workflowRepo *sqlite.WorkflowRepository) *TimelineService {
+	return &TimelineService{
+		taskRepo:     taskRepo,
+		auditRepo:    auditRepo,
+		workflowRepo: workflowRepo,
+	}
+}
+
+// Get builds the timeline of a task from the status changes in the audit log.
+func (s *TimelineService) Get(id string) (*domain.TaskTimeline, error) {
+	task, err := s.taskRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(id)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	changes, err := s.auditRepo.ListStatusChanges(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return domain.BuildTaskTimeline(task, changes, workflow.IsDone, time.Now().UTC()), nil
+}
+
+// Report aggregates the timelines of all tasks per agent and per spec.
+func (s *TimelineService) Report() (*domain.TimeReport, error) {
+	tasks, err := s.taskRepo.ListAll()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	changes, err := s.auditRepo.ListAllStatusChanges()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	byTask := make(map[string][]*domain.AuditEntry)
+	for _, change := range changes {
+		byTask[change.TaskID] = append(byTask[change.TaskID], change)
+	}
+
+	now := time.Now().UTC()
+	timelines := make(map[string]*domain.TaskTimeline, len(tasks))
+	for _, task := range tasks {
+		timelines[task.ID] = domain.BuildTaskTimeline(task, byTask[task.ID], workflow.IsDone, now)
+	}
+
+	return domain.BuildTimeReport(tasks, timelines, workflow.IsDone), nil
+}
@@ -10,23 +10,48 @@ import (
 
 // TransitionService handles task status transitions.
 type TransitionService struct {
-	taskRepo  *sqlite.TaskRepository
-	auditRepo *sqlite.AuditRepository
+	taskRepo     *sqlite.TaskRepository
+	auditRepo    *sqlite.AuditRepository
+	workflowRepo *sqlite.WorkflowRepository
+	commentRepo  *sqlite.CommentRepository
+	settingsRepo *sqlite.SettingsRepository
+	agentRepo    *sqlite.AgentRepository
 }
 
 // NewTransitionService creates a new TransitionService.
-func NewTransitionService(taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository) *TransitionService {
+func NewTransitionService(taskRepo *sqlite.TaskRepository, auditRepo *sqlite.AuditRepository, workflowRepo *sqlite.WorkflowRepository, commentRepo *sqlite.CommentRepository, settingsRepo *sqlite.SettingsRepository, agentRepo *sqlite.AgentRepository) *TransitionService {
 	return &TransitionService{
-		taskRepo:  taskRepo,
-		auditRepo: auditRepo,
+		taskRepo:     taskRepo,
+		auditRepo:    auditRepo,
+		workflowRepo: workflowRepo,
+		commentRepo:  commentRepo,
+		settingsRepo: settingsRepo,
+		agentRepo:    agentRepo,
 	}
 }
 
 // Claim claims a task for an agent (open -> in_progress).
+// The claim fails when the task is assigned to another agent or group, or
+// when the agent or the project already holds as many in-progress tasks as
+// its WIP limit allows.
 func (s *TransitionService) Claim(taskID, agentID string) (*domain.Task, error) {
 	now := time.Now().UTC()
 
-	task, err := s.taskRepo.AtomicClaim(taskID, agentID, now)
+	limits, err := s.wipLimits(agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claimable states need not be open, so note the one claimed from
+	before, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	task, err := s.taskRepo.AtomicClaim(taskID, agentID, now, limits)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domain.NewTaskNotFoundError(taskID)
@@ -35,16 +60,12 @@ func (s *TransitionService) Claim(taskID, agentID string) (*domain.Task, error)
 	}
 
 	// Check if claim succeeded (task is now in_progress and claimed by this agent)
-	if task.Status != domain.StatusInProgress || task.ClaimedBy == nil || *task.ClaimedBy != agentID {
-		// Task was not open, return appropriate error
-		if task.Status == domain.StatusInProgress && task.ClaimedBy != nil {
-			claimedAt := ""
-			if task.ClaimedAt != nil {
-				claimedAt = task.ClaimedAt.Format(time.RFC3339)
-			}
-			return nil, domain.NewAlreadyClaimedError(*task.ClaimedBy, claimedAt)
+	if !isClaimedBy(task, agentID) {
+		workflow, err := s.workflowRepo.Get()
+		if err != nil {
+			return nil, domain.NewInternalError(err)
 		}
-		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusInProgress)
+		return nil, s.claimError(task, agentID, limits, workflow.IsClaimable(task.Status))
 	}
 
 	// Log the claim
@@ -52,7 +73,7 @@ func (s *TransitionService) Claim(taskID, agentID string) (*domain.Task, error)
 		TaskID:    taskID,
 		Action:    "claim",
 		Field:     strPtr("status"),
-		OldValue:  strPtr(string(domain.StatusOpen)),
+		OldValue:  strPtr(string(before.Status)),
 		NewValue:  strPtr(string(domain.StatusInProgress)),
 		ChangedAt: now,
 		ChangedBy: agentID,
@@ -62,8 +83,16 @@ func (s *TransitionService) Claim(taskID, agentID string) (*domain.Task, error)
 }
 
 // Complete marks a task as done (in_progress -> done).
-// Only the claiming agent can complete the task.
+// When the project workflow has a review phase, the task moves to in_review
+// instead and is done once another agent approves it.
+// Only the claiming agent can complete the task, and unless the project
+// allows it, only once all of its subtasks are finished.
 func (s *TransitionService) Complete(taskID, agentID string) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -73,8 +102,12 @@ func (s *TransitionService) Complete(taskID, agentID string) (*domain.Task, erro
 	}
 
 	// Check if transition is valid
-	if task.Status != domain.StatusInProgress {
-		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusDone)
+	target := domain.StatusDone
+	if workflow.HasReview() {
+		target = domain.StatusInReview
+	}
+	if task.Status != domain.StatusInProgress || !workflow.CanTransition(task.Status, target) {
+		return nil, domain.NewInvalidTransitionError(task.Status, target)
 	}
 
 	// Check if agent owns the task
@@ -85,9 +118,13 @@ func (s *TransitionService) Complete(taskID, agentID string) (*domain.Task, erro
 		return nil, domain.NewNotOwnerError("unknown")
 	}
 
+	if err := s.checkChildrenDone(taskID, workflow); err != nil {
+		return nil, err
+	}
+
 	now := time.Now().UTC()
 	oldStatus := task.Status
-	task.Status = domain.StatusDone
+	task.Status = target
 	task.UpdatedAt = now
 
 	if err := s.taskRepo.Update(task); err != nil {
@@ -100,17 +137,152 @@ func (s *TransitionService) Complete(taskID, agentID string) (*domain.Task, erro
 		Action:    "done",
 		Field:     strPtr("status"),
 		OldValue:  strPtr(string(oldStatus)),
+		NewValue:  strPtr(string(task.Status)),
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+
+	if task.Status == domain.StatusDone {
+		if err := s.autoUnblock(taskID, agentID, now); err != nil {
+			return nil, err
+		}
+		if err := s.completeParents(task, workflow, agentID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// Approve approves a task under review (in_review -> done).
+// The task must be approved by an agent other than the one that completed it.
+func (s *TransitionService) Approve(taskID, agentID string) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Check if transition is valid
+	if task.Status != domain.StatusInReview || !workflow.CanTransition(task.Status, domain.StatusDone) {
+		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusDone)
+	}
+
+	if task.ClaimedBy != nil && *task.ClaimedBy == agentID {
+		return nil, domain.NewSelfReviewError(agentID)
+	}
+
+	if err := s.checkChildrenDone(taskID, workflow); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	task.Status = domain.StatusDone
+	task.UpdatedAt = now
+
+	if err := s.taskRepo.Update(task); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Log the approval
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    "approve",
+		Field:     strPtr("status"),
+		OldValue:  strPtr(string(domain.StatusInReview)),
 		NewValue:  strPtr(string(domain.StatusDone)),
 		ChangedAt: now,
 		ChangedBy: agentID,
 	})
 
+	if err := s.autoUnblock(taskID, agentID, now); err != nil {
+		return nil, err
+	}
+	if err := s.completeParents(task, workflow, agentID, now); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Reject rejects a task under review (in_review -> open).
+// The task is released so it can be claimed again, and the reason is
+// recorded as a comment. Like approval, rejection must come from an agent
+// other than the one that completed the task.
+func (s *TransitionService) Reject(taskID, agentID, reason string) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Check if transition is valid
+	if task.Status != domain.StatusInReview || !workflow.CanTransition(task.Status, domain.StatusOpen) {
+		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusOpen)
+	}
+
+	if task.ClaimedBy != nil && *task.ClaimedBy == agentID {
+		return nil, domain.NewSelfReviewError(agentID)
+	}
+
+	now := time.Now().UTC()
+	task.Status = domain.StatusOpen
+	task.ClaimedBy = nil
+	task.ClaimedAt = nil
+	task.UpdatedAt = now
+
+	if err := s.taskRepo.Update(task); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	comment := &domain.Comment{
+		TaskID:    taskID,
+		Body:      "Rejected: " + reason,
+		Author:    agentID,
+		CreatedAt: now,
+	}
+	if err := s.commentRepo.Add(comment); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Log the rejection
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    "reject",
+		Field:     strPtr("status"),
+		OldValue:  strPtr(string(domain.StatusInReview)),
+		NewValue:  strPtr(string(domain.StatusOpen)),
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+
 	return task, nil
 }
 
 // Release releases a task (in_progress -> open).
-// Only the claiming agent can release unless force is true.
+// Only the claiming agent can release unless force is true. Every release
+// counts as a failed attempt, and once the project's max_attempts is reached
+// the task is quarantined (moved to blocked) instead of reopened.
 func (s *TransitionService) Release(taskID, agentID string, force bool) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -120,7 +292,7 @@ func (s *TransitionService) Release(taskID, agentID string, force bool) (*domain
 	}
 
 	// Check if transition is valid
-	if task.Status != domain.StatusInProgress {
+	if task.Status != domain.StatusInProgress || !workflow.CanTransition(task.Status, domain.StatusOpen) {
 		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusOpen)
 	}
 
@@ -134,12 +306,18 @@ func (s *TransitionService) Release(taskID, agentID string, force bool) (*domain
 		}
 	}
 
+	settings, err := s.settingsRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
 	now := time.Now().UTC()
 	oldStatus := task.Status
 	task.Status = domain.StatusOpen
 	task.ClaimedBy = nil
 	task.ClaimedAt = nil
 	task.UpdatedAt = now
+	quarantined := task.RecordFailedAttempt(maxAttempts(workflow, settings, task.Status), now)
 
 	if err := s.taskRepo.Update(task); err != nil {
 		return nil, domain.NewInternalError(err)
@@ -155,12 +333,107 @@ func (s *TransitionService) Release(taskID, agentID string, force bool) (*domain
 		ChangedAt: now,
 		ChangedBy: agentID,
 	})
+	if quarantined {
+		s.logQuarantine(task, domain.StatusOpen, agentID, now)
+	}
+
+	return task, nil
+}
+
+// Handoff transfers an in-progress task to another agent without releasing
+// it, so no other agent can claim it in between. Only the claiming agent can
+// hand the task off, unless force is set by one of the project admins. The
+// handoff fails when the task is assigned to someone other than the receiving
+// agent, or when that agent already holds as many tasks as its WIP limit
+// allows.
+func (s *TransitionService) Handoff(taskID, agentID, to string, force bool) (*domain.Task, error) {
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	if task.Status != domain.StatusInProgress || task.ClaimedBy == nil {
+		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusInProgress)
+	}
+	from := *task.ClaimedBy
+	if from != agentID {
+		if !force {
+			return nil, domain.NewNotOwnerError(from)
+		}
+		settings, err := s.settingsRepo.Get()
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		if !settings.IsAdmin(agentID) {
+			return nil, domain.NewNotOwnerError(from)
+		}
+	}
+	if from == to {
+		return nil, domain.NewValidationError([]string{"task is already claimed by " + to})
+	}
+	if err := s.checkAssignee(task, to); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.wipLimits(to)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	ok, err := s.taskRepo.AtomicHandoff(taskID, from, to, now, limits.Agent)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	if !ok {
+		// Either the receiving agent is at its limit or the task changed
+		// since it was read
+		if err := s.checkWIPLimits(to, domain.WIPLimits{Agent: limits.Agent}); err != nil {
+			return nil, err
+		}
+		current, err := s.taskRepo.GetByID(taskID)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		if err := s.checkAssignee(current, to); err != nil {
+			return nil, err
+		}
+		if current.Status == domain.StatusInProgress && current.ClaimedBy != nil {
+			return nil, domain.NewNotOwnerError(*current.ClaimedBy)
+		}
+		return nil, domain.NewInvalidTransitionError(current.Status, domain.StatusInProgress)
+	}
+
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    domain.ActionHandoff,
+		Field:     strPtr("claimed_by"),
+		OldValue:  strPtr(from),
+		NewValue:  strPtr(to),
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
 
+	task, err = s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
 	return task, nil
 }
 
 // Block blocks a task (any -> blocked).
-func (s *TransitionService) Block(taskID, agentID string) (*domain.Task, error) {
+// reason explains the block, and blockedBy optionally references the task,
+// spec or URL the task is waiting on. With autoUnblock, a task blocked by
+// another task is unblocked automatically once that task is done.
+func (s *TransitionService) Block(taskID, agentID string, reason, blockedBy *string, autoUnblock bool) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -169,14 +442,40 @@ func (s *TransitionService) Block(taskID, agentID string) (*domain.Task, error)
 		return nil, domain.NewInternalError(err)
 	}
 
-	// Already blocked is a no-op
-	if task.Status == domain.StatusBlocked {
+	// Check if transition is valid; a blocked task may change its block details
+	if task.Status != domain.StatusBlocked && !workflow.CanTransition(task.Status, domain.StatusBlocked) {
+		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusBlocked)
+	}
+
+	if blockedBy != nil && domain.ClassifyBlocker(*blockedBy) == domain.BlockerTask {
+		if *blockedBy == taskID {
+			return nil, domain.NewValidationError([]string{"a task cannot be blocked by itself"})
+		}
+		blocker, err := s.taskRepo.GetByID(*blockedBy)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, domain.NewTaskNotFoundError(*blockedBy)
+			}
+			return nil, domain.NewInternalError(err)
+		}
+		if autoUnblock && blocker.Status == domain.StatusDone {
+			return nil, domain.NewValidationError([]string{"blocking task " + *blockedBy + " is already done"})
+		}
+	} else if autoUnblock {
+		return nil, domain.NewValidationError([]string{"auto_unblock requires blocked_by to reference a task"})
+	}
+
+	// Already blocked without new details is a no-op
+	if task.Status == domain.StatusBlocked && reason == nil && blockedBy == nil {
 		return task, nil
 	}
 
 	now := time.Now().UTC()
 	oldStatus := task.Status
 	task.Status = domain.StatusBlocked
+	task.BlockReason = reason
+	task.BlockedBy = blockedBy
+	task.AutoUnblock = autoUnblock
 	task.UpdatedAt = now
 
 	if err := s.taskRepo.Update(task); err != nil {
@@ -184,21 +483,163 @@ func (s *TransitionService) Block(taskID, agentID string) (*domain.Task, error)
 	}
 
 	// Log the block
+	if oldStatus != domain.StatusBlocked {
+		s.auditRepo.Log(&domain.AuditEntry{
+			TaskID:    taskID,
+			Action:    "block",
+			Field:     strPtr("status"),
+			OldValue:  strPtr(string(oldStatus)),
+			NewValue:  strPtr(string(domain.StatusBlocked)),
+			ChangedAt: now,
+			ChangedBy: agentID,
+		})
+	}
+	if reason != nil {
+		s.auditRepo.Log(&domain.AuditEntry{
+			TaskID:    taskID,
+			Action:    "block",
+			Field:     strPtr("block_reason"),
+			NewValue:  reason,
+			ChangedAt: now,
+			ChangedBy: agentID,
+		})
+	}
+	if blockedBy != nil {
+		s.auditRepo.Log(&domain.AuditEntry{
+			TaskID:    taskID,
+			Action:    "block",
+			Field:     strPtr("blocked_by"),
+			NewValue:  blockedBy,
+			ChangedAt: now,
+			ChangedBy: agentID,
+		})
+	}
+
+	return task, nil
+}
+
+// Unblock unblocks a task (blocked -> open).
+func (s *TransitionService) Unblock(taskID, agentID string) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Check if transition is valid
+	if task.Status != domain.StatusBlocked || !workflow.CanTransition(task.Status, domain.StatusOpen) {
+		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusOpen)
+	}
+
+	now := time.Now().UTC()
+	task.Status = domain.StatusOpen
+	task.ClearBlock()
+	task.UpdatedAt = now
+
+	if err := s.taskRepo.Update(task); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Log the unblock
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    "unblock",
+		Field:     strPtr("status"),
+		OldValue:  strPtr(string(domain.StatusBlocked)),
+		NewValue:  strPtr(string(domain.StatusOpen)),
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+
+	return task, nil
+}
+
+// Cancel cancels a task that will not be done (any -> cancelled).
+// Done tasks cannot be cancelled. The claim and any block details are
+// cleared, and the optional reason is recorded as a comment. Whether
+// dependents of the cancelled task become ready depends on the project's
+// cancel policy.
+func (s *TransitionService) Cancel(taskID, agentID string, reason *string) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Check if transition is valid
+	if task.Status == domain.StatusCancelled || workflow.IsDone(task.Status) ||
+		!workflow.CanTransition(task.Status, domain.StatusCancelled) {
+		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusCancelled)
+	}
+
+	now := time.Now().UTC()
+	oldStatus := task.Status
+	task.Status = domain.StatusCancelled
+	task.ClaimedBy = nil
+	task.ClaimedAt = nil
+	task.ClearBlock()
+	task.UpdatedAt = now
+
+	if err := s.taskRepo.Update(task); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if reason != nil {
+		comment := &domain.Comment{
+			TaskID:    taskID,
+			Body:      "Cancelled: " + *reason,
+			Author:    agentID,
+			CreatedAt: now,
+		}
+		if err := s.commentRepo.Add(comment); err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+	}
+
+	// Log the cancellation
 	s.auditRepo.Log(&domain.AuditEntry{
 		TaskID:    taskID,
-		Action:    "block",
+		Action:    "cancel",
 		Field:     strPtr("status"),
 		OldValue:  strPtr(string(oldStatus)),
-		NewValue:  strPtr(string(domain.StatusBlocked)),
+		NewValue:  strPtr(string(domain.StatusCancelled)),
 		ChangedAt: now,
 		ChangedBy: agentID,
 	})
 
+	if workflow.CancelPolicy() == domain.CancelPolicySatisfy {
+		if err := s.autoUnblock(taskID, agentID, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.completeParents(task, workflow, agentID, now); err != nil {
+		return nil, err
+	}
+
 	return task, nil
 }
 
-// Unblock unblocks a task (blocked -> open).
-func (s *TransitionService) Unblock(taskID, agentID string) (*domain.Task, error) {
+// Reopen reopens a cancelled task (cancelled -> open).
+func (s *TransitionService) Reopen(taskID, agentID string) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -208,7 +649,7 @@ func (s *TransitionService) Unblock(taskID, agentID string) (*domain.Task, error
 	}
 
 	// Check if transition is valid
-	if task.Status != domain.StatusBlocked {
+	if task.Status != domain.StatusCancelled || !workflow.CanTransition(task.Status, domain.StatusOpen) {
 		return nil, domain.NewInvalidTransitionError(task.Status, domain.StatusOpen)
 	}
 
@@ -220,12 +661,12 @@ func (s *TransitionService) Unblock(taskID, agentID string) (*domain.Task, error
 		return nil, domain.NewInternalError(err)
 	}
 
-	// Log the unblock
+	// Log the reopen
 	s.auditRepo.Log(&domain.AuditEntry{
 		TaskID:    taskID,
-		Action:    "unblock",
+		Action:    "reopen",
 		Field:     strPtr("status"),
-		OldValue:  strPtr(string(domain.StatusBlocked)),
+		OldValue:  strPtr(string(domain.StatusCancelled)),
 		NewValue:  strPtr(string(domain.StatusOpen)),
 		ChangedAt: now,
 		ChangedBy: agentID,
@@ -233,3 +674,437 @@ func (s *TransitionService) Unblock(taskID, agentID string) (*domain.Task, error
 
 	return task, nil
 }
+
+// Move moves a task to another state of the project workflow.
+// The move must be an allowed workflow transition, and with review on no task
+// moves to done this way. Tasks in review leave it for a done or claimable
+// state only through Approve or Reject. Moving to in_progress claims the task for
+// the agent atomically, with the same assignee and WIP limit checks as Claim,
+// and moving to a claimable state releases it, counting a failed attempt as
+// Release does.
+// Only the claiming agent can move a task that is in progress.
+func (s *TransitionService) Move(taskID, agentID string, to domain.TaskStatus) (*domain.Task, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewTaskNotFoundError(taskID)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	if !workflow.HasState(to) {
+		return nil, domain.NewValidationError([]string{"unknown workflow state: " + string(to)})
+	}
+
+	if !workflow.CanTransition(task.Status, to) {
+		return nil, domain.NewInvalidTransitionError(task.Status, to)
+	}
+
+	// With review on, tasks only become done by approval
+	if workflow.HasReview() && to == domain.StatusDone && task.Status != domain.StatusInReview {
+		return nil, domain.NewInvalidTransitionError(task.Status, to)
+	}
+
+	// Reviews end by approval, or by rejection with a reason
+	if task.Status == domain.StatusInReview {
+		if workflow.IsDone(to) {
+			return nil, domain.NewReviewTransitionError(to, "approve")
+		}
+		if workflow.IsClaimable(to) {
+			return nil, domain.NewReviewTransitionError(to, "reject")
+		}
+	}
+
+	// Check if agent owns the task
+	if task.Status == domain.StatusInProgress && task.ClaimedBy != nil && *task.ClaimedBy != agentID {
+		return nil, domain.NewNotOwnerError(*task.ClaimedBy)
+	}
+
+	// Reviews must come from another agent
+	if task.Status == domain.StatusInReview && task.ClaimedBy != nil && *task.ClaimedBy == agentID {
+		return nil, domain.NewSelfReviewError(agentID)
+	}
+
+	if workflow.IsDone(to) {
+		if err := s.checkChildrenDone(taskID, workflow); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	oldStatus := task.Status
+	quarantined := false
+
+	if to == domain.StatusInProgress {
+		// Claim atomically, so racing agents, the assignee and the WIP
+		// limits are handled as for Claim
+		limits, err := s.wipLimits(agentID)
+		if err != nil {
+			return nil, err
+		}
+		claimed, err := s.taskRepo.AtomicClaimFrom(taskID, oldStatus, agentID, now, limits)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, domain.NewTaskNotFoundError(taskID)
+			}
+			return nil, domain.NewInternalError(err)
+		}
+		if !isClaimedBy(claimed, agentID) {
+			return nil, s.claimError(claimed, agentID, limits, claimed.Status == oldStatus)
+		}
+		task = claimed
+		if oldStatus == domain.StatusBlocked {
+			task.ClearBlock()
+			if err := s.taskRepo.Update(task); err != nil {
+				return nil, domain.NewInternalError(err)
+			}
+		}
+	} else {
+		task.Status = to
+		task.UpdatedAt = now
+		if workflow.IsClaimable(to) {
+			task.ClaimedBy = nil
+			task.ClaimedAt = nil
+		}
+		if oldStatus == domain.StatusBlocked {
+			task.ClearBlock()
+		}
+		if oldStatus == domain.StatusInProgress && workflow.IsClaimable(to) {
+			settings, err := s.settingsRepo.Get()
+			if err != nil {
+				return nil, domain.NewInternalError(err)
+			}
+			quarantined = task.RecordFailedAttempt(maxAttempts(workflow, settings, task.Status), now)
+		}
+
+		if err := s.taskRepo.Update(task); err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+	}
+
+	// Log the transition
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    taskID,
+		Action:    "transition",
+		Field:     strPtr("status"),
+		OldValue:  strPtr(string(oldStatus)),
+		NewValue:  strPtr(string(to)),
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+	if quarantined {
+		s.logQuarantine(task, to, agentID, now)
+	}
+
+	if workflow.IsDone(to) && !workflow.IsDone(oldStatus) {
+		if err := s.autoUnblock(taskID, agentID, now); err != nil {
+			return nil, err
+		}
+	}
+	if isFinished(workflow, to) && !isFinished(workflow, oldStatus) {
+		if err := s.completeParents(task, workflow, agentID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// autoUnblock reopens the tasks that were blocked by a now-completed task
+// and asked to be unblocked automatically.
+func (s *TransitionService) autoUnblock(blockerID, agentID string, now time.Time) error {
+	blocked, err := s.taskRepo.ListAutoUnblock(blockerID)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	for _, task := range blocked {
+		task.Status = domain.StatusOpen
+		task.ClearBlock()
+		task.UpdatedAt = now
+
+		if err := s.taskRepo.Update(task); err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		s.auditRepo.Log(&domain.AuditEntry{
+			TaskID:    task.ID,
+			Action:    "unblock",
+			Field:     strPtr("status"),
+			OldValue:  strPtr(string(domain.StatusBlocked)),
+			NewValue:  strPtr(string(domain.StatusOpen)),
+			ChangedAt: now,
+			ChangedBy: agentID,
+		})
+	}
+
+	return nil
+}
+
+// logQuarantine records that a released task was quarantined, moving it from
+// the status it was released to into blocked.
+func (s *TransitionService) logQuarantine(task *domain.Task, from domain.TaskStatus, agentID string, now time.Time) {
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    task.ID,
+		Action:    domain.ActionQuarantine,
+		Field:     strPtr("status"),
+		OldValue:  strPtr(string(from)),
+		NewValue:  strPtr(string(domain.StatusBlocked)),
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    task.ID,
+		Action:    domain.ActionQuarantine,
+		Field:     strPtr("block_reason"),
+		NewValue:  task.BlockReason,
+		ChangedAt: now,
+		ChangedBy: agentID,
+	})
+}
+
+// maxAttempts returns the failed attempts after which a task released to the
+// status is quarantined, or zero when the workflow does not let it be blocked.
+func maxAttempts(workflow *domain.Workflow, settings *domain.ProjectSettings, status domain.TaskStatus) int {
+	if !workflow.CanTransition(status, domain.StatusBlocked) {
+		return 0
+	}
+	return settings.MaxAttempts
+}
+
+// isFinished checks if a task in the status needs no more work: it is either
+// in a done state or cancelled.
+func isFinished(workflow *domain.Workflow, status domain.TaskStatus) bool {
+	return workflow.IsDone(status) || status == domain.StatusCancelled
+}
+
+// agent returns a known agent, or nil when the agent was never seen.
+func (s *TransitionService) agent(agentID string) (*domain.Agent, error) {
+	agent, err := s.agentRepo.GetByID(agentID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return agent, nil
+}
+
+// wipLimits returns the WIP limits that apply to an agent.
+func (s *TransitionService) wipLimits(agentID string) (domain.WIPLimits, error) {
+	settings, err := s.settingsRepo.Get()
+	if err != nil {
+		return domain.WIPLimits{}, domain.NewInternalError(err)
+	}
+
+	agent, err := s.agent(agentID)
+	if err != nil {
+		return domain.WIPLimits{}, err
+	}
+	var agentLimit *int
+	if agent != nil {
+		agentLimit = agent.WIPLimit
+	}
+
+	return domain.WIPLimitsFor(settings, agentLimit), nil
+}
+
+// isClaimedBy checks if the task is in progress under the agent.
+func isClaimedBy(task *domain.Task, agentID string) bool {
+	return task.Status == domain.StatusInProgress && task.ClaimedBy != nil && *task.ClaimedBy == agentID
+}
+
+// claimError explains why an atomic claim by the agent failed, given the task
+// as it is after the attempt. Only when the claim could have started from
+// the task's status can the assignee or a WIP limit be the cause.
+func (s *TransitionService) claimError(task *domain.Task, agentID string, limits domain.WIPLimits, startable bool) error {
+	if startable {
+		if err := s.checkAssignee(task, agentID); err != nil {
+			return err
+		}
+		if err := s.checkWIPLimits(agentID, limits); err != nil {
+			return err
+		}
+	}
+	if task.Status == domain.StatusInProgress && task.ClaimedBy != nil {
+		claimedAt := ""
+		if task.ClaimedAt != nil {
+			claimedAt = task.ClaimedAt.Format(time.RFC3339)
+		}
+		return domain.NewAlreadyClaimedError(*task.ClaimedBy, claimedAt)
+	}
+	return domain.NewInvalidTransitionError(task.Status, domain.StatusInProgress)
+}
+
+// checkAssignee returns a not assignee error when the task is assigned to
+// another agent or to a group the agent is not registered in.
+func (s *TransitionService) checkAssignee(task *domain.Task, agentID string) error {
+	if task.Assignee == nil {
+		return nil
+	}
+	agent, err := s.agent(agentID)
+	if err != nil {
+		return err
+	}
+	var capabilities []string
+	if agent != nil {
+		capabilities = agent.Capabilities
+	}
+	if !task.IsAssignedTo(agentID, capabilities) {
+		return domain.NewNotAssigneeError(*task.Assignee)
+	}
+	return nil
+}
+
+// checkWIPLimits returns a WIP limit exceeded error when the agent or the
+// project already holds as many in-progress tasks as its limit allows.
+func (s *TransitionService) checkWIPLimits(agentID string, limits domain.WIPLimits) error {
+	if limits.Agent > 0 {
+		held, err := s.taskRepo.CountInProgress(&agentID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+		if held >= limits.Agent {
+			return domain.NewWIPLimitExceededError("agent", limits.Agent, held)
+		}
+	}
+	if limits.Project > 0 {
+		held, err := s.taskRepo.CountInProgress(nil)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+		if held >= limits.Project {
+			return domain.NewWIPLimitExceededError("project", limits.Project, held)
+		}
+	}
+	return nil
+}
+
+// checkChildrenDone returns an error when the project requires subtasks to be
+// finished first and the task still has unfinished subtasks.
+func (s *TransitionService) checkChildrenDone(taskID string, workflow *domain.Workflow) error {
+	settings, err := s.settingsRepo.Get()
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if !settings.RequireChildrenDone {
+		return nil
+	}
+
+	children, err := s.taskRepo.ListChildren(taskID)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	var unfinished []string
+	for _, child := range children {
+		if !isFinished(workflow, child.Status) {
+			unfinished = append(unfinished, child.ID)
+		}
+	}
+	if len(unfinished) > 0 {
+		return domain.NewChildrenNotDoneError(taskID, unfinished)
+	}
+	return nil
+}
+
+// completeParents completes the parent of a finished task once all of the
+// parent's subtasks are finished and at least one of them is done, and
+// continues up the hierarchy. Cancelled subtasks never count as done, even
+// when the cancel policy lets them satisfy dependencies. When the workflow has
+// a review phase the parent moves to in_review instead and the walk stops
+// there. Parents the workflow cannot take to that status, such as blocked
+// ones, are left alone. It does nothing unless the project auto-completes
+// parents.
+func (s *TransitionService) completeParents(task *domain.Task, workflow *domain.Workflow, agentID string, now time.Time) error {
+	settings, err := s.settingsRepo.Get()
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if !settings.AutoCompleteParents {
+		return nil
+	}
+
+	for task.ParentID != nil {
+		parent, err := s.taskRepo.GetByID(*task.ParentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return domain.NewInternalError(err)
+		}
+		target := domain.StatusDone
+		if workflow.HasReview() {
+			target = domain.StatusInReview
+		}
+		if isFinished(workflow, parent.Status) || !canAutoComplete(workflow, parent.Status, target) {
+			return nil
+		}
+
+		children, err := s.taskRepo.ListChildren(parent.ID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+		anyDone := false
+		for _, child := range children {
+			if !isFinished(workflow, child.Status) {
+				return nil
+			}
+			if workflow.IsDone(child.Status) && child.Status != domain.StatusCancelled {
+				anyDone = true
+			}
+		}
+		if !anyDone {
+			return nil
+		}
+
+		oldStatus := parent.Status
+		parent.Status = target
+		parent.ClaimedBy = nil
+		parent.ClaimedAt = nil
+		parent.ClearBlock()
+		parent.UpdatedAt = now
+
+		if err := s.taskRepo.Update(parent); err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		s.auditRepo.Log(&domain.AuditEntry{
+			TaskID:    parent.ID,
+			Action:    "auto_complete",
+			Field:     strPtr("status"),
+			OldValue:  strPtr(string(oldStatus)),
+			NewValue:  strPtr(string(target)),
+			ChangedAt: now,
+			ChangedBy: agentID,
+		})
+
+		if target != domain.StatusDone {
+			return nil
+		}
+		if err := s.autoUnblock(parent.ID, agentID, now); err != nil {
+			return err
+		}
+
+		task = parent
+	}
+
+	return nil
+}
+
+// canAutoComplete reports whether the workflow lets a parent in status reach
+// target, either directly or, when status is claimable, by way of in_progress
+// as it would if an agent claimed and finished it.
+func canAutoComplete(workflow *domain.Workflow, status, target domain.TaskStatus) bool {
+	if workflow.CanTransition(status, target) {
+		return true
+	}
+	return workflow.IsClaimable(status) &&
+		workflow.CanTransition(status, domain.StatusInProgress) &&
+		workflow.CanTransition(domain.StatusInProgress, target)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// TrashService handles restoring and purging deleted tasks and specs.
+type TrashService struct {
+	taskRepo  *sqlite.TaskRepository
+	specRepo  *sqlite.SpecRepository
+	auditRepo *sqlite.AuditRepository
+}
+
+// NewTrashService creates a new TrashService.
+func NewTrashService(taskRepo *sqlite.TaskRepository, specRepo *sqlite.SpecRepository, auditRepo *sqlite.AuditRepository) *TrashService {
+	return &TrashService{
+		taskRepo:  taskRepo,
+		specRepo:  specRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// List returns the deleted tasks and specs.
+func (s *TrashService) List() (*domain.Trash, error) {
+	tasks, err := s.taskRepo.ListDeleted()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	specs, err := s.specRepo.ListDeleted()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return &domain.Trash{Tasks: tasks, Specs: specs}, nil
+}
+
+// RestoreTask moves a task out of the trash together with the subtasks that
+// were deleted along with it. The parent task must not be in the trash.
+func (s *TrashService) RestoreTask(id, agentID string) (*domain.Task, error) {
+	task, err := s.taskRepo.GetDeleted(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.notInTrash(id)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	if task.ParentID != nil {
+		if _, err := s.taskRepo.GetByID(*task.ParentID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, domain.NewValidationError([]string{"parent task " + *task.ParentID + " is in the trash; restore it first"})
+			}
+			return nil, domain.NewInternalError(err)
+		}
+	}
+
+	if err := s.taskRepo.Restore(id); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	s.auditRepo.Log(&domain.AuditEntry{
+		TaskID:    id,
+		Action:    "restore",
+		ChangedAt: time.Now().UTC(),
+		ChangedBy: agentID,
+	})
+
+	restored, err := s.taskRepo.GetByID(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return restored, nil
+}
+
+// RestoreSpec moves a spec out of the trash.
+func (s *TrashService) RestoreSpec(id string) (*domain.Spec, error) {
+	if err := s.specRepo.Restore(id); err != nil {
+		if err == sql.ErrNoRows {
+			if _, getErr := s.specRepo.GetByID(id); getErr == nil {
+				return nil, domain.NewValidationError([]string{"spec " + id + " is not in the trash"})
+			}
+			return nil, domain.NewSpecNotFoundError(id)
+		}
+		return nil, domain.NewInternalError(err)
+	}
+
+	spec, err := s.specRepo.GetByID(id)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return spec, nil
+}
+
+// Purge permanently deletes a task or spec from the trash.
+// Purging a task also purges its subtasks, so a task with a subtask outside
+// the trash cannot be purged.
+func (s *TrashService) Purge(id string) error {
+	err := s.taskRepo.Purge(id)
+	if err == sql.ErrNoRows {
+		err = s.specRepo.Purge(id)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if _, getErr := s.taskRepo.GetDeleted(id); getErr == nil {
+				return domain.NewValidationError([]string{"task " + id + " has subtasks that are not in the trash; delete them first"})
+			}
+			return s.notInTrash(id)
+		}
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+// PurgeDeletedBefore permanently deletes everything that was moved to the
+// trash before the given time and returns the number of tasks and specs removed.
+// Tasks with a subtask outside the trash are kept.
+func (s *TrashService) PurgeDeletedBefore(cutoff time.Time) (int, error) {
+	tasks, err := s.taskRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+
+	specs, err := s.specRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return tasks, domain.NewInternalError(err)
+	}
+	return tasks + specs, nil
+}
+
+// Empty permanently deletes everything in the trash and returns the number
+// of tasks and specs removed.
+func (s *TrashService) Empty() (int, error) {
+	// Deletion times are stored with second precision, so include the current second.
+	return s.PurgeDeletedBefore(time.Now().UTC().Add(time.Second))
+}
+
+// notInTrash returns the error for an ID that has no trash entry.
+func (s *TrashService) notInTrash(id string) *domain.DomainError {
+	if _, err := s.taskRepo.GetByID(id); err == nil {
+		return domain.NewValidationError([]string{"task " + id + " is not in the trash"})
+	}
+	return domain.NewTaskNotFoundError(id)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
+)
+
+// WorkflowService handles the project workflow definition.
+type WorkflowService struct {
+	workflowRepo *sqlite.WorkflowRepository
+}
+
+// NewWorkflowService creates a new WorkflowService.
+func NewWorkflowService(workflowRepo *sqlite.WorkflowRepository) *WorkflowService {
+	return &WorkflowService{workflowRepo: workflowRepo}
+}
+
+// Get retrieves the project workflow.
+func (s *WorkflowService) Get() (*domain.Workflow, error) {
+	workflow, err := s.workflowRepo.Get()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return workflow, nil
+}
+
+// Set replaces the project workflow.
+// States that still have tasks cannot be removed.
+func (s *WorkflowService) Set(workflow *domain.Workflow) (*domain.Workflow, error) {
+	if errors := workflow.Validate(); len(errors) > 0 {
+		return nil, domain.NewValidationError(errors)
+	}
+
+	counts, err := s.workflowRepo.CountTasksByStatus()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	var errors []string
+	for status, count := range counts {
+		if !workflow.HasState(status) {
+			errors = append(errors, fmt.Sprintf("state %s is still used by %d task(s)", status, count))
+		}
+	}
+	if len(errors) > 0 {
+		return nil, domain.NewValidationError(errors)
+	}
+
+	if err := s.workflowRepo.Replace(workflow); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return s.Get()
+}
@@ -1,13 +1,18 @@
 package store
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync"
 
 	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/airyra/airyra/internal/domain"
+	"github.com/airyra/airyra/internal/store/sqlite"
 )
 
 // initialSchema is the SQL schema for initializing a new project database.
@@ -42,8 +47,7 @@ CREATE TABLE IF NOT EXISTS tasks (
     spec_id     TEXT REFERENCES specs(id) ON DELETE SET NULL,
     title       TEXT NOT NULL,
     description TEXT,
-    status      TEXT NOT NULL DEFAULT 'open'
-                CHECK (status IN ('open', 'in_progress', 'blocked', 'done')),
+    status      TEXT NOT NULL DEFAULT 'open',
     priority    INTEGER NOT NULL DEFAULT 2 CHECK (priority BETWEEN 0 AND 4),
     claimed_by  TEXT,
     claimed_at  TEXT,
@@ -77,6 +81,14 @@ CREATE INDEX IF NOT EXISTS idx_dependencies_child ON dependencies(child_id);
 -- Index for finding what depends on a task
 CREATE INDEX IF NOT EXISTS idx_dependencies_parent ON dependencies(parent_id);
 
+-- Dependencies on tasks in other projects
+CREATE TABLE IF NOT EXISTS external_dependencies (
+    child_id  TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    project   TEXT NOT NULL,
+    parent_id TEXT NOT NULL,
+    PRIMARY KEY (child_id, project, parent_id)
+);
+
 -- Audit log table
 CREATE TABLE IF NOT EXISTS audit_log (
     id         INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -100,6 +112,136 @@ CREATE INDEX IF NOT EXISTS idx_audit_log_changed_by ON audit_log(changed_by);
 
 -- Index for querying audit log by time
 CREATE INDEX IF NOT EXISTS idx_audit_log_changed_at ON audit_log(changed_at);
+
+-- Task links table (commits, branches, pull requests and files)
+CREATE TABLE IF NOT EXISTS task_links (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id    TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    type       TEXT NOT NULL CHECK (type IN ('commit', 'branch', 'pr', 'file')),
+    value      TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    UNIQUE (task_id, type, value)
+);
+
+-- Index for listing links of a task
+CREATE INDEX IF NOT EXISTS idx_task_links_task_id ON task_links(task_id);
+
+-- Index for finding tasks by linked artifact (e.g. a commit SHA)
+CREATE INDEX IF NOT EXISTS idx_task_links_value ON task_links(value);
+
+-- Task comments table (work notes left by agents)
+CREATE TABLE IF NOT EXISTS task_comments (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id    TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    body       TEXT NOT NULL,
+    author     TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+-- Index for listing comments of a task
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments(task_id);
+
+-- Workflow states (the task statuses of the project)
+CREATE TABLE IF NOT EXISTS workflow_states (
+    name         TEXT PRIMARY KEY,
+    position     INTEGER NOT NULL,
+    is_done      INTEGER NOT NULL DEFAULT 0,
+    is_claimable INTEGER NOT NULL DEFAULT 0
+);
+
+-- Allowed workflow transitions
+CREATE TABLE IF NOT EXISTS workflow_transitions (
+    from_state TEXT NOT NULL REFERENCES workflow_states(name) ON DELETE CASCADE,
+    to_state   TEXT NOT NULL REFERENCES workflow_states(name) ON DELETE CASCADE,
+    PRIMARY KEY (from_state, to_state)
+);
+
+-- Project settings (JSON-encoded values keyed by setting name)
+CREATE TABLE IF NOT EXISTS project_settings (
+    key   TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+-- Recurring task schedules
+CREATE TABLE IF NOT EXISTS schedules (
+    id           TEXT PRIMARY KEY,
+    cron         TEXT NOT NULL,
+    catch_up     TEXT NOT NULL DEFAULT 'once' CHECK (catch_up IN ('once', 'all', 'skip')),
+    title        TEXT NOT NULL,
+    description  TEXT,
+    priority     INTEGER NOT NULL DEFAULT 2 CHECK (priority BETWEEN 0 AND 4),
+    estimate     INTEGER,
+    spec_id      TEXT REFERENCES specs(id) ON DELETE SET NULL,
+    next_run_at  TEXT NOT NULL,
+    last_run_at  TEXT,
+    last_task_id TEXT,
+    created_by   TEXT NOT NULL,
+    created_at   TEXT NOT NULL,
+    updated_at   TEXT NOT NULL
+);
+
+-- Index for finding due schedules
+CREATE INDEX IF NOT EXISTS idx_schedules_next_run_at ON schedules(next_run_at);
+
+-- Agents seen in the project, with their registration details
+CREATE TABLE IF NOT EXISTS agents (
+    id            TEXT PRIMARY KEY,
+    name          TEXT,
+    capabilities  TEXT NOT NULL DEFAULT '[]',
+    metadata      TEXT NOT NULL DEFAULT '{}',
+    registered_at TEXT,
+    first_seen_at TEXT NOT NULL,
+    last_seen_at  TEXT NOT NULL
+);
+`
+
+// columnMigrations lists columns added to existing tables after their initial
+// creation. Databases created before a column existed are upgraded on open.
+var columnMigrations = []struct {
+	table      string
+	column     string
+	definition string
+}{
+	{"tasks", "block_reason", "TEXT"},
+	{"tasks", "blocked_by", "TEXT"},
+	{"tasks", "auto_unblock", "INTEGER NOT NULL DEFAULT 0"},
+	{"tasks", "deleted_at", "TEXT"},
+	{"tasks", "estimate", "INTEGER"},
+	{"tasks", "due_at", "TEXT"},
+	{"tasks", "defer_until", "TEXT"},
+	{"tasks", "defer_event", "TEXT"},
+	{"tasks", "assignee", "TEXT"},
+	{"tasks", "attempts", "INTEGER NOT NULL DEFAULT 0"},
+	{"tasks", "quarantined_at", "TEXT"},
+	{"specs", "deleted_at", "TEXT"},
+	{"specs", "due_at", "TEXT"},
+	{"agents", "wip_limit", "INTEGER"},
+	{"audit_log", "spec_id", "TEXT"},
+}
+
+// postMigrationSchema holds statements that depend on migrated columns.
+const postMigrationSchema = `
+-- Index for finding tasks blocked by another task
+CREATE INDEX IF NOT EXISTS idx_tasks_blocked_by ON tasks(blocked_by);
+
+-- Indexes for listing and purging the trash
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_specs_deleted_at ON specs(deleted_at);
+
+-- Index for finding overdue tasks
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks(due_at);
+
+-- Index for querying audit log by spec
+CREATE INDEX IF NOT EXISTS idx_audit_log_spec_id ON audit_log(spec_id);
+
+-- Move spec events logged under task_id before spec_id existed
+UPDATE audit_log SET spec_id = task_id, task_id = ''
+WHERE spec_id IS NULL AND task_id IN (SELECT id FROM specs);
+
+-- Add the cancelled state to workflows created before it existed
+INSERT OR IGNORE INTO workflow_states (name, position, is_done, is_claimable)
+SELECT 'cancelled', MAX(position) + 1, 0, 0 FROM workflow_states;
 `
 
 // Manager handles multiple SQLite database connections, one per project.
@@ -150,10 +292,143 @@ func (m *Manager) GetDB(project string) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to initialize schema: %w", err)
 	}
 
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
 	m.dbs[project] = db
 	return db, nil
 }
 
+// migrate adds any missing columns to an existing database.
+func migrate(db *sql.DB) error {
+	for _, m := range columnMigrations {
+		exists, err := columnExists(db, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.definition)
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to add %s.%s: %w", m.table, m.column, err)
+		}
+	}
+
+	if err := seedWorkflow(db); err != nil {
+		return fmt.Errorf("failed to seed workflow: %w", err)
+	}
+
+	if _, err := db.Exec(postMigrationSchema); err != nil {
+		return err
+	}
+
+	return dropTaskStatusCheck(db)
+}
+
+// seedWorkflow stores the default workflow in a project that has none yet.
+func seedWorkflow(db *sql.DB) error {
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM workflow_states").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return sqlite.NewWorkflowRepository(db).Replace(domain.DefaultWorkflow())
+}
+
+// taskStatusCheck matches the status CHECK constraint of databases created
+// before task statuses were defined by the project workflow.
+var taskStatusCheck = regexp.MustCompile(`\s*CHECK \(status IN \([^)]*\)\)`)
+
+// dropTaskStatusCheck removes the hard-coded status CHECK constraint from the
+// tasks table. Removing a CHECK constraint does not change the on-disk format,
+// so the stored schema is rewritten in place as described in the SQLite
+// ALTER TABLE documentation instead of rebuilding the table.
+func dropTaskStatusCheck(db *sql.DB) error {
+	var ddl string
+	if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'tasks'").Scan(&ddl); err != nil {
+		return err
+	}
+	if !taskStatusCheck.MatchString(ddl) {
+		return nil
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int
+	if err := tx.QueryRow("PRAGMA schema_version").Scan(&version); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("PRAGMA writable_schema = ON"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE sqlite_master SET sql = ? WHERE type = 'table' AND name = 'tasks'",
+		taskStatusCheck.ReplaceAllString(ddl, "")); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA schema_version = %d", version+1)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("PRAGMA writable_schema = OFF"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// columnExists reports whether a table has the given column.
+func columnExists(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// HasProject reports whether a project exists, i.e. has a database.
+func (m *Manager) HasProject(project string) (bool, error) {
+	m.mu.RLock()
+	_, ok := m.dbs[project]
+	m.mu.RUnlock()
+	if ok {
+		return true, nil
+	}
+
+	_, err := os.Stat(filepath.Join(m.basePath, project+".db"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 // ListProjects returns a list of all known projects (based on existing database files).
 func (m *Manager) ListProjects() ([]string, error) {
 	entries, err := os.ReadDir(m.basePath)
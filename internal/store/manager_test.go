@@ -0,0 +1,219 @@
+package store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetDB_MigratesExistingDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create a database with the original tasks table, before any migrated columns
+	db, err := sql.Open("sqlite3", filepath.Join(tmpDir, "legacy.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE tasks (
+			id          TEXT PRIMARY KEY,
+			parent_id   TEXT REFERENCES tasks(id) ON DELETE CASCADE,
+			spec_id     TEXT,
+			title       TEXT NOT NULL,
+			description TEXT,
+			status      TEXT NOT NULL DEFAULT 'open'
+			            CHECK (status IN ('open', 'in_progress', 'blocked', 'done')),
+			priority    INTEGER NOT NULL DEFAULT 2,
+			claimed_by  TEXT,
+			claimed_at  TEXT,
+			created_at  TEXT NOT NULL,
+			updated_at  TEXT NOT NULL
+		);
+		INSERT INTO tasks (id, title, created_at, updated_at)
+		VALUES ('ar-0001', 'Legacy task', '2024-01-01T00:00:00Z', '2024-01-01T00:00:00Z');
+	`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("failed to create legacy schema: %v", err)
+	}
+
+	manager, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err = manager.GetDB("legacy")
+	if err != nil {
+		t.Fatalf("GetDB failed: %v", err)
+	}
+
+	for _, m := range columnMigrations {
+		exists, err := columnExists(db, m.table, m.column)
+		if err != nil {
+			t.Fatalf("columnExists failed: %v", err)
+		}
+		if !exists {
+			t.Errorf("expected column %s.%s to be added", m.table, m.column)
+		}
+	}
+
+	var title string
+	if err := db.QueryRow("SELECT title FROM tasks WHERE id = 'ar-0001'").Scan(&title); err != nil {
+		t.Fatalf("existing task should survive migration: %v", err)
+	}
+
+	// The hard-coded status constraint is gone, so workflow states can be stored
+	if _, err := db.Exec("UPDATE tasks SET status = 'review' WHERE id = 'ar-0001'"); err != nil {
+		t.Errorf("expected custom status to be accepted after migration: %v", err)
+	}
+}
+
+func TestGetDB_MigrationIsIdempotent(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		manager, err := NewManager(tmpDir)
+		if err != nil {
+			t.Fatalf("failed to create manager: %v", err)
+		}
+		if _, err := manager.GetDB("project"); err != nil {
+			t.Fatalf("GetDB failed on open %d: %v", i+1, err)
+		}
+		manager.Close()
+	}
+}
+
+func TestGetDB_AddsCancelledStateToExistingWorkflow(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create a database whose workflow was seeded before the cancelled state existed
+	db, err := sql.Open("sqlite3", filepath.Join(tmpDir, "project.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE workflow_states (
+			name         TEXT PRIMARY KEY,
+			position     INTEGER NOT NULL,
+			is_done      INTEGER NOT NULL DEFAULT 0,
+			is_claimable INTEGER NOT NULL DEFAULT 0
+		);
+		INSERT INTO workflow_states VALUES
+			('open', 0, 0, 1), ('in_progress', 1, 0, 0), ('review', 2, 0, 0),
+			('blocked', 3, 0, 0), ('done', 4, 1, 0);
+	`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("failed to create workflow schema: %v", err)
+	}
+
+	manager, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err = manager.GetDB("project")
+	if err != nil {
+		t.Fatalf("GetDB failed: %v", err)
+	}
+
+	var count, position int
+	if err := db.QueryRow("SELECT COUNT(*) FROM workflow_states").Scan(&count); err != nil {
+		t.Fatalf("failed to count states: %v", err)
+	}
+	if count != 6 {
+		t.Errorf("expected existing states to be kept and cancelled added, got %d states", count)
+	}
+	if err := db.QueryRow("SELECT position FROM workflow_states WHERE name = 'cancelled'").Scan(&position); err != nil {
+		t.Fatalf("expected cancelled state to be added: %v", err)
+	}
+	if position != 5 {
+		t.Errorf("expected cancelled state to be last, got position %d", position)
+	}
+}
+
+func TestHasProject(t *testing.T) {
+	manager, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	if exists, err := manager.HasProject("backend"); err != nil || exists {
+		t.Fatalf("HasProject before creation = %v, %v; want false", exists, err)
+	}
+	if _, err := manager.GetDB("backend"); err != nil {
+		t.Fatalf("GetDB failed: %v", err)
+	}
+	if exists, err := manager.HasProject("backend"); err != nil || !exists {
+		t.Errorf("HasProject after creation = %v, %v; want true", exists, err)
+	}
+}
+
+func TestGetDB_MovesSpecAuditEntriesToSpecID(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create a database whose audit log recorded spec events under task_id
+	db, err := sql.Open("sqlite3", filepath.Join(tmpDir, "project.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE specs (
+			id            TEXT PRIMARY KEY,
+			title         TEXT NOT NULL,
+			description   TEXT,
+			manual_status TEXT CHECK (manual_status IS NULL OR manual_status = 'cancelled'),
+			created_at    TEXT NOT NULL,
+			updated_at    TEXT NOT NULL
+		);
+		CREATE TABLE audit_log (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id    TEXT NOT NULL,
+			action     TEXT NOT NULL,
+			field      TEXT,
+			old_value  TEXT,
+			new_value  TEXT,
+			changed_at TEXT NOT NULL,
+			changed_by TEXT NOT NULL
+		);
+		INSERT INTO specs (id, title, created_at, updated_at)
+		VALUES ('sp-0001', 'Legacy spec', '2024-01-01T00:00:00Z', '2024-01-01T00:00:00Z');
+		INSERT INTO audit_log (task_id, action, changed_at, changed_by) VALUES
+			('sp-0001', 'overdue', '2024-01-02T00:00:00Z', 'system'),
+			('ar-0001', 'overdue', '2024-01-02T00:00:00Z', 'system');
+	`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("failed to create legacy schema: %v", err)
+	}
+
+	manager, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer manager.Close()
+
+	db, err = manager.GetDB("project")
+	if err != nil {
+		t.Fatalf("GetDB failed: %v", err)
+	}
+
+	var taskID string
+	var specID sql.NullString
+	if err := db.QueryRow("SELECT task_id, spec_id FROM audit_log WHERE id = 1").Scan(&taskID, &specID); err != nil {
+		t.Fatalf("failed to read spec entry: %v", err)
+	}
+	if taskID != "" || specID.String != "sp-0001" {
+		t.Errorf("expected spec entry to move to spec_id, got task_id %q spec_id %q", taskID, specID.String)
+	}
+
+	if err := db.QueryRow("SELECT task_id, spec_id FROM audit_log WHERE id = 2").Scan(&taskID, &specID); err != nil {
+		t.Fatalf("failed to read task entry: %v", err)
+	}
+	if taskID != "ar-0001" || specID.Valid {
+		t.Errorf("expected task entry to be unchanged, got task_id %q spec_id %q", taskID, specID.String)
+	}
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// agentColumns lists the agent columns in the order expected by scanAgent.
+const agentColumns = `id, name, capabilities, metadata, wip_limit, registered_at, first_seen_at, last_seen_at`
+
+// AgentRepository handles agent persistence operations.
+type AgentRepository struct {
+	db DBTX
+}
+
+// NewAgentRepository creates a new AgentRepository.
+func NewAgentRepository(db DBTX) *AgentRepository {
+	return &AgentRepository{db: db}
+}
+
+// Register stores the name, capabilities, metadata and WIP limit of an
+// agent, creating it if it was never seen. The registration time of an agent
+// that registers again is kept.
+func (r *AgentRepository) Register(agent *domain.Agent) error {
+	capabilities, err := json.Marshal(agent.Capabilities)
+	if err != nil {
+		return err
+	}
+	metadata, err := json.Marshal(agent.Metadata)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(`
+		INSERT INTO agents (`+agentColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			capabilities = excluded.capabilities,
+			metadata = excluded.metadata,
+			wip_limit = excluded.wip_limit,
+			registered_at = COALESCE(agents.registered_at, excluded.registered_at),
+			last_seen_at = excluded.last_seen_at
+	`,
+		agent.ID,
+		agent.Name,
+		string(capabilities),
+		string(metadata),
+		agent.WIPLimit,
+		formatTime(agent.RegisteredAt),
+		agent.FirstSeenAt.UTC().Format(time.RFC3339),
+		agent.LastSeenAt.UTC().Format(time.RFC3339),
+	)
+	return err
+}
+
+// Touch records that an agent was seen at now, creating it if it was never
+// seen. The row is only written when the last-seen time moves forward.
+func (r *AgentRepository) Touch(id string, now time.Time) error {
+	seen := now.UTC().Format(time.RFC3339)
+	_, err := r.db.Exec(`
+		INSERT INTO agents (id, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET last_seen_at = excluded.last_seen_at
+		WHERE agents.last_seen_at < excluded.last_seen_at
+	`, id, seen, seen)
+	return err
+}
+
+// GetByID retrieves an agent by its ID.
+func (r *AgentRepository) GetByID(id string) (*domain.Agent, error) {
+	row := r.db.QueryRow(`SELECT `+agentColumns+` FROM agents WHERE id = ?`, id)
+	return scanAgent(row)
+}
+
+// List returns all agents, most recently seen first.
+func (r *AgentRepository) List() ([]*domain.Agent, error) {
+	rows, err := r.db.Query(`SELECT ` + agentColumns + ` FROM agents ORDER BY last_seen_at DESC, id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	agents := []*domain.Agent{}
+	for rows.Next() {
+		agent, err := scanAgent(rows)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, agent)
+	}
+	return agents, rows.Err()
+}
+
+func scanAgent(row rowScanner) (*domain.Agent, error) {
+	var agent domain.Agent
+	var name, registeredAt sql.NullString
+	var wipLimit sql.NullInt64
+	var capabilities, metadata, firstSeenAt, lastSeenAt string
+
+	err := row.Scan(
+		&agent.ID,
+		&name,
+		&capabilities,
+		&metadata,
+		&wipLimit,
+		&registeredAt,
+		&firstSeenAt,
+		&lastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if name.Valid {
+		agent.Name = &name.String
+	}
+	if err := json.Unmarshal([]byte(capabilities), &agent.Capabilities); err != nil {
+		return nil, err
+	}
+	if agent.Capabilities == nil {
+		agent.Capabilities = []string{}
+	}
+	if err := json.Unmarshal([]byte(metadata), &agent.Metadata); err != nil {
+		return nil, err
+	}
+	if wipLimit.Valid {
+		limit := int(wipLimit.Int64)
+		agent.WIPLimit = &limit
+	}
+	agent.RegisteredAt = parseTime(registeredAt)
+	agent.FirstSeenAt, _ = time.Parse(time.RFC3339, firstSeenAt)
+	agent.LastSeenAt, _ = time.Parse(time.RFC3339, lastSeenAt)
+
+	return &agent, nil
+}
@@ -9,21 +9,22 @@ import (
 
 // AuditRepository handles audit log persistence operations.
 type AuditRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewAuditRepository creates a new AuditRepository.
-func NewAuditRepository(db *sql.DB) *AuditRepository {
+func NewAuditRepository(db DBTX) *AuditRepository {
 	return &AuditRepository{db: db}
 }
 
 // Log creates an audit log entry.
 func (r *AuditRepository) Log(entry *domain.AuditEntry) error {
 	_, err := r.db.Exec(`
-		INSERT INTO audit_log (task_id, action, field, old_value, new_value, changed_at, changed_by)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO audit_log (task_id, spec_id, action, field, old_value, new_value, changed_at, changed_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		entry.TaskID,
+		entry.SpecID,
 		entry.Action,
 		entry.Field,
 		entry.OldValue,
@@ -37,7 +38,7 @@ func (r *AuditRepository) Log(entry *domain.AuditEntry) error {
 // ListByTaskID returns all audit entries for a task.
 func (r *AuditRepository) ListByTaskID(taskID string) ([]*domain.AuditEntry, error) {
 	rows, err := r.db.Query(`
-		SELECT id, task_id, action, field, old_value, new_value, changed_at, changed_by
+		SELECT id, task_id, spec_id, action, field, old_value, new_value, changed_at, changed_by
 		FROM audit_log
 		WHERE task_id = ?
 		ORDER BY changed_at DESC
@@ -50,6 +51,40 @@ func (r *AuditRepository) ListByTaskID(taskID string) ([]*domain.AuditEntry, err
 	return r.scanEntries(rows)
 }
 
+// ListStatusChanges returns the status changes and handoffs of a task,
+// oldest first.
+func (r *AuditRepository) ListStatusChanges(taskID string) ([]*domain.AuditEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT id, task_id, spec_id, action, field, old_value, new_value, changed_at, changed_by
+		FROM audit_log
+		WHERE task_id = ? AND (field = 'status' OR action = 'handoff')
+		ORDER BY changed_at ASC, id ASC
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanEntries(rows)
+}
+
+// ListAllStatusChanges returns the status changes and handoffs of all
+// tasks, oldest first.
+func (r *AuditRepository) ListAllStatusChanges() ([]*domain.AuditEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT id, task_id, spec_id, action, field, old_value, new_value, changed_at, changed_by
+		FROM audit_log
+		WHERE field = 'status' OR action = 'handoff'
+		ORDER BY changed_at ASC, id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanEntries(rows)
+}
+
 // AuditQueryParams contains parameters for querying the audit log.
 type AuditQueryParams struct {
 	Action    *string
@@ -93,7 +128,7 @@ func (r *AuditRepository) Query(params AuditQueryParams) ([]*domain.AuditEntry,
 	}
 
 	// Fetch entries
-	selectQuery := "SELECT id, task_id, action, field, old_value, new_value, changed_at, changed_by " + baseQuery
+	selectQuery := "SELECT id, task_id, spec_id, action, field, old_value, new_value, changed_at, changed_by " + baseQuery
 	selectQuery += " ORDER BY changed_at DESC LIMIT ? OFFSET ?"
 	args = append(args, params.PerPage, offset)
 
@@ -115,12 +150,13 @@ func (r *AuditRepository) scanEntries(rows *sql.Rows) ([]*domain.AuditEntry, err
 	var entries []*domain.AuditEntry
 	for rows.Next() {
 		var entry domain.AuditEntry
-		var field, oldValue, newValue sql.NullString
+		var specID, field, oldValue, newValue sql.NullString
 		var changedAt string
 
 		err := rows.Scan(
 			&entry.ID,
 			&entry.TaskID,
+			&specID,
 			&entry.Action,
 			&field,
 			&oldValue,
@@ -132,6 +168,9 @@ func (r *AuditRepository) scanEntries(rows *sql.Rows) ([]*domain.AuditEntry, err
 			return nil, err
 		}
 
+		if specID.Valid {
+			entry.SpecID = &specID.String
+		}
 		if field.Valid {
 			entry.Field = &field.String
 		}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// CommentRepository handles task comment persistence operations.
+type CommentRepository struct {
+	db *sql.DB
+}
+
+// NewCommentRepository creates a new CommentRepository.
+func NewCommentRepository(db *sql.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Add creates a new comment and sets its ID.
+func (r *CommentRepository) Add(comment *domain.Comment) error {
+	result, err := r.db.Exec(`
+		INSERT INTO task_comments (task_id, body, author, created_at)
+		VALUES (?, ?, ?, ?)
+	`,
+		comment.TaskID,
+		comment.Body,
+		comment.Author,
+		comment.CreatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	comment.ID = id
+	return nil
+}
+
+// ListByTaskID returns all comments on a task, oldest first.
+func (r *CommentRepository) ListByTaskID(taskID string) ([]*domain.Comment, error) {
+	rows, err := r.db.Query(`
+		SELECT id, task_id, body, author, created_at
+		FROM task_comments
+		WHERE task_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*domain.Comment
+	for rows.Next() {
+		var comment domain.Comment
+		var createdAt string
+
+		err := rows.Scan(
+			&comment.ID,
+			&comment.TaskID,
+			&comment.Body,
+			&comment.Author,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+		comments = append(comments, &comment)
+	}
+	return comments, rows.Err()
+}
@@ -0,0 +1,11 @@
+package sqlite
+
+import "database/sql"
+
+// DBTX is implemented by both *sql.DB and *sql.Tx, so that repositories can
+// take part in a transaction spanning several of them.
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
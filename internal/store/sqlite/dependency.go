@@ -8,11 +8,11 @@ import (
 
 // DependencyRepository handles dependency persistence operations.
 type DependencyRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewDependencyRepository creates a new DependencyRepository.
-func NewDependencyRepository(db *sql.DB) *DependencyRepository {
+func NewDependencyRepository(db DBTX) *DependencyRepository {
 	return &DependencyRepository{db: db}
 }
 
@@ -91,6 +91,58 @@ func (r *DependencyRepository) ListByParent(parentID string) ([]*domain.Dependen
 	return deps, rows.Err()
 }
 
+// ListTransitiveByChild returns the dependencies of a task and, recursively,
+// of the tasks it depends on, closest first.
+func (r *DependencyRepository) ListTransitiveByChild(childID string) ([]*domain.Dependency, error) {
+	return r.listTransitive(`
+		WITH RECURSIVE reached(child_id, parent_id, depth) AS (
+			SELECT child_id, parent_id, 1 FROM dependencies WHERE child_id = ?
+			UNION
+			SELECT d.child_id, d.parent_id, r.depth + 1
+			FROM dependencies d JOIN reached r ON d.child_id = r.parent_id
+		)
+		SELECT child_id, parent_id, MIN(depth) FROM reached
+		GROUP BY child_id, parent_id
+		ORDER BY MIN(depth), child_id, parent_id
+	`, childID)
+}
+
+// ListTransitiveByParent returns the dependencies on a task and, recursively,
+// on the tasks that depend on it, closest first.
+func (r *DependencyRepository) ListTransitiveByParent(parentID string) ([]*domain.Dependency, error) {
+	return r.listTransitive(`
+		WITH RECURSIVE reached(child_id, parent_id, depth) AS (
+			SELECT child_id, parent_id, 1 FROM dependencies WHERE parent_id = ?
+			UNION
+			SELECT d.child_id, d.parent_id, r.depth + 1
+			FROM dependencies d JOIN reached r ON d.parent_id = r.child_id
+		)
+		SELECT child_id, parent_id, MIN(depth) FROM reached
+		GROUP BY child_id, parent_id
+		ORDER BY MIN(depth), parent_id, child_id
+	`, parentID)
+}
+
+// listTransitive runs a query selecting (child, parent, depth) rows.
+func (r *DependencyRepository) listTransitive(query, taskID string) ([]*domain.Dependency, error) {
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []*domain.Dependency
+	for rows.Next() {
+		var dep domain.Dependency
+		if err := rows.Scan(&dep.ChildID, &dep.ParentID, &dep.Depth); err != nil {
+			return nil, err
+		}
+		deps = append(deps, &dep)
+	}
+
+	return deps, rows.Err()
+}
+
 // Exists checks if a dependency exists.
 func (r *DependencyRepository) Exists(childID, parentID string) (bool, error) {
 	var count int
@@ -104,52 +156,83 @@ func (r *DependencyRepository) Exists(childID, parentID string) (bool, error) {
 	return count > 0, nil
 }
 
-// WouldCreateCycle checks if adding a dependency would create a cycle.
-// Returns the cycle path if a cycle would be created, nil otherwise.
-func (r *DependencyRepository) WouldCreateCycle(childID, parentID string) ([]string, error) {
-	// Use BFS to check if childID is reachable from parentID via existing dependencies.
-	// If so, adding childID -> parentID would create a cycle.
-	// Example: If A->B exists and we try to add B->A, we check if A is reachable from B.
-	// Starting from B (parentID), we traverse dependencies. If we reach A (childID), there's a cycle.
-
-	visited := make(map[string]bool)
-	cameFrom := make(map[string]string) // tracks BFS traversal path
-	queue := []string{parentID}
-	visited[parentID] = true
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		if current == childID {
-			// Found a path from parentID to childID, so adding childID -> parentID creates a cycle
-			// Reconstruct: childID -> ... -> parentID -> childID
-			path := []string{current}
-			node := current
-			for node != parentID {
-				prev := cameFrom[node]
-				path = append(path, prev)
-				node = prev
-			}
-			// Add childID at the end to show the complete cycle
-			path = append(path, childID)
-			return path, nil
-		}
+// AddExternal adds a dependency of a task on a task in another project.
+func (r *DependencyRepository) AddExternal(childID, project, parentID string) error {
+	_, err := r.db.Exec(
+		"INSERT INTO external_dependencies (child_id, project, parent_id) VALUES (?, ?, ?)",
+		childID, project, parentID,
+	)
+	return err
+}
 
-		// Get all tasks that current depends on
-		deps, err := r.ListByChild(current)
-		if err != nil {
-			return nil, err
-		}
+// RemoveExternal removes a dependency on a task in another project.
+func (r *DependencyRepository) RemoveExternal(childID, project, parentID string) error {
+	result, err := r.db.Exec(
+		"DELETE FROM external_dependencies WHERE child_id = ? AND project = ? AND parent_id = ?",
+		childID, project, parentID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
 
-		for _, dep := range deps {
-			if !visited[dep.ParentID] {
-				visited[dep.ParentID] = true
-				cameFrom[dep.ParentID] = current
-				queue = append(queue, dep.ParentID)
-			}
+// ExistsExternal checks if a dependency on a task in another project exists.
+func (r *DependencyRepository) ExistsExternal(childID, project, parentID string) (bool, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM external_dependencies WHERE child_id = ? AND project = ? AND parent_id = ?",
+		childID, project, parentID,
+	).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListExternalByChild returns the dependencies of a task on tasks in other projects.
+func (r *DependencyRepository) ListExternalByChild(childID string) ([]*domain.Dependency, error) {
+	return r.listExternal(`
+		SELECT child_id, project, parent_id FROM external_dependencies
+		WHERE child_id = ?
+		ORDER BY project, parent_id
+	`, childID)
+}
+
+// ListExternal returns the dependencies of all live tasks on tasks in other projects.
+func (r *DependencyRepository) ListExternal() ([]*domain.Dependency, error) {
+	return r.listExternal(`
+		SELECT e.child_id, e.project, e.parent_id FROM external_dependencies e
+		JOIN tasks t ON t.id = e.child_id
+		WHERE t.deleted_at IS NULL
+		ORDER BY e.project, e.parent_id
+	`)
+}
+
+// listExternal runs a query selecting (child, project, parent) rows.
+func (r *DependencyRepository) listExternal(query string, args ...interface{}) ([]*domain.Dependency, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []*domain.Dependency
+	for rows.Next() {
+		var dep domain.Dependency
+		if err := rows.Scan(&dep.ChildID, &dep.Project, &dep.ParentID); err != nil {
+			return nil, err
 		}
+		deps = append(deps, &dep)
 	}
 
-	return nil, nil
+	return deps, rows.Err()
 }
@@ -0,0 +1,135 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// GraphRepository reads the task and spec graph of a project.
+type GraphRepository struct {
+	db *sql.DB
+}
+
+// NewGraphRepository creates a new GraphRepository.
+func NewGraphRepository(db *sql.DB) *GraphRepository {
+	return &GraphRepository{db: db}
+}
+
+// Get returns every task and spec outside the trash together with their
+// dependency and subtask edges.
+func (r *GraphRepository) Get() (*domain.Graph, error) {
+	graph := &domain.Graph{Nodes: []domain.GraphNode{}, Edges: []domain.GraphEdge{}}
+
+	rows, err := r.db.Query(`
+		SELECT ` + specColumns + `
+		FROM specs s
+		WHERE s.deleted_at IS NULL
+		ORDER BY s.created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := (&SpecRepository{db: r.db}).scanSpecs(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	for _, spec := range specs {
+		graph.Nodes = append(graph.Nodes, domain.GraphNode{
+			ID:     spec.ID,
+			Kind:   domain.GraphNodeSpec,
+			Title:  spec.Title,
+			Status: string(spec.ComputeStatus()),
+		})
+	}
+
+	rows, err = r.db.Query(`
+		SELECT id, title, status, spec_id, parent_id, estimate
+		FROM tasks
+		WHERE ` + notDeleted + `
+		ORDER BY priority ASC, created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subtaskEdges []domain.GraphEdge
+	for rows.Next() {
+		var node domain.GraphNode
+		var specID, parentID sql.NullString
+		var estimate sql.NullInt64
+		if err := rows.Scan(&node.ID, &node.Title, &node.Status, &specID, &parentID, &estimate); err != nil {
+			return nil, err
+		}
+		node.Kind = domain.GraphNodeTask
+		if specID.Valid {
+			node.SpecID = &specID.String
+		}
+		if estimate.Valid {
+			minutes := int(estimate.Int64)
+			node.Estimate = &minutes
+		}
+		graph.Nodes = append(graph.Nodes, node)
+
+		if parentID.Valid {
+			subtaskEdges = append(subtaskEdges, domain.GraphEdge{
+				From: parentID.String,
+				To:   node.ID,
+				Kind: domain.GraphEdgeSubtask,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	deps, err := r.edges(`
+		SELECT d.parent_id, d.child_id
+		FROM dependencies d
+		JOIN tasks p ON p.id = d.parent_id
+		JOIN tasks c ON c.id = d.child_id
+		WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+		ORDER BY d.parent_id, d.child_id
+	`, domain.GraphEdgeDependency)
+	if err != nil {
+		return nil, err
+	}
+
+	specDeps, err := r.edges(`
+		SELECT d.parent_id, d.child_id
+		FROM spec_dependencies d
+		JOIN specs p ON p.id = d.parent_id
+		JOIN specs c ON c.id = d.child_id
+		WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+		ORDER BY d.parent_id, d.child_id
+	`, domain.GraphEdgeSpecDependency)
+	if err != nil {
+		return nil, err
+	}
+
+	graph.Edges = append(graph.Edges, deps...)
+	graph.Edges = append(graph.Edges, specDeps...)
+	graph.Edges = append(graph.Edges, subtaskEdges...)
+	return graph, nil
+}
+
+// edges runs a query selecting (from, to) pairs and returns them as edges of the given kind.
+func (r *GraphRepository) edges(query string, kind domain.GraphEdgeKind) ([]domain.GraphEdge, error) {
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edges []domain.GraphEdge
+	for rows.Next() {
+		edge := domain.GraphEdge{Kind: kind}
+		if err := rows.Scan(&edge.From, &edge.To); err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+	return edges, rows.Err()
+}
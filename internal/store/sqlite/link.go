@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// LinkRepository handles task link persistence operations.
+type LinkRepository struct {
+	db *sql.DB
+}
+
+// NewLinkRepository creates a new LinkRepository.
+func NewLinkRepository(db *sql.DB) *LinkRepository {
+	return &LinkRepository{db: db}
+}
+
+// Add creates a new task link and sets its ID.
+func (r *LinkRepository) Add(link *domain.TaskLink) error {
+	result, err := r.db.Exec(`
+		INSERT INTO task_links (task_id, type, value, created_at, created_by)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		link.TaskID,
+		string(link.Type),
+		link.Value,
+		link.CreatedAt.Format(time.RFC3339),
+		link.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	link.ID = id
+	return nil
+}
+
+// Get retrieves a link by task, type and value.
+func (r *LinkRepository) Get(taskID string, linkType domain.LinkType, value string) (*domain.TaskLink, error) {
+	rows, err := r.db.Query(`
+		SELECT id, task_id, type, value, created_at, created_by
+		FROM task_links
+		WHERE task_id = ? AND type = ? AND value = ?
+	`, taskID, string(linkType), value)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links, err := r.scanLinks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return links[0], nil
+}
+
+// ListByTaskID returns all links for a task, oldest first.
+func (r *LinkRepository) ListByTaskID(taskID string) ([]*domain.TaskLink, error) {
+	rows, err := r.db.Query(`
+		SELECT id, task_id, type, value, created_at, created_by
+		FROM task_links
+		WHERE task_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanLinks(rows)
+}
+
+func (r *LinkRepository) scanLinks(rows *sql.Rows) ([]*domain.TaskLink, error) {
+	var links []*domain.TaskLink
+	for rows.Next() {
+		var link domain.TaskLink
+		var linkType, createdAt string
+
+		err := rows.Scan(
+			&link.ID,
+			&link.TaskID,
+			&linkType,
+			&link.Value,
+			&createdAt,
+			&link.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		link.Type = domain.LinkType(linkType)
+		link.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+		links = append(links, &link)
+	}
+	return links, rows.Err()
+}
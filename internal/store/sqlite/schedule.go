@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// scheduleColumns lists the schedule columns in the order expected by
+// scanSchedule.
+const scheduleColumns = `
+			id, cron, catch_up, title, description, priority, estimate, spec_id,
+			next_run_at, last_run_at, last_task_id, created_by, created_at, updated_at`
+
+// ScheduleRepository handles schedule persistence operations.
+type ScheduleRepository struct {
+	db DBTX
+}
+
+// NewScheduleRepository creates a new ScheduleRepository.
+func NewScheduleRepository(db DBTX) *ScheduleRepository {
+	return &ScheduleRepository{db: db}
+}
+
+// Create creates a new schedule.
+func (r *ScheduleRepository) Create(schedule *domain.Schedule) error {
+	_, err := r.db.Exec(`
+		INSERT INTO schedules (`+scheduleColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		schedule.ID,
+		schedule.Cron,
+		schedule.CatchUp,
+		schedule.Title,
+		schedule.Description,
+		schedule.Priority,
+		schedule.Estimate,
+		schedule.SpecID,
+		schedule.NextRunAt.UTC().Format(time.RFC3339),
+		formatTime(schedule.LastRunAt),
+		schedule.LastTaskID,
+		schedule.CreatedBy,
+		schedule.CreatedAt.Format(time.RFC3339),
+		schedule.UpdatedAt.Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetByID retrieves a schedule by its ID.
+func (r *ScheduleRepository) GetByID(id string) (*domain.Schedule, error) {
+	row := r.db.QueryRow(`SELECT `+scheduleColumns+` FROM schedules WHERE id = ?`, id)
+	return scanSchedule(row)
+}
+
+// List returns all schedules, soonest first.
+func (r *ScheduleRepository) List() ([]*domain.Schedule, error) {
+	return r.list(`SELECT ` + scheduleColumns + ` FROM schedules ORDER BY next_run_at ASC, id ASC`)
+}
+
+// ListDue returns the schedules whose next run is at or before now.
+func (r *ScheduleRepository) ListDue(now time.Time) ([]*domain.Schedule, error) {
+	return r.list(`SELECT `+scheduleColumns+` FROM schedules WHERE next_run_at <= ? ORDER BY next_run_at ASC, id ASC`,
+		now.UTC().Format(time.RFC3339))
+}
+
+// RecordRun stores the outcome of running a schedule: its next run and, when
+// a task was created, the time of the run and the task.
+func (r *ScheduleRepository) RecordRun(id string, nextRunAt time.Time, lastRunAt *time.Time, lastTaskID *string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := r.db.Exec(`
+		UPDATE schedules
+		SET next_run_at = ?,
+			last_run_at = COALESCE(?, last_run_at),
+			last_task_id = COALESCE(?, last_task_id),
+			updated_at = ?
+		WHERE id = ?
+	`, nextRunAt.UTC().Format(time.RFC3339), formatTime(lastRunAt), lastTaskID, now, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Delete removes a schedule. Tasks it created are kept.
+func (r *ScheduleRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM schedules WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) list(query string, args ...interface{}) ([]*domain.Schedule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []*domain.Schedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func scanSchedule(row rowScanner) (*domain.Schedule, error) {
+	var schedule domain.Schedule
+	var description, specID, lastRunAt, lastTaskID sql.NullString
+	var estimate sql.NullInt64
+	var nextRunAt, createdAt, updatedAt string
+
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.Cron,
+		&schedule.CatchUp,
+		&schedule.Title,
+		&description,
+		&schedule.Priority,
+		&estimate,
+		&specID,
+		&nextRunAt,
+		&lastRunAt,
+		&lastTaskID,
+		&schedule.CreatedBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		schedule.Description = &description.String
+	}
+	if estimate.Valid {
+		e := int(estimate.Int64)
+		schedule.Estimate = &e
+	}
+	if specID.Valid {
+		schedule.SpecID = &specID.String
+	}
+	if lastTaskID.Valid {
+		schedule.LastTaskID = &lastTaskID.String
+	}
+	schedule.NextRunAt, _ = time.Parse(time.RFC3339, nextRunAt)
+	schedule.LastRunAt = parseTime(lastRunAt)
+	schedule.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	schedule.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &schedule, nil
+}
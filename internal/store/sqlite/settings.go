@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// SettingsRepository handles project settings persistence operations.
+type SettingsRepository struct {
+	db *sql.DB
+}
+
+// NewSettingsRepository creates a new SettingsRepository.
+func NewSettingsRepository(db *sql.DB) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// Get retrieves the project settings. Settings that were never stored keep
+// their default values.
+func (r *SettingsRepository) Get() (*domain.ProjectSettings, error) {
+	rows, err := r.db.Query("SELECT key, value FROM project_settings")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stored := make(map[string]json.RawMessage)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		stored[key] = json.RawMessage(value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	settings := domain.DefaultProjectSettings()
+	if len(stored) == 0 {
+		return settings, nil
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Replace stores every project setting atomically.
+func (r *SettingsRepository) Replace(settings *domain.ProjectSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for key, value := range values {
+		if _, err := tx.Exec(`
+			INSERT INTO project_settings (key, value) VALUES (?, ?)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value
+		`, key, string(value)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -7,27 +7,70 @@ import (
 	"github.com/airyra/airyra/internal/domain"
 )
 
+// specTaskActive excludes cancelled tasks and tasks in the trash from spec
+// task counts and status.
+const specTaskActive = "status != 'cancelled' AND deleted_at IS NULL"
+
+// unfinishedParentSpec matches a spec (aliased parent) that does not satisfy
+// its dependents: it is cancelled, has no tasks yet, or has unfinished tasks.
+const unfinishedParentSpec = `(
+	parent.manual_status = 'cancelled'
+	OR (SELECT COUNT(*) FROM tasks WHERE spec_id = parent.id AND ` + specTaskActive + `) = 0
+	OR (SELECT COUNT(*) FROM tasks WHERE spec_id = parent.id AND ` + specTaskActive + `) !=
+	   (SELECT COUNT(*) FROM tasks WHERE spec_id = parent.id AND ` + specTaskActive + ` AND status IN ` + doneStates + `)
+)`
+
+// overdueSpec matches a spec (aliased s) that is not cancelled, has no tasks
+// yet or has unfinished tasks, and whose due date is before its parameter.
+const overdueSpec = `s.due_at IS NOT NULL AND s.due_at < ? AND s.manual_status IS NULL AND (
+	(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) = 0
+	OR (SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) !=
+	   (SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + ` AND status IN ` + doneStates + `)
+)`
+
+// SpecFilter narrows spec listings. Nil fields are not applied.
+type SpecFilter struct {
+	Status *domain.SpecStatus
+	// OverdueAt keeps the unfinished specs whose due date is before it.
+	OverdueAt *time.Time
+}
+
+// specColumns lists the spec columns, including computed task counts, in the
+// order expected by scanSpec and scanSpecs.
+const specColumns = `
+			s.id,
+			s.title,
+			s.description,
+			s.manual_status,
+			s.created_at,
+			s.updated_at,
+			s.deleted_at,
+			s.due_at,
+			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `), 0) as task_count,
+			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + ` AND status IN ` + doneStates + `), 0) as done_count`
+
 // SpecRepository handles spec persistence operations.
 type SpecRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewSpecRepository creates a new SpecRepository.
-func NewSpecRepository(db *sql.DB) *SpecRepository {
+func NewSpecRepository(db DBTX) *SpecRepository {
 	return &SpecRepository{db: db}
 }
 
 // Create creates a new spec.
 func (r *SpecRepository) Create(spec *domain.Spec) error {
 	query := `
-		INSERT INTO specs (id, title, description, manual_status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO specs (id, title, description, manual_status, due_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := r.db.Exec(query,
 		spec.ID,
 		spec.Title,
 		spec.Description,
 		spec.ManualStatus,
+		formatTime(spec.DueAt),
 		spec.CreatedAt.Format(time.RFC3339),
 		spec.UpdatedAt.Format(time.RFC3339),
 	)
@@ -35,95 +78,69 @@ func (r *SpecRepository) Create(spec *domain.Spec) error {
 }
 
 // GetByID retrieves a spec by its ID with computed task counts.
+// Specs in the trash are not returned.
 func (r *SpecRepository) GetByID(id string) (*domain.Spec, error) {
 	query := `
-		SELECT
-			s.id,
-			s.title,
-			s.description,
-			s.manual_status,
-			s.created_at,
-			s.updated_at,
-			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id), 0) as task_count,
-			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND status = 'done'), 0) as done_count
+		SELECT ` + specColumns + `
 		FROM specs s
-		WHERE s.id = ?
+		WHERE s.id = ? AND s.deleted_at IS NULL
 	`
 	row := r.db.QueryRow(query, id)
 	return r.scanSpec(row)
 }
 
-// List retrieves specs with pagination and optional status filter.
-// Status is computed from task counts, so filtering happens via HAVING clause.
-func (r *SpecRepository) List(status *domain.SpecStatus, page, perPage int) ([]*domain.Spec, int, error) {
+// List retrieves specs with pagination, narrowed by filter. Status is computed
+// from task counts, so it is filtered on the counts. Overdue specs are listed
+// earliest due first.
+func (r *SpecRepository) List(filter SpecFilter, page, perPage int) ([]*domain.Spec, int, error) {
 	offset := (page - 1) * perPage
 
 	// Build query with computed status
 	baseQuery := `
-		SELECT
-			s.id,
-			s.title,
-			s.description,
-			s.manual_status,
-			s.created_at,
-			s.updated_at,
-			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id), 0) as task_count,
-			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND status = 'done'), 0) as done_count
+		SELECT ` + specColumns + `
 		FROM specs s
+		WHERE s.deleted_at IS NULL
 	`
 
 	// For status filtering, we need to compute and filter
-	if status != nil {
-		var statusCondition string
-		switch *status {
+	var conditions string
+	args := []interface{}{}
+	if filter.Status != nil {
+		switch *filter.Status {
 		case domain.SpecStatusCancelled:
-			statusCondition = "WHERE s.manual_status = 'cancelled'"
+			conditions = "AND s.manual_status = 'cancelled'"
 		case domain.SpecStatusDraft:
-			statusCondition = `WHERE s.manual_status IS NULL AND
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) = 0`
+			conditions = `AND s.manual_status IS NULL AND
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) = 0`
 		case domain.SpecStatusDone:
-			statusCondition = `WHERE s.manual_status IS NULL AND
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) > 0 AND
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) =
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND status = 'done')`
+			conditions = `AND s.manual_status IS NULL AND
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) > 0 AND
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) =
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + ` AND status IN ` + doneStates + `)`
 		case domain.SpecStatusActive:
-			statusCondition = `WHERE s.manual_status IS NULL AND
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) > 0 AND
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) !=
-				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND status = 'done')`
-		}
-
-		// Count with filter
-		countQuery := "SELECT COUNT(*) FROM (" + baseQuery + statusCondition + ")"
-		var total int
-		if err := r.db.QueryRow(countQuery).Scan(&total); err != nil {
-			return nil, 0, err
-		}
-
-		// Fetch with filter
-		query := baseQuery + statusCondition + " ORDER BY s.created_at DESC LIMIT ? OFFSET ?"
-		rows, err := r.db.Query(query, perPage, offset)
-		if err != nil {
-			return nil, 0, err
+			conditions = `AND s.manual_status IS NULL AND
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) > 0 AND
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) !=
+				(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + ` AND status IN ` + doneStates + `)`
 		}
-		defer rows.Close()
-
-		specs, err := r.scanSpecs(rows)
-		if err != nil {
-			return nil, 0, err
-		}
-		return specs, total, nil
+	}
+	order := " ORDER BY s.created_at DESC"
+	if filter.OverdueAt != nil {
+		conditions += " AND " + overdueSpec
+		args = append(args, filter.OverdueAt.UTC().Format(time.RFC3339))
+		order = " ORDER BY s.due_at ASC, s.created_at DESC"
 	}
 
-	// No filter - simple query
-	countQuery := "SELECT COUNT(*) FROM specs"
+	// Count with filter
+	countQuery := "SELECT COUNT(*) FROM (" + baseQuery + conditions + ")"
 	var total int
-	if err := r.db.QueryRow(countQuery).Scan(&total); err != nil {
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
-	query := baseQuery + " ORDER BY s.created_at DESC LIMIT ? OFFSET ?"
-	rows, err := r.db.Query(query, perPage, offset)
+	// Fetch with filter
+	query := baseQuery + conditions + order + " LIMIT ? OFFSET ?"
+	rows, err := r.db.Query(query, append(args, perPage, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -136,6 +153,27 @@ func (r *SpecRepository) List(status *domain.SpecStatus, page, perPage int) ([]*
 	return specs, total, nil
 }
 
+// ListUnreportedOverdue returns the live specs that are overdue at now and
+// have no overdue event in the audit log for their current due date.
+func (r *SpecRepository) ListUnreportedOverdue(now time.Time) ([]*domain.Spec, error) {
+	rows, err := r.db.Query(`
+		SELECT `+specColumns+`
+		FROM specs s
+		WHERE s.deleted_at IS NULL AND `+overdueSpec+`
+		AND NOT EXISTS (
+			SELECT 1 FROM audit_log a
+			WHERE a.spec_id = s.id AND a.action = 'overdue' AND a.new_value = s.due_at
+		)
+		ORDER BY s.due_at ASC, s.created_at ASC
+	`, now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSpecs(rows)
+}
+
 // ListReady retrieves specs that are ready to be worked on.
 // A spec is ready if it has no pending dependencies (all parent specs are done).
 func (r *SpecRepository) ListReady(page, perPage int) ([]*domain.Spec, int, error) {
@@ -144,34 +182,20 @@ func (r *SpecRepository) ListReady(page, perPage int) ([]*domain.Spec, int, erro
 	// A spec is ready if:
 	// 1. Not cancelled
 	// 2. Not already done
-	// 3. All parent specs (dependencies) are done
+	// 3. All parent specs (dependencies) are done, ignoring specs in the trash
 	query := `
-		SELECT
-			s.id,
-			s.title,
-			s.description,
-			s.manual_status,
-			s.created_at,
-			s.updated_at,
-			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id), 0) as task_count,
-			COALESCE((SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND status = 'done'), 0) as done_count
+		SELECT ` + specColumns + `
 		FROM specs s
-		WHERE s.manual_status IS NULL
+		WHERE s.manual_status IS NULL AND s.deleted_at IS NULL
 		AND NOT (
-			(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) > 0 AND
-			(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id) =
-			(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND status = 'done')
+			(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) > 0 AND
+			(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + `) =
+			(SELECT COUNT(*) FROM tasks WHERE spec_id = s.id AND ` + specTaskActive + ` AND status IN ` + doneStates + `)
 		)
 		AND NOT EXISTS (
 			SELECT 1 FROM spec_dependencies sd
 			JOIN specs parent ON sd.parent_id = parent.id
-			WHERE sd.child_id = s.id
-			AND (
-				parent.manual_status = 'cancelled'
-				OR (SELECT COUNT(*) FROM tasks WHERE spec_id = parent.id) = 0
-				OR (SELECT COUNT(*) FROM tasks WHERE spec_id = parent.id) !=
-				   (SELECT COUNT(*) FROM tasks WHERE spec_id = parent.id AND status = 'done')
-			)
+			WHERE sd.child_id = s.id AND parent.deleted_at IS NULL AND ` + unfinishedParentSpec + `
 		)
 	`
 
@@ -199,13 +223,14 @@ func (r *SpecRepository) ListReady(page, perPage int) ([]*domain.Spec, int, erro
 func (r *SpecRepository) Update(spec *domain.Spec) error {
 	query := `
 		UPDATE specs
-		SET title = ?, description = ?, manual_status = ?, updated_at = ?
+		SET title = ?, description = ?, manual_status = ?, due_at = ?, updated_at = ?
 		WHERE id = ?
 	`
 	result, err := r.db.Exec(query,
 		spec.Title,
 		spec.Description,
 		spec.ManualStatus,
+		formatTime(spec.DueAt),
 		spec.UpdatedAt.Format(time.RFC3339),
 		spec.ID,
 	)
@@ -223,9 +248,72 @@ func (r *SpecRepository) Update(spec *domain.Spec) error {
 	return nil
 }
 
-// Delete deletes a spec by ID.
-func (r *SpecRepository) Delete(id string) error {
-	result, err := r.db.Exec("DELETE FROM specs WHERE id = ?", id)
+// Delete moves a spec to the trash. Its tasks are left untouched.
+func (r *SpecRepository) Delete(id string, now time.Time) error {
+	result, err := r.db.Exec("UPDATE specs SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL",
+		now.Format(time.RFC3339), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetDeleted retrieves a spec from the trash by its ID.
+func (r *SpecRepository) GetDeleted(id string) (*domain.Spec, error) {
+	query := `
+		SELECT ` + specColumns + `
+		FROM specs s
+		WHERE s.id = ? AND s.deleted_at IS NOT NULL
+	`
+	row := r.db.QueryRow(query, id)
+	return r.scanSpec(row)
+}
+
+// Restore moves a spec out of the trash.
+func (r *SpecRepository) Restore(id string) error {
+	result, err := r.db.Exec("UPDATE specs SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListDeleted returns the specs in the trash, most recently deleted first.
+func (r *SpecRepository) ListDeleted() ([]*domain.Spec, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + specColumns + `
+		FROM specs s
+		WHERE s.deleted_at IS NOT NULL
+		ORDER BY s.deleted_at DESC, s.created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSpecs(rows)
+}
+
+// Purge permanently deletes a spec in the trash. Its tasks are kept and
+// no longer belong to a spec.
+func (r *SpecRepository) Purge(id string) error {
+	result, err := r.db.Exec("DELETE FROM specs WHERE id = ? AND deleted_at IS NOT NULL", id)
 	if err != nil {
 		return err
 	}
@@ -240,20 +328,89 @@ func (r *SpecRepository) Delete(id string) error {
 	return nil
 }
 
+// PurgeDeletedBefore permanently deletes the specs that were moved to the trash
+// before the given time and returns how many were removed.
+func (r *SpecRepository) PurgeDeletedBefore(cutoff time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM specs WHERE deleted_at IS NOT NULL AND deleted_at < ?",
+		cutoff.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), err
+}
+
+// CountTasksByStatus counts the live tasks of a spec by status.
+func (r *SpecRepository) CountTasksByStatus(specID string) (map[string]int, error) {
+	rows, err := r.db.Query(`
+		SELECT status, COUNT(*) FROM tasks
+		WHERE spec_id = ? AND deleted_at IS NULL
+		GROUP BY status
+	`, specID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// ListCompletionTimes returns when each done task of a spec was completed:
+// the last time the audit log recorded it entering a done state, or its last
+// update when the log has no such entry. Cancelled tasks are left out.
+func (r *SpecRepository) ListCompletionTimes(specID string) ([]time.Time, error) {
+	rows, err := r.db.Query(`
+		SELECT COALESCE(MAX(a.changed_at), t.updated_at) FROM tasks t
+		LEFT JOIN audit_log a ON a.task_id = t.id AND a.field = 'status' AND a.new_value IN `+doneStates+`
+		WHERE t.spec_id = ? AND t.status != 'cancelled' AND t.deleted_at IS NULL AND t.status IN `+doneStates+`
+		GROUP BY t.id
+	`, specID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		completed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, completed)
+	}
+
+	return times, rows.Err()
+}
+
 // ListTasksBySpecID returns all tasks belonging to a spec.
 func (r *SpecRepository) ListTasksBySpecID(specID string, page, perPage int) ([]*domain.Task, int, error) {
 	offset := (page - 1) * perPage
 
-	countQuery := "SELECT COUNT(*) FROM tasks WHERE spec_id = ?"
+	countQuery := "SELECT COUNT(*) FROM tasks WHERE spec_id = ? AND " + notDeleted
 	var total int
 	if err := r.db.QueryRow(countQuery, specID).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	query := `
-		SELECT id, parent_id, spec_id, title, description, status, priority, claimed_by, claimed_at, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE spec_id = ?
+		WHERE spec_id = ? AND ` + notDeleted + `
 		ORDER BY priority ASC, created_at ASC
 		LIMIT ? OFFSET ?
 	`
@@ -266,7 +423,7 @@ func (r *SpecRepository) ListTasksBySpecID(specID string, page, perPage int) ([]
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		task, err := scanTaskRowWithSpecID(rows)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -278,7 +435,7 @@ func (r *SpecRepository) ListTasksBySpecID(specID string, page, perPage int) ([]
 
 func (r *SpecRepository) scanSpec(row *sql.Row) (*domain.Spec, error) {
 	var spec domain.Spec
-	var description, manualStatus sql.NullString
+	var description, manualStatus, deletedAt, dueAt sql.NullString
 	var createdAt, updatedAt string
 
 	err := row.Scan(
@@ -288,6 +445,8 @@ func (r *SpecRepository) scanSpec(row *sql.Row) (*domain.Spec, error) {
 		&manualStatus,
 		&createdAt,
 		&updatedAt,
+		&deletedAt,
+		&dueAt,
 		&spec.TaskCount,
 		&spec.DoneCount,
 	)
@@ -306,6 +465,8 @@ func (r *SpecRepository) scanSpec(row *sql.Row) (*domain.Spec, error) {
 	}
 	spec.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	spec.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	spec.DeletedAt = parseTime(deletedAt)
+	spec.DueAt = parseTime(dueAt)
 
 	return &spec, nil
 }
@@ -314,7 +475,7 @@ func (r *SpecRepository) scanSpecs(rows *sql.Rows) ([]*domain.Spec, error) {
 	var specs []*domain.Spec
 	for rows.Next() {
 		var spec domain.Spec
-		var description, manualStatus sql.NullString
+		var description, manualStatus, deletedAt, dueAt sql.NullString
 		var createdAt, updatedAt string
 
 		err := rows.Scan(
@@ -324,6 +485,8 @@ func (r *SpecRepository) scanSpecs(rows *sql.Rows) ([]*domain.Spec, error) {
 			&manualStatus,
 			&createdAt,
 			&updatedAt,
+			&deletedAt,
+			&dueAt,
 			&spec.TaskCount,
 			&spec.DoneCount,
 		)
@@ -339,55 +502,10 @@ func (r *SpecRepository) scanSpecs(rows *sql.Rows) ([]*domain.Spec, error) {
 		}
 		spec.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 		spec.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		spec.DeletedAt = parseTime(deletedAt)
+		spec.DueAt = parseTime(dueAt)
 
 		specs = append(specs, &spec)
 	}
 	return specs, rows.Err()
 }
-
-// scanTaskRowWithSpecID scans a task row including spec_id field.
-func scanTaskRowWithSpecID(rows *sql.Rows) (*domain.Task, error) {
-	var task domain.Task
-	var parentID, specID, description, claimedBy, claimedAt sql.NullString
-	var status string
-	var createdAt, updatedAt string
-
-	err := rows.Scan(
-		&task.ID,
-		&parentID,
-		&specID,
-		&task.Title,
-		&description,
-		&status,
-		&task.Priority,
-		&claimedBy,
-		&claimedAt,
-		&createdAt,
-		&updatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	task.Status = domain.TaskStatus(status)
-	if parentID.Valid {
-		task.ParentID = &parentID.String
-	}
-	if specID.Valid {
-		task.SpecID = &specID.String
-	}
-	if description.Valid {
-		task.Description = &description.String
-	}
-	if claimedBy.Valid {
-		task.ClaimedBy = &claimedBy.String
-	}
-	if claimedAt.Valid {
-		t, _ := time.Parse(time.RFC3339, claimedAt.String)
-		task.ClaimedAt = &t
-	}
-	task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-
-	return &task, nil
-}
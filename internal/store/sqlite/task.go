@@ -2,26 +2,160 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/airyra/airyra/internal/domain"
 )
 
+// taskColumns lists the task columns in the order expected by scanTask.
+const taskColumns = `id, parent_id, spec_id, title, description, status, priority, claimed_by, claimed_at,
+	block_reason, blocked_by, auto_unblock, estimate, due_at, defer_until, defer_event, assignee, attempts, quarantined_at, created_at, updated_at,
+	deleted_at`
+
+// notDeleted excludes tasks that are in the trash.
+const notDeleted = `deleted_at IS NULL`
+
+// taskSubtree selects the IDs of a task and all of its subtasks with the same
+// deleted_at value (NULL for live tasks, the deletion time for trashed ones).
+const taskSubtree = `
+	WITH RECURSIVE subtree(id) AS (
+		SELECT id FROM tasks WHERE id = ? AND deleted_at IS ?
+		UNION
+		SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS ?
+	)
+	SELECT id FROM subtree`
+
+// liveTaskAncestors selects the IDs of the tasks above a live task in the
+// hierarchy. Purging one of them would cascade to the live task, so they stay
+// in the trash until their subtasks are deleted too.
+const liveTaskAncestors = `
+	WITH RECURSIVE ancestors(id) AS (
+		SELECT parent_id FROM tasks WHERE deleted_at IS NULL AND parent_id IS NOT NULL
+		UNION
+		SELECT t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.id WHERE t.parent_id IS NOT NULL
+	)
+	SELECT id FROM ancestors`
+
+// doneStates selects the workflow states that satisfy dependencies.
+const doneStates = `(SELECT name FROM workflow_states WHERE is_done = 1)`
+
+// overdueTask matches unfinished tasks whose due date is before its parameter.
+const overdueTask = `due_at IS NOT NULL AND due_at < ? AND status != 'cancelled' AND status NOT IN ` + doneStates
+
+// claimableStates selects the workflow states from which tasks can be claimed.
+const claimableStates = `(SELECT name FROM workflow_states WHERE is_claimable = 1)`
+
+// assignedTo matches the tasks that the agent given twice as its parameters
+// may claim: unassigned tasks, tasks assigned to the agent and tasks assigned
+// to a group (an @-prefixed capability) the agent registered with.
+const assignedTo = `(assignee IS NULL OR assignee = ? OR (
+		substr(assignee, 1, 1) = '` + domain.AssigneeGroupPrefix + `' AND EXISTS (
+			SELECT 1 FROM agents a, json_each(a.capabilities) c
+			WHERE a.id = ? AND c.value = substr(assignee, 2)
+		)
+	))`
+
+// readyTask matches live, claimable tasks (aliased t) with no unfinished dependency
+// that are not deferred, to a later time or to an event, and that the calling agent
+// may claim. Its parameters are the current time, the calling agent twice, a JSON
+// array of the IDs of tasks waiting on unfinished tasks in other projects, and
+// whether tasks are gated by their spec's dependencies.
+const readyTask = `t.status IN ` + claimableStates + ` AND t.deleted_at IS NULL
+	AND (t.defer_until IS NULL OR t.defer_until <= ?) AND t.defer_event IS NULL
+	AND ` + assignedTo + `
+	AND NOT EXISTS (
+		SELECT 1 FROM dependencies d
+		JOIN tasks dep ON d.parent_id = dep.id
+		WHERE d.child_id = t.id AND dep.deleted_at IS NULL AND dep.status NOT IN ` + doneStates + `
+	)
+	AND t.id NOT IN (SELECT value FROM json_each(?))
+	AND NOT (? AND EXISTS (
+		SELECT 1 FROM spec_dependencies sd
+		JOIN specs parent ON sd.parent_id = parent.id
+		WHERE sd.child_id = t.spec_id AND parent.deleted_at IS NULL AND ` + unfinishedParentSpec + `
+	))`
+
+// ReadyOptions narrows the ready queue.
+type ReadyOptions struct {
+	// Now leaves out the tasks deferred until after it.
+	Now time.Time
+	// Waiting lists the IDs of tasks that depend on unfinished tasks in other projects.
+	Waiting []string
+	// SpecGate leaves out tasks whose spec depends on an unfinished spec.
+	SpecGate bool
+	// DueSoon moves the tasks whose effective due date is before it to the
+	// front of the queue, earliest due first.
+	DueSoon time.Time
+	// Agent leaves out the tasks assigned to other agents and groups.
+	Agent string
+}
+
+// TaskFilter narrows task listings. Nil fields are not applied.
+type TaskFilter struct {
+	Status    *domain.TaskStatus
+	ClaimedBy *string
+	// OverdueAt keeps the unfinished tasks whose due date is before it.
+	OverdueAt *time.Time
+	// Quarantined keeps the tasks quarantined after too many failed attempts.
+	Quarantined bool
+}
+
+// effectivePriorities defines the effective CTE, which gives every ready task
+// the most urgent priority and the earliest due date among itself and the
+// unfinished tasks that depend on it, directly or transitively. A task in a
+// spec depends on every task of the specs its spec depends on.
+const effectivePriorities = `
+	waits_on(child_id, parent_id) AS (
+		SELECT child_id, parent_id FROM dependencies
+		UNION
+		SELECT c.id, p.id FROM spec_dependencies sd
+		JOIN tasks c ON c.spec_id = sd.child_id
+		JOIN tasks p ON p.spec_id = sd.parent_id
+	),
+	downstream(root_id, task_id) AS (
+		SELECT t.id, t.id FROM tasks t WHERE ` + readyTask + `
+		UNION
+		SELECT ds.root_id, w.child_id FROM downstream ds
+		JOIN waits_on w ON w.parent_id = ds.task_id
+	),
+	effective(task_id, effective_priority, effective_due_at) AS (
+		SELECT ds.root_id, MIN(dt.priority), MIN(dt.due_at) FROM downstream ds
+		JOIN tasks dt ON dt.id = ds.task_id
+		WHERE dt.deleted_at IS NULL AND dt.status != 'cancelled' AND dt.status NOT IN ` + doneStates + `
+		GROUP BY ds.root_id
+	)`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// extraScanner scans a row holding the task columns followed by extra columns.
+type extraScanner struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func (s extraScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
 // TaskRepository handles task persistence operations.
 type TaskRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewTaskRepository creates a new TaskRepository.
-func NewTaskRepository(db *sql.DB) *TaskRepository {
+func NewTaskRepository(db DBTX) *TaskRepository {
 	return &TaskRepository{db: db}
 }
 
 // Create creates a new task.
 func (r *TaskRepository) Create(task *domain.Task) error {
 	query := `
-		INSERT INTO tasks (id, parent_id, spec_id, title, description, status, priority, claimed_by, claimed_at, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (` + taskColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	var claimedAt *string
 	if task.ClaimedAt != nil {
@@ -39,34 +173,73 @@ func (r *TaskRepository) Create(task *domain.Task) error {
 		task.Priority,
 		task.ClaimedBy,
 		claimedAt,
+		task.BlockReason,
+		task.BlockedBy,
+		task.AutoUnblock,
+		task.Estimate,
+		formatTime(task.DueAt),
+		formatTime(task.DeferUntil),
+		task.DeferEvent,
+		task.Assignee,
+		task.Attempts,
+		formatTime(task.QuarantinedAt),
 		task.CreatedAt.Format(time.RFC3339),
 		task.UpdatedAt.Format(time.RFC3339),
+		formatTime(task.DeletedAt),
 	)
 	return err
 }
 
-// GetByID retrieves a task by its ID.
+// GetByID retrieves a task by its ID. Tasks in the trash are not returned.
 func (r *TaskRepository) GetByID(id string) (*domain.Task, error) {
 	query := `
-		SELECT id, parent_id, spec_id, title, description, status, priority, claimed_by, claimed_at, created_at, updated_at
-		FROM tasks WHERE id = ?
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE id = ? AND ` + notDeleted + `
 	`
 	row := r.db.QueryRow(query, id)
-	return r.scanTask(row)
+	return scanTask(row)
 }
 
-// List retrieves tasks with pagination and optional status filter.
-func (r *TaskRepository) List(status *domain.TaskStatus, page, perPage int) ([]*domain.Task, int, error) {
+// GetDeleted retrieves a task from the trash by its ID.
+func (r *TaskRepository) GetDeleted(id string) (*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks WHERE id = ? AND deleted_at IS NOT NULL
+	`
+	row := r.db.QueryRow(query, id)
+	return scanTask(row)
+}
+
+// List retrieves tasks with pagination, narrowed by filter. Overdue tasks
+// are listed earliest due first, quarantined tasks most recently quarantined
+// first.
+func (r *TaskRepository) List(filter TaskFilter, page, perPage int) ([]*domain.Task, int, error) {
 	offset := (page - 1) * perPage
 
-	// Count total
-	countQuery := "SELECT COUNT(*) FROM tasks"
+	conditions := ""
 	args := []interface{}{}
-	if status != nil {
-		countQuery += " WHERE status = ?"
-		args = append(args, string(*status))
+	if filter.Status != nil {
+		conditions += " AND status = ?"
+		args = append(args, string(*filter.Status))
+	}
+	if filter.ClaimedBy != nil {
+		conditions += " AND claimed_by = ?"
+		args = append(args, *filter.ClaimedBy)
+	}
+	order := " ORDER BY priority ASC, created_at ASC"
+	if filter.OverdueAt != nil {
+		conditions += " AND " + overdueTask
+		args = append(args, filter.OverdueAt.UTC().Format(time.RFC3339))
+		order = " ORDER BY due_at ASC, priority ASC, created_at ASC"
+	}
+	if filter.Quarantined {
+		conditions += " AND quarantined_at IS NOT NULL"
+		order = " ORDER BY quarantined_at DESC, priority ASC, created_at ASC"
 	}
 
+	// Count total
+	countQuery := "SELECT COUNT(*) FROM tasks WHERE " + notDeleted + conditions
+
 	var total int
 	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, err
@@ -74,13 +247,9 @@ func (r *TaskRepository) List(status *domain.TaskStatus, page, perPage int) ([]*
 
 	// Fetch tasks
 	query := `
-		SELECT id, parent_id, spec_id, title, description, status, priority, claimed_by, claimed_at, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-	`
-	if status != nil {
-		query += " WHERE status = ?"
-	}
-	query += " ORDER BY priority ASC, created_at ASC LIMIT ? OFFSET ?"
+		WHERE ` + notDeleted + conditions + order + " LIMIT ? OFFSET ?"
 
 	fetchArgs := args
 	fetchArgs = append(fetchArgs, perPage, offset)
@@ -93,7 +262,7 @@ func (r *TaskRepository) List(status *domain.TaskStatus, page, perPage int) ([]*
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		task, err := r.scanTaskRows(rows)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -104,40 +273,40 @@ func (r *TaskRepository) List(status *domain.TaskStatus, page, perPage int) ([]*
 }
 
 // ListReady retrieves tasks that are ready to be worked on.
-// A task is ready if it's open and has no incomplete dependencies.
-func (r *TaskRepository) ListReady(page, perPage int) ([]*domain.Task, int, error) {
+// A task is ready if it's in a claimable state and all its dependencies are in a done state.
+// Dependencies on tasks in the trash are ignored. Tasks are further left out as
+// described by opts.
+func (r *TaskRepository) ListReady(page, perPage int, opts ReadyOptions) ([]*domain.Task, int, error) {
 	offset := (page - 1) * perPage
 
+	waitingJSON, err := json.Marshal(nonNilStrings(opts.Waiting))
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Count ready tasks
-	countQuery := `
-		SELECT COUNT(*) FROM tasks t
-		WHERE t.status = 'open'
-		AND NOT EXISTS (
-			SELECT 1 FROM dependencies d
-			JOIN tasks dep ON d.parent_id = dep.id
-			WHERE d.child_id = t.id AND dep.status != 'done'
-		)
-	`
+	now := opts.Now.UTC().Format(time.RFC3339)
+	countQuery := `SELECT COUNT(*) FROM tasks t WHERE ` + readyTask
 	var total int
-	if err := r.db.QueryRow(countQuery).Scan(&total); err != nil {
+	if err := r.db.QueryRow(countQuery, now, opts.Agent, opts.Agent, string(waitingJSON), opts.SpecGate).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
-	// Fetch ready tasks
+	// Fetch ready tasks: those due soon first, earliest due first, then the
+	// rest by most urgent effective priority
 	query := `
-		SELECT t.id, t.parent_id, t.spec_id, t.title, t.description, t.status, t.priority, t.claimed_by, t.claimed_at, t.created_at, t.updated_at
+		WITH RECURSIVE ` + effectivePriorities + `
+		SELECT ` + taskColumns + `, e.effective_priority, e.effective_due_at
 		FROM tasks t
-		WHERE t.status = 'open'
-		AND NOT EXISTS (
-			SELECT 1 FROM dependencies d
-			JOIN tasks dep ON d.parent_id = dep.id
-			WHERE d.child_id = t.id AND dep.status != 'done'
-		)
-		ORDER BY t.priority ASC, t.created_at ASC
+		JOIN effective e ON e.task_id = t.id
+		ORDER BY (e.effective_due_at IS NULL OR e.effective_due_at >= ?) ASC,
+			CASE WHEN e.effective_due_at < ? THEN e.effective_due_at END ASC,
+			e.effective_priority ASC, t.priority ASC, t.created_at ASC
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.Query(query, perPage, offset)
+	dueSoon := opts.DueSoon.UTC().Format(time.RFC3339)
+	rows, err := r.db.Query(query, now, opts.Agent, opts.Agent, string(waitingJSON), opts.SpecGate, dueSoon, dueSoon, perPage, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -145,21 +314,274 @@ func (r *TaskRepository) ListReady(page, perPage int) ([]*domain.Task, int, erro
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		task, err := r.scanTaskRows(rows)
+		var effective int
+		var effectiveDueAt sql.NullString
+		task, err := scanTask(extraScanner{rows, []interface{}{&effective, &effectiveDueAt}})
 		if err != nil {
 			return nil, 0, err
 		}
+		task.EffectivePriority = &effective
+		task.EffectiveDueAt = parseTime(effectiveDueAt)
 		tasks = append(tasks, task)
 	}
 
 	return tasks, total, rows.Err()
 }
 
+// SpecExists reports whether a spec exists and is not in the trash.
+func (r *TaskRepository) SpecExists(id string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS (SELECT 1 FROM specs WHERE id = ? AND deleted_at IS NULL)", id).Scan(&exists)
+	return exists, err
+}
+
+// ListUnfinishedParentSpecs returns the IDs of the unfinished specs that the
+// spec of a task depends on. Specs in the trash are ignored.
+func (r *TaskRepository) ListUnfinishedParentSpecs(taskID string) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT parent.id FROM tasks t
+		JOIN spec_dependencies sd ON sd.child_id = t.spec_id
+		JOIN specs parent ON sd.parent_id = parent.id
+		WHERE t.id = ? AND parent.deleted_at IS NULL AND `+unfinishedParentSpec+`
+		ORDER BY parent.id
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// ListUnfinished returns the IDs, among the given ones, of the live tasks that
+// are not in a done state.
+func (r *TaskRepository) ListUnfinished(ids []string) ([]string, error) {
+	idsJSON, err := json.Marshal(nonNilStrings(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id FROM tasks
+		WHERE id IN (SELECT value FROM json_each(?)) AND `+notDeleted+` AND status NOT IN `+doneStates+`
+		ORDER BY id
+	`, string(idsJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var unfinished []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		unfinished = append(unfinished, id)
+	}
+
+	return unfinished, rows.Err()
+}
+
+// nonNilStrings returns s, or an empty slice when s is nil, so that it encodes
+// as a JSON array.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// ListUnreportedOverdue returns the live tasks that are overdue at now and
+// have no overdue event in the audit log for their current due date.
+func (r *TaskRepository) ListUnreportedOverdue(now time.Time) ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE `+notDeleted+` AND `+overdueTask+`
+		AND NOT EXISTS (
+			SELECT 1 FROM audit_log a
+			WHERE a.task_id = tasks.id AND a.action = 'overdue' AND a.new_value = tasks.due_at
+		)
+		ORDER BY due_at ASC, created_at ASC
+	`, now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// ListAutoUnblock returns the blocked tasks that should be unblocked once the given task is done.
+func (r *TaskRepository) ListAutoUnblock(blockerID string) ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE status = 'blocked' AND auto_unblock = 1 AND blocked_by = ? AND `+notDeleted+`
+		ORDER BY priority ASC, created_at ASC
+	`, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// ListAll returns all tasks that are not in the trash, oldest first.
+func (r *TaskRepository) ListAll() ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE ` + notDeleted + `
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// ListClaimed returns the in-progress tasks held by an agent, oldest claim
+// first.
+func (r *TaskRepository) ListClaimed() ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE `+notDeleted+` AND status = ? AND claimed_by IS NOT NULL
+		ORDER BY claimed_at ASC, id ASC
+	`, string(domain.StatusInProgress))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []*domain.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// ListChildren returns the direct subtasks of a task.
+func (r *TaskRepository) ListChildren(parentID string) ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE parent_id = ? AND `+notDeleted+`
+		ORDER BY priority ASC, created_at ASC
+	`, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// ListDescendants returns all subtasks of a task, recursively.
+func (r *TaskRepository) ListDescendants(id string) ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE id IN (`+taskSubtree+`) AND id != ?
+		ORDER BY priority ASC, created_at ASC
+	`, id, nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// ListDeferredOn returns the live tasks deferred until the event is signalled.
+func (r *TaskRepository) ListDeferredOn(event string) ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE defer_event = ? AND `+notDeleted+`
+		ORDER BY priority ASC, created_at ASC
+	`, event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
 // Update updates a task's fields.
 func (r *TaskRepository) Update(task *domain.Task) error {
 	query := `
 		UPDATE tasks
-		SET parent_id = ?, spec_id = ?, title = ?, description = ?, status = ?, priority = ?, claimed_by = ?, claimed_at = ?, updated_at = ?
+		SET parent_id = ?, spec_id = ?, title = ?, description = ?, status = ?, priority = ?, claimed_by = ?, claimed_at = ?,
+		    block_reason = ?, blocked_by = ?, auto_unblock = ?, estimate = ?, due_at = ?, defer_until = ?, defer_event = ?,
+		    assignee = ?, attempts = ?, quarantined_at = ?, updated_at = ?
 		WHERE id = ?
 	`
 	var claimedAt *string
@@ -177,6 +599,16 @@ func (r *TaskRepository) Update(task *domain.Task) error {
 		task.Priority,
 		task.ClaimedBy,
 		claimedAt,
+		task.BlockReason,
+		task.BlockedBy,
+		task.AutoUnblock,
+		task.Estimate,
+		formatTime(task.DueAt),
+		formatTime(task.DeferUntil),
+		task.DeferEvent,
+		task.Assignee,
+		task.Attempts,
+		formatTime(task.QuarantinedAt),
 		task.UpdatedAt.Format(time.RFC3339),
 		task.ID,
 	)
@@ -194,9 +626,73 @@ func (r *TaskRepository) Update(task *domain.Task) error {
 	return nil
 }
 
-// Delete deletes a task by ID.
-func (r *TaskRepository) Delete(id string) error {
-	result, err := r.db.Exec("DELETE FROM tasks WHERE id = ?", id)
+// Delete moves a task and all of its subtasks to the trash.
+func (r *TaskRepository) Delete(id string, now time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE tasks SET deleted_at = ?
+		WHERE id IN (`+taskSubtree+`)
+	`, now.Format(time.RFC3339), id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Restore moves a task out of the trash together with the subtasks that were
+// deleted along with it.
+func (r *TaskRepository) Restore(id string) error {
+	var deletedAt string
+	err := r.db.QueryRow("SELECT deleted_at FROM tasks WHERE id = ? AND deleted_at IS NOT NULL", id).Scan(&deletedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE tasks SET deleted_at = NULL
+		WHERE id IN (`+taskSubtree+`)
+	`, id, deletedAt, deletedAt)
+	return err
+}
+
+// ListDeleted returns the tasks in the trash, most recently deleted first.
+func (r *TaskRepository) ListDeleted() ([]*domain.Task, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// Purge permanently deletes a task in the trash and its subtasks. A task with
+// a live subtask anywhere below it is not purged.
+func (r *TaskRepository) Purge(id string) error {
+	result, err := r.db.Exec(`
+		DELETE FROM tasks
+		WHERE id = ? AND deleted_at IS NOT NULL AND id NOT IN (`+liveTaskAncestors+`)
+	`, id)
 	if err != nil {
 		return err
 	}
@@ -211,19 +707,67 @@ func (r *TaskRepository) Delete(id string) error {
 	return nil
 }
 
+// PurgeDeletedBefore permanently deletes the tasks that were moved to the trash
+// before the given time and returns how many were removed. Tasks with a live
+// subtask anywhere below them are kept.
+func (r *TaskRepository) PurgeDeletedBefore(cutoff time.Time) (int, error) {
+	result, err := r.db.Exec(`
+		DELETE FROM tasks
+		WHERE deleted_at IS NOT NULL AND deleted_at < ? AND id NOT IN (`+liveTaskAncestors+`)
+	`, cutoff.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), err
+}
+
+// inProgressCount counts the in-progress tasks, narrowed to those claimed by
+// its parameter when the parameter is not NULL.
+const inProgressCount = `(SELECT COUNT(*) FROM tasks
+	WHERE status = 'in_progress' AND deleted_at IS NULL AND (? IS NULL OR claimed_by = ?))`
+
 // AtomicClaim attempts to claim a task atomically.
 // Returns the updated task if successful, or an error if the task cannot be claimed.
-func (r *TaskRepository) AtomicClaim(taskID, agentID string, now time.Time) (*domain.Task, error) {
+// The claim also fails, leaving the task unchanged, when the task is assigned
+// to another agent or group, or when it would take the agent or the project
+// past its WIP limits.
+func (r *TaskRepository) AtomicClaim(taskID, agentID string, now time.Time, limits domain.WIPLimits) (*domain.Task, error) {
+	return r.claim(`status IN `+claimableStates, nil, taskID, agentID, now, limits)
+}
+
+// AtomicClaimFrom claims a task atomically like AtomicClaim, but only while
+// the task is in the from status, which need not be claimable. It backs
+// workflow moves into in_progress.
+func (r *TaskRepository) AtomicClaimFrom(taskID string, from domain.TaskStatus, agentID string, now time.Time, limits domain.WIPLimits) (*domain.Task, error) {
+	return r.claim(`status = ?`, []interface{}{string(from)}, taskID, agentID, now, limits)
+}
+
+// claim moves a task matching the status condition, whose parameters are
+// statusArgs, into in_progress for the agent, subject to the assignee and
+// WIP limit checks of AtomicClaim. It returns the task as it is afterwards.
+func (r *TaskRepository) claim(status string, statusArgs []interface{}, taskID, agentID string, now time.Time, limits domain.WIPLimits) (*domain.Task, error) {
 	nowStr := now.Format(time.RFC3339)
 
+	args := []interface{}{agentID, nowStr, nowStr, taskID}
+	args = append(args, statusArgs...)
+	args = append(args,
+		agentID, agentID,
+		limits.Agent, agentID, agentID, limits.Agent,
+		limits.Project, nil, nil, limits.Project)
+
 	result, err := r.db.Exec(`
 		UPDATE tasks
 		SET status = 'in_progress',
 		    claimed_by = ?,
 		    claimed_at = ?,
 		    updated_at = ?
-		WHERE id = ? AND status = 'open'
-	`, agentID, nowStr, nowStr, taskID)
+		WHERE id = ? AND `+status+` AND `+notDeleted+`
+		  AND `+assignedTo+`
+		  AND (? = 0 OR `+inProgressCount+` < ?)
+		  AND (? = 0 OR `+inProgressCount+` < ?)
+	`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -240,62 +784,53 @@ func (r *TaskRepository) AtomicClaim(taskID, agentID string, now time.Time) (*do
 	return r.GetByID(taskID)
 }
 
-func (r *TaskRepository) scanTask(row *sql.Row) (*domain.Task, error) {
-	var task domain.Task
-	var parentID, specID, description, claimedBy, claimedAt sql.NullString
-	var status string
-	var createdAt, updatedAt string
+// AtomicHandoff transfers an in-progress task from one agent to another
+// atomically. It returns false, leaving the task unchanged, when the task is
+// no longer in progress under from, when it is assigned to someone other than
+// the receiving agent, or when it would take the receiving agent past
+// agentLimit in-progress tasks (zero means no limit).
+func (r *TaskRepository) AtomicHandoff(taskID, from, to string, now time.Time, agentLimit int) (bool, error) {
+	nowStr := now.Format(time.RFC3339)
 
-	err := row.Scan(
-		&task.ID,
-		&parentID,
-		&specID,
-		&task.Title,
-		&description,
-		&status,
-		&task.Priority,
-		&claimedBy,
-		&claimedAt,
-		&createdAt,
-		&updatedAt,
-	)
+	result, err := r.db.Exec(`
+		UPDATE tasks
+		SET claimed_by = ?,
+		    claimed_at = ?,
+		    updated_at = ?
+		WHERE id = ? AND status = 'in_progress' AND claimed_by = ? AND `+notDeleted+`
+		  AND `+assignedTo+`
+		  AND (? = 0 OR `+inProgressCount+` < ?)
+	`, to, nowStr, nowStr, taskID, from,
+		to, to,
+		agentLimit, to, to, agentLimit)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
-		}
-		return nil, err
+		return false, err
 	}
 
-	task.Status = domain.TaskStatus(status)
-	if parentID.Valid {
-		task.ParentID = &parentID.String
-	}
-	if specID.Valid {
-		task.SpecID = &specID.String
-	}
-	if description.Valid {
-		task.Description = &description.String
-	}
-	if claimedBy.Valid {
-		task.ClaimedBy = &claimedBy.String
-	}
-	if claimedAt.Valid {
-		t, _ := time.Parse(time.RFC3339, claimedAt.String)
-		task.ClaimedAt = &t
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
 	}
-	task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return rowsAffected > 0, nil
+}
 
-	return &task, nil
+// CountInProgress counts the in-progress tasks, all of them when claimedBy is
+// nil and those of one agent otherwise.
+func (r *TaskRepository) CountInProgress(claimedBy *string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT `+inProgressCount, claimedBy, claimedBy).Scan(&count)
+	return count, err
 }
 
-func (r *TaskRepository) scanTaskRows(rows *sql.Rows) (*domain.Task, error) {
+// scanTask scans a row selected with taskColumns into a task.
+func scanTask(row rowScanner) (*domain.Task, error) {
 	var task domain.Task
-	var parentID, specID, description, claimedBy, claimedAt sql.NullString
+	var parentID, specID, description, claimedBy, claimedAt, blockReason, blockedBy, dueAt, deferUntil, deferEvent, assignee, quarantinedAt, deletedAt sql.NullString
+	var estimate sql.NullInt64
 	var status string
 	var createdAt, updatedAt string
 
-	err := rows.Scan(
+	err := row.Scan(
 		&task.ID,
 		&parentID,
 		&specID,
@@ -305,8 +840,19 @@ func (r *TaskRepository) scanTaskRows(rows *sql.Rows) (*domain.Task, error) {
 		&task.Priority,
 		&claimedBy,
 		&claimedAt,
+		&blockReason,
+		&blockedBy,
+		&task.AutoUnblock,
+		&estimate,
+		&dueAt,
+		&deferUntil,
+		&deferEvent,
+		&assignee,
+		&task.Attempts,
+		&quarantinedAt,
 		&createdAt,
 		&updatedAt,
+		&deletedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -329,8 +875,46 @@ func (r *TaskRepository) scanTaskRows(rows *sql.Rows) (*domain.Task, error) {
 		t, _ := time.Parse(time.RFC3339, claimedAt.String)
 		task.ClaimedAt = &t
 	}
+	if blockReason.Valid {
+		task.BlockReason = &blockReason.String
+	}
+	if blockedBy.Valid {
+		task.BlockedBy = &blockedBy.String
+	}
+	if estimate.Valid {
+		minutes := int(estimate.Int64)
+		task.Estimate = &minutes
+	}
+	task.DueAt = parseTime(dueAt)
+	task.DeferUntil = parseTime(deferUntil)
+	if deferEvent.Valid {
+		task.DeferEvent = &deferEvent.String
+	}
+	if assignee.Valid {
+		task.Assignee = &assignee.String
+	}
+	task.QuarantinedAt = parseTime(quarantinedAt)
 	task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	task.DeletedAt = parseTime(deletedAt)
 
 	return &task, nil
 }
+
+// formatTime formats an optional time for storage.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
+
+// parseTime parses an optional stored time.
+func parseTime(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, _ := time.Parse(time.RFC3339, s.String)
+	return &t
+}
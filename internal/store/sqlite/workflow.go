@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/airyra/airyra/internal/domain"
+)
+
+// WorkflowRepository handles workflow persistence operations.
+type WorkflowRepository struct {
+	db *sql.DB
+}
+
+// NewWorkflowRepository creates a new WorkflowRepository.
+func NewWorkflowRepository(db *sql.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// Get retrieves the project workflow.
+func (r *WorkflowRepository) Get() (*domain.Workflow, error) {
+	workflow := &domain.Workflow{
+		States:      []domain.WorkflowState{},
+		Transitions: []domain.WorkflowTransition{},
+	}
+
+	rows, err := r.db.Query(`
+		SELECT name, is_done, is_claimable
+		FROM workflow_states
+		ORDER BY position ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state domain.WorkflowState
+		var name string
+		if err := rows.Scan(&name, &state.Done, &state.Claimable); err != nil {
+			return nil, err
+		}
+		state.Name = domain.TaskStatus(name)
+		workflow.States = append(workflow.States, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	transitions, err := r.db.Query(`
+		SELECT t.from_state, t.to_state
+		FROM workflow_transitions t
+		JOIN workflow_states f ON f.name = t.from_state
+		JOIN workflow_states s ON s.name = t.to_state
+		ORDER BY f.position ASC, s.position ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer transitions.Close()
+
+	for transitions.Next() {
+		var from, to string
+		if err := transitions.Scan(&from, &to); err != nil {
+			return nil, err
+		}
+		workflow.Transitions = append(workflow.Transitions, domain.WorkflowTransition{
+			From: domain.TaskStatus(from),
+			To:   domain.TaskStatus(to),
+		})
+	}
+
+	return workflow, transitions.Err()
+}
+
+// Replace replaces the project workflow atomically.
+func (r *WorkflowRepository) Replace(workflow *domain.Workflow) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM workflow_transitions"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM workflow_states"); err != nil {
+		return err
+	}
+
+	for i, state := range workflow.States {
+		if _, err := tx.Exec(`
+			INSERT INTO workflow_states (name, position, is_done, is_claimable)
+			VALUES (?, ?, ?, ?)
+		`, string(state.Name), i, state.Done, state.Claimable); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range workflow.Transitions {
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO workflow_transitions (from_state, to_state)
+			VALUES (?, ?)
+		`, string(t.From), string(t.To)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CountTasksByStatus returns the number of tasks in each status.
+func (r *WorkflowRepository) CountTasksByStatus() (map[domain.TaskStatus]int, error) {
+	rows, err := r.db.Query("SELECT status, COUNT(*) FROM tasks GROUP BY status")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.TaskStatus]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[domain.TaskStatus(status)] = count
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,93 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ListAgents lists the agents seen in the project with the tasks they hold,
+// most recently seen first. A stale agent that still holds tasks is most
+// likely a dead worker.
+func (c *Client) ListAgents(ctx context.Context, opts ...ListAgentsOption) ([]Agent, error) {
+	options := &listAgentsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	path := c.projectPath("/agents")
+
+	params := url.Values{}
+	if options.state != "" {
+		params.Set("state", string(options.state))
+	}
+	if options.staleAfter > 0 {
+		params.Set("stale_after", options.staleAfter.String())
+	}
+
+	if len(params) > 0 {
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list agents failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var agents []Agent
+	if err := json.NewDecoder(resp.Body).Decode(&agents); err != nil {
+		return nil, fmt.Errorf("failed to decode agents response: %w", err)
+	}
+
+	return agents, nil
+}
+
+// RegisterAgent registers the client's agent, replacing the name,
+// capabilities and metadata of an earlier registration. The agent must not
+// be anonymous.
+func (c *Client) RegisterAgent(ctx context.Context, opts ...RegisterAgentOption) (*Agent, error) {
+	body := registerAgentRequest{}
+	for _, opt := range opts {
+		opt(&body)
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/agents"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("register agent failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var agent Agent
+	if err := json.NewDecoder(resp.Body).Decode(&agent); err != nil {
+		return nil, fmt.Errorf("failed to decode agent response: %w", err)
+	}
+
+	return &agent, nil
+}
@@ -0,0 +1,66 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListAgents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/agents" {
+			t.Errorf("expected path /v1/projects/test-project/agents, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("state") != "stale" || r.URL.Query().Get("stale_after") != "1h0m0s" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"worker-1","capabilities":[],"state":"stale",` +
+			`"claims":[{"task_id":"task-1","title":"Ship it"}]}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	agents, err := client.ListAgents(context.Background(), WithAgentState(AgentStale), WithStaleAfter(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(agents) != 1 || agents[0].State != AgentStale || len(agents[0].Claims) != 1 || agents[0].Claims[0].TaskID != "task-1" {
+		t.Errorf("unexpected agents: %+v", agents)
+	}
+}
+
+func TestRegisterAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/agents" {
+			t.Errorf("expected POST /v1/projects/test-project/agents, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body registerAgentRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Name == nil || *body.Name != "Builder" || len(body.Capabilities) != 2 || body.Metadata["host"] != "ci-3" ||
+			body.WIPLimit == nil || *body.WIPLimit != 2 {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Agent{ID: "test-agent", Name: body.Name, Capabilities: body.Capabilities, WIPLimit: body.WIPLimit, State: AgentIdle})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	agent, err := client.RegisterAgent(context.Background(),
+		WithAgentName("Builder"), WithCapabilities("go", "sql"), WithAgentMetadata("host", "ci-3"), WithWIPLimit(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if agent.ID != "test-agent" || agent.State != AgentIdle || agent.WIPLimit == nil || *agent.WIPLimit != 2 {
+		t.Errorf("unexpected agent: %+v", agent)
+	}
+}
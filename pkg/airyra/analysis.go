@@ -0,0 +1,58 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetAnalysis retrieves the critical path, blocking fan-out and parallelism
+// of the project's unfinished tasks.
+func (c *Client) GetAnalysis(ctx context.Context, opts ...AnalysisOption) (*ScheduleAnalysis, error) {
+	options := &analysisOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	path := c.projectPath("/analysis")
+
+	params := url.Values{}
+	if options.specID != "" {
+		params.Set("spec", options.specID)
+	}
+	if options.limit > 0 {
+		params.Set("limit", strconv.Itoa(options.limit))
+	}
+
+	if len(params) > 0 {
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get analysis failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var analysis ScheduleAnalysis
+	if err := json.NewDecoder(resp.Body).Decode(&analysis); err != nil {
+		return nil, fmt.Errorf("failed to decode analysis response: %w", err)
+	}
+
+	return &analysis, nil
+}
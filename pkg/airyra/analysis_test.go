@@ -0,0 +1,47 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAnalysis(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/analysis" {
+			t.Errorf("expected path /v1/projects/test-project/analysis, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("spec"); got != "sp-1" {
+			t.Errorf("expected spec=sp-1, got %q", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "5" {
+			t.Errorf("expected limit=5, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ScheduleAnalysis{
+			Remaining:   2,
+			Parallelism: 1,
+			CriticalPath: []AnalysisTask{
+				{ID: "task-100", Title: "Schema", Status: "open"},
+				{ID: "task-123", Title: "API", Status: "open"},
+			},
+			BlockingFanOut: []FanOut{
+				{AnalysisTask: AnalysisTask{ID: "task-100", Title: "Schema", Status: "open"}, Direct: 1, Downstream: 1},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	analysis, err := client.GetAnalysis(context.Background(), WithAnalysisSpec("sp-1"), WithFanOutLimit(5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(analysis.CriticalPath) != 2 || analysis.BlockingFanOut[0].ID != "task-100" {
+		t.Errorf("unexpected analysis: %+v", analysis)
+	}
+}
@@ -0,0 +1,65 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AddComment adds a comment to a task.
+// The comment is authored by the client's agent ID.
+func (c *Client) AddComment(ctx context.Context, taskID, body string) (*Comment, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+taskID+"/comments"), addCommentRequest{Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("add comment failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var comment Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// ListComments lists the comments on a task, oldest first.
+func (c *Client) ListComments(ctx context.Context, taskID string) ([]Comment, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/comments"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list comments failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var comments []Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+		return nil, fmt.Errorf("failed to decode comments response: %w", err)
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,99 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAddComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/comments" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/comments, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+
+		var body addCommentRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Body != "Tried X, failed because Y" {
+			t.Errorf("expected comment body to be sent, got %q", body.Body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Comment{
+			ID:        1,
+			TaskID:    "task-123",
+			Body:      body.Body,
+			Author:    r.Header.Get("X-Airyra-Agent"),
+			CreatedAt: time.Now(),
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	comment, err := client.AddComment(context.Background(), "task-123", "Tried X, failed because Y")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if comment.ID != 1 {
+		t.Errorf("expected comment ID 1, got %d", comment.ID)
+	}
+	if comment.Author == "" {
+		t.Error("expected comment author to be set from the agent header")
+	}
+}
+
+func TestAddCommentTaskNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "TASK_NOT_FOUND",
+				"message": "Task not found",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.AddComment(context.Background(), "nonexistent", "note")
+	if !IsTaskNotFound(err) {
+		t.Errorf("expected task not found error, got %v", err)
+	}
+}
+
+func TestListComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Comment{
+			{ID: 1, TaskID: "task-123", Body: "first", Author: "agent-1"},
+			{ID: 2, TaskID: "task-123", Body: "second", Author: "agent-2"},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	comments, err := client.ListComments(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(comments))
+	}
+	if comments[1].Author != "agent-2" {
+		t.Errorf("expected second comment author agent-2, got %s", comments[1].Author)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 // AddDependency adds a dependency between two tasks.
 // The child task will depend on the parent task (child is blocked until parent is done).
+// A parent in another project is referenced as "project/task-id".
 func (c *Client) AddDependency(ctx context.Context, childID, parentID string) error {
 	body := addDependencyRequest{
 		ParentID: parentID,
@@ -39,7 +40,8 @@ func (c *Client) AddDependency(ctx context.Context, childID, parentID string) er
 	return nil
 }
 
-// RemoveDependency removes a dependency between two tasks.
+// RemoveDependency removes a dependency between two tasks. A parent in another
+// project is referenced as "project/task-id".
 func (c *Client) RemoveDependency(ctx context.Context, childID, parentID string) error {
 	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/tasks/"+childID+"/deps/"+parentID), nil)
 	if err != nil {
@@ -62,9 +64,31 @@ func (c *Client) RemoveDependency(ctx context.Context, childID, parentID string)
 	return nil
 }
 
-// ListDependencies lists dependencies for a task.
-func (c *Client) ListDependencies(ctx context.Context, taskID string) ([]Dependency, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/deps"), nil)
+// ListDependencies lists the dependencies of a task. With WithTransitive,
+// the dependencies of the tasks it depends on are included, recursively.
+func (c *Client) ListDependencies(ctx context.Context, taskID string, opts ...DependencyListOption) ([]Dependency, error) {
+	return c.listDependencies(ctx, "/tasks/"+taskID+"/deps", "list dependencies", opts)
+}
+
+// ListDependents lists the dependencies on a task, i.e. the tasks it blocks.
+// With WithTransitive, the tasks blocked by those tasks are included, recursively.
+func (c *Client) ListDependents(ctx context.Context, taskID string, opts ...DependencyListOption) ([]Dependency, error) {
+	return c.listDependencies(ctx, "/tasks/"+taskID+"/dependents", "list dependents", opts)
+}
+
+// listDependencies fetches a list of dependencies from path.
+func (c *Client) listDependencies(ctx context.Context, path, action string, opts []DependencyListOption) ([]Dependency, error) {
+	options := &dependencyListOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	path = c.projectPath(path)
+	if options.transitive {
+		path += "?transitive=true"
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +98,7 @@ func (c *Client) ListDependencies(ctx context.Context, taskID string) ([]Depende
 		if isConnectionRefused(err) {
 			return nil, ErrServerNotRunning
 		}
-		return nil, fmt.Errorf("list dependencies failed: %w", err)
+		return nil, fmt.Errorf("%s failed: %w", action, err)
 	}
 	defer resp.Body.Close()
 
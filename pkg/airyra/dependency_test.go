@@ -148,3 +148,49 @@ func TestListDependencies(t *testing.T) {
 		t.Errorf("expected first dependency parent_id parent-1, got %s", deps[0].ParentID)
 	}
 }
+
+func TestListDependencies_OtherProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"child_id":"task-123","parent_id":"parent-1","project":"backend"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	deps, err := client.ListDependencies(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deps) != 1 || deps[0].Project != "backend" || deps[0].ParentID != "parent-1" {
+		t.Errorf("expected a dependency on backend/parent-1, got %+v", deps)
+	}
+}
+
+func TestListDependents_Transitive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/dependents" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/dependents, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("transitive"); got != "true" {
+			t.Errorf("expected transitive=true, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Dependency{
+			{ChildID: "child-1", ParentID: "task-123", Depth: 1},
+			{ChildID: "child-2", ParentID: "child-1", Depth: 2},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	deps, err := client.ListDependents(context.Background(), "task-123", WithTransitive())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deps) != 2 || deps[1].Depth != 2 {
+		t.Errorf("expected 2 dependents with the second at depth 2, got %+v", deps)
+	}
+}
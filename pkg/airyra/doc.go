@@ -42,9 +42,13 @@
 //
 //	task, err := client.ClaimTask(ctx, taskID)
 //
-// Mark a task as blocked:
+// Mark a task as blocked, optionally recording why and what it waits on:
 //
-//	task, err := client.BlockTask(ctx, taskID)
+//	task, err := client.BlockTask(ctx, taskID,
+//	    airyra.WithBlockReason("waiting on API keys"),
+//	    airyra.WithBlockedBy(otherTaskID),
+//	    airyra.WithAutoUnblock(),
+//	)
 //
 // Unblock a task:
 //
@@ -58,6 +62,45 @@
 //
 //	task, err := client.ReleaseTask(ctx, taskID, false)
 //
+// Cancel a task that will not be done, and reopen it later:
+//
+//	task, err := client.CancelTask(ctx, taskID, "superseded by the new API")
+//	task, err := client.ReopenTask(ctx, taskID)
+//
+// # Workflows
+//
+// Projects can add their own states to the default open, in_progress,
+// blocked and done workflow. Move a task along an allowed transition:
+//
+//	task, err := client.TransitionTask(ctx, taskID, "review")
+//
+// Read or replace the project workflow:
+//
+//	workflow, err := client.GetWorkflow(ctx)
+//	workflow, err = client.SetWorkflow(ctx, workflow)
+//
+// When the workflow has an in_review state, CompleteTask moves the task to
+// review and another agent approves or rejects it:
+//
+//	task, err := client.ApproveTask(ctx, taskID)
+//	task, err := client.RejectTask(ctx, taskID, "missing tests")
+//
+// # Subtasks
+//
+// List the direct subtasks of a task, or fetch its whole subtask tree:
+//
+//	children, err := client.ListChildren(ctx, taskID)
+//	tree, err := client.GetTaskTree(ctx, taskID)
+//
+// By default a task cannot be completed while it has unfinished subtasks,
+// and a task is completed automatically when its last subtask is done.
+// Both rules are project settings:
+//
+//	off := false
+//	settings, err := client.UpdateSettings(ctx, airyra.SettingsUpdate{
+//	    RequireChildrenDone: &off,
+//	})
+//
 // # Dependencies
 //
 // Add a dependency (child waits for parent):
@@ -72,6 +115,55 @@
 //
 //	deps, err := client.ListDependencies(ctx, taskID)
 //
+// Include indirect dependencies, or list what finishing a task would unblock:
+//
+//	deps, err = client.ListDependencies(ctx, taskID, airyra.WithTransitive())
+//	blocked, err := client.ListDependents(ctx, taskID, airyra.WithTransitive())
+//
+// # Graph
+//
+// Retrieve the task and spec graph, optionally narrowed to a spec or to the
+// tasks upstream or downstream of one task:
+//
+//	graph, err := client.GetGraph(ctx)
+//	graph, err = client.GetGraph(ctx, airyra.WithGraphSpec(specID))
+//	graph, err = client.GetGraph(ctx, airyra.WithGraphTask(taskID, airyra.GraphAncestors))
+//
+// # Analysis
+//
+// Find what gates delivery: the critical path of unfinished dependencies,
+// the tasks that unblock the most work, and how many tasks can run in
+// parallel. Estimates (in minutes) weight the critical path:
+//
+//	task, err = client.UpdateTask(ctx, taskID, airyra.WithUpdateEstimate(120))
+//	analysis, err := client.GetAnalysis(ctx, airyra.WithAnalysisSpec(specID))
+//	for _, t := range analysis.CriticalPath {
+//	    fmt.Println(t.ID, t.Title)
+//	}
+//
+// # Comments
+//
+// Leave a work note on a task for the next agent:
+//
+//	comment, err := client.AddComment(ctx, taskID, "Tried X, failed because Y")
+//
+// List the comments on a task:
+//
+//	comments, err := client.ListComments(ctx, taskID)
+//
+// # Trash
+//
+// Deleting a task moves it and its subtasks to the trash. List the trash and
+// restore a task:
+//
+//	trash, err := client.ListTrash(ctx)
+//	task, err := client.RestoreTask(ctx, taskID)
+//
+// Permanently delete an item, or everything, from the trash:
+//
+//	err := client.PurgeTrash(ctx, taskID)
+//	purged, err := client.EmptyTrash(ctx)
+//
 // # Audit History
 //
 // Get the change history for a task:
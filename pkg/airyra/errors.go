@@ -30,6 +30,11 @@ const (
 	ErrCodeSpecAlreadyCancelled   ErrorCode = "SPEC_ALREADY_CANCELLED"
 	ErrCodeSpecNotCancelled       ErrorCode = "SPEC_NOT_CANCELLED"
 	ErrCodeSpecDepNotFound        ErrorCode = "SPEC_DEPENDENCY_NOT_FOUND"
+	ErrCodeSelfReview             ErrorCode = "SELF_REVIEW"
+	ErrCodeChildrenNotDone        ErrorCode = "CHILDREN_NOT_DONE"
+	ErrCodeScheduleNotFound       ErrorCode = "SCHEDULE_NOT_FOUND"
+	ErrCodeWIPLimitExceeded       ErrorCode = "WIP_LIMIT_EXCEEDED"
+	ErrCodeNotAssignee            ErrorCode = "NOT_ASSIGNEE"
 )
 
 // Error represents an error response from the Airyra API.
@@ -102,6 +107,11 @@ func IsSpecNotFound(err error) bool {
 	return hasErrorCode(err, ErrCodeSpecNotFound)
 }
 
+// IsScheduleNotFound returns true if the error indicates a schedule was not found.
+func IsScheduleNotFound(err error) bool {
+	return hasErrorCode(err, ErrCodeScheduleNotFound)
+}
+
 // IsSpecAlreadyCancelled returns true if the error indicates a spec is already cancelled.
 func IsSpecAlreadyCancelled(err error) bool {
 	return hasErrorCode(err, ErrCodeSpecAlreadyCancelled)
@@ -117,6 +127,29 @@ func IsSpecDependencyNotFound(err error) bool {
 	return hasErrorCode(err, ErrCodeSpecDepNotFound)
 }
 
+// IsSelfReview returns true if the error indicates an agent tried to review its own task.
+func IsSelfReview(err error) bool {
+	return hasErrorCode(err, ErrCodeSelfReview)
+}
+
+// IsChildrenNotDone returns true if the error indicates a task cannot be
+// completed because some of its subtasks are unfinished.
+func IsChildrenNotDone(err error) bool {
+	return hasErrorCode(err, ErrCodeChildrenNotDone)
+}
+
+// IsWIPLimitExceeded returns true if the error indicates a claim was refused
+// because the agent or the project holds as many tasks as its WIP limit allows.
+func IsWIPLimitExceeded(err error) bool {
+	return hasErrorCode(err, ErrCodeWIPLimitExceeded)
+}
+
+// IsNotAssignee returns true if the error indicates a claim was refused
+// because the task is assigned to another agent or group.
+func IsNotAssignee(err error) bool {
+	return hasErrorCode(err, ErrCodeNotAssignee)
+}
+
 // IsServerNotRunning returns true if the error indicates the server is not running.
 func IsServerNotRunning(err error) bool {
 	return errors.Is(err, ErrServerNotRunning)
@@ -130,6 +130,34 @@ func TestErrorHelpers(t *testing.T) {
 			want:    false,
 		},
 
+		// WIPLimitExceeded
+		{
+			name:    "IsWIPLimitExceeded with WIP limit error",
+			err:     &Error{Code: ErrCodeWIPLimitExceeded, Message: "WIP limit reached"},
+			checker: IsWIPLimitExceeded,
+			want:    true,
+		},
+		{
+			name:    "IsWIPLimitExceeded with different error",
+			err:     newAlreadyClaimedError("agent", "now"),
+			checker: IsWIPLimitExceeded,
+			want:    false,
+		},
+
+		// NotAssignee
+		{
+			name:    "IsNotAssignee with not assignee error",
+			err:     &Error{Code: ErrCodeNotAssignee, Message: "task is assigned to @go"},
+			checker: IsNotAssignee,
+			want:    true,
+		},
+		{
+			name:    "IsNotAssignee with different error",
+			err:     newAlreadyClaimedError("agent", "now"),
+			checker: IsNotAssignee,
+			want:    false,
+		},
+
 		// ServerNotRunning
 		{
 			name:    "IsServerNotRunning with sentinel error",
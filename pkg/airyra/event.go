@@ -0,0 +1,38 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SignalEvent signals an external event. Every task deferred until the event
+// with WithDeferEvent returns to the ready queue, unless it is also deferred
+// to a later time. It returns the tasks that were waiting on the event.
+func (c *Client) SignalEvent(ctx context.Context, name string) ([]*Task, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/events/"+name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("signal event failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var tasks []*Task
+	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode tasks response: %w", err)
+	}
+
+	return tasks, nil
+}
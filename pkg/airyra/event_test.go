@@ -0,0 +1,54 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSignalEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/events/deploy-done" {
+			t.Errorf("expected POST /v1/projects/test-project/events/deploy-done, got %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Task{{ID: "ar-1", Title: "Smoke test", Status: StatusOpen}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	tasks, err := client.SignalEvent(context.Background(), "deploy-done")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "ar-1" || tasks[0].DeferEvent != nil {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestCreateTask_WithDeferEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body createTaskRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.DeferEvent == nil || *body.DeferEvent != "deploy-done" {
+			t.Errorf("expected defer_event deploy-done, got %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Task{ID: "ar-1", Title: body.Title, Status: StatusOpen, DeferEvent: body.DeferEvent})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.CreateTask(context.Background(), "Smoke test", WithDeferEvent("deploy-done"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.DeferEvent == nil || *task.DeferEvent != "deploy-done" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+}
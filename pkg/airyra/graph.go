@@ -0,0 +1,59 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetGraph retrieves the task and spec graph of the project.
+func (c *Client) GetGraph(ctx context.Context, opts ...GraphOption) (*Graph, error) {
+	options := &graphOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	path := c.projectPath("/graph")
+
+	params := url.Values{}
+	if options.specID != "" {
+		params.Set("spec", options.specID)
+	}
+	if options.taskID != "" {
+		params.Set("task", options.taskID)
+	}
+	if options.direction != "" {
+		params.Set("direction", string(options.direction))
+	}
+
+	if len(params) > 0 {
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get graph failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var graph Graph
+	if err := json.NewDecoder(resp.Body).Decode(&graph); err != nil {
+		return nil, fmt.Errorf("failed to decode graph response: %w", err)
+	}
+
+	return &graph, nil
+}
@@ -0,0 +1,43 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/graph" {
+			t.Errorf("expected path /v1/projects/test-project/graph, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("task"); got != "task-123" {
+			t.Errorf("expected task=task-123, got %q", got)
+		}
+		if got := r.URL.Query().Get("direction"); got != "ancestors" {
+			t.Errorf("expected direction=ancestors, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Graph{
+			Nodes: []GraphNode{
+				{ID: "task-100", Kind: "task", Title: "Schema", Status: "done"},
+				{ID: "task-123", Kind: "task", Title: "API", Status: "open"},
+			},
+			Edges: []GraphEdge{{From: "task-100", To: "task-123", Kind: "dependency"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	graph, err := client.GetGraph(context.Background(), WithGraphTask("task-123", GraphAncestors))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(graph.Nodes) != 2 || len(graph.Edges) != 1 {
+		t.Errorf("expected 2 nodes and 1 edge, got %d and %d", len(graph.Nodes), len(graph.Edges))
+	}
+}
@@ -66,6 +66,11 @@ type createTaskOptions struct {
 	description *string
 	priority    *int
 	parentID    *string
+	estimate    *int
+	dueAt       *string
+	deferUntil  *string
+	deferEvent  *string
+	assignee    *string
 }
 
 // WithDescription sets the task description.
@@ -89,6 +94,43 @@ func WithParentID(parentID string) CreateTaskOption {
 	}
 }
 
+// WithEstimate sets the expected effort of the task in minutes.
+func WithEstimate(minutes int) CreateTaskOption {
+	return func(o *createTaskOptions) {
+		o.estimate = &minutes
+	}
+}
+
+// WithDueAt sets the due date of the task.
+func WithDueAt(due time.Time) CreateTaskOption {
+	return func(o *createTaskOptions) {
+		o.dueAt = formatTime(due)
+	}
+}
+
+// WithDeferUntil keeps the task out of the ready queue until the given time.
+func WithDeferUntil(until time.Time) CreateTaskOption {
+	return func(o *createTaskOptions) {
+		o.deferUntil = formatTime(until)
+	}
+}
+
+// WithDeferEvent keeps the task out of the ready queue until the event is
+// signalled with SignalEvent.
+func WithDeferEvent(event string) CreateTaskOption {
+	return func(o *createTaskOptions) {
+		o.deferEvent = &event
+	}
+}
+
+// WithAssignee restricts who may claim the task to an agent ID, or to the
+// agents registered with a capability when written as @capability.
+func WithAssignee(assignee string) CreateTaskOption {
+	return func(o *createTaskOptions) {
+		o.assignee = &assignee
+	}
+}
+
 // UpdateTaskOption configures an UpdateTask call.
 type UpdateTaskOption func(*updateTaskOptions)
 
@@ -97,6 +139,11 @@ type updateTaskOptions struct {
 	title       *string
 	description *string
 	priority    *int
+	estimate    *int
+	dueAt       *string
+	deferUntil  *string
+	deferEvent  *string
+	assignee    *string
 }
 
 // WithTitle sets the task title for update.
@@ -120,14 +167,82 @@ func WithUpdatePriority(priority int) UpdateTaskOption {
 	}
 }
 
+// WithUpdateEstimate sets the expected effort of the task in minutes for
+// update. Zero clears the estimate.
+func WithUpdateEstimate(minutes int) UpdateTaskOption {
+	return func(o *updateTaskOptions) {
+		o.estimate = &minutes
+	}
+}
+
+// WithUpdateDueAt sets the due date of the task for update. The zero time
+// clears the due date.
+func WithUpdateDueAt(due time.Time) UpdateTaskOption {
+	return func(o *updateTaskOptions) {
+		o.dueAt = formatTime(due)
+	}
+}
+
+// WithUpdateDeferUntil keeps the task out of the ready queue until the given
+// time. The zero time returns it to the queue.
+func WithUpdateDeferUntil(until time.Time) UpdateTaskOption {
+	return func(o *updateTaskOptions) {
+		o.deferUntil = formatTime(until)
+	}
+}
+
+// WithUpdateDeferEvent keeps the task out of the ready queue until the event
+// is signalled with SignalEvent. An empty event stops it waiting.
+func WithUpdateDeferEvent(event string) UpdateTaskOption {
+	return func(o *updateTaskOptions) {
+		o.deferEvent = &event
+	}
+}
+
+// WithUpdateAssignee restricts who may claim the task for update. An empty
+// assignee lets any agent claim it again.
+func WithUpdateAssignee(assignee string) UpdateTaskOption {
+	return func(o *updateTaskOptions) {
+		o.assignee = &assignee
+	}
+}
+
+// BlockTaskOption configures a BlockTask call.
+type BlockTaskOption func(*blockTaskRequest)
+
+// WithBlockReason records why the task is blocked.
+func WithBlockReason(reason string) BlockTaskOption {
+	return func(o *blockTaskRequest) {
+		o.Reason = &reason
+	}
+}
+
+// WithBlockedBy records the task ID, spec ID or URL the task is waiting on.
+func WithBlockedBy(ref string) BlockTaskOption {
+	return func(o *blockTaskRequest) {
+		o.BlockedBy = &ref
+	}
+}
+
+// WithAutoUnblock unblocks the task automatically once the blocking task is done.
+// It requires WithBlockedBy to reference a task.
+func WithAutoUnblock() BlockTaskOption {
+	return func(o *blockTaskRequest) {
+		o.AutoUnblock = true
+	}
+}
+
 // ListTasksOption configures a ListTasks call.
 type ListTasksOption func(*listTasksOptions)
 
 // listTasksOptions holds options for listing tasks.
 type listTasksOptions struct {
-	status  string
-	page    int
-	perPage int
+	status      string
+	overdue     bool
+	quarantined bool
+	claimedBy   string
+	page        int
+	perPage     int
 }
 
 // defaultListTasksOptions returns the default list options.
@@ -145,6 +260,30 @@ func WithStatus(status TaskStatus) ListTasksOption {
 	}
 }
 
+// WithOverdue lists only the unfinished tasks past their due date, earliest
+// due first.
+func WithOverdue() ListTasksOption {
+	return func(o *listTasksOptions) {
+		o.overdue = true
+	}
+}
+
+// WithQuarantined lists only the tasks quarantined after too many failed
+// attempts, most recently quarantined first.
+func WithQuarantined() ListTasksOption {
+	return func(o *listTasksOptions) {
+		o.quarantined = true
+	}
+}
+
+// WithClaimedBy lists only the tasks claimed by the agent. Pass "me" for
+// the tasks claimed by the client's own agent.
+func WithClaimedBy(agentID string) ListTasksOption {
+	return func(o *listTasksOptions) {
+		o.claimedBy = agentID
+	}
+}
+
 // WithPage sets the page number (1-indexed).
 func WithPage(page int) ListTasksOption {
 	return func(o *listTasksOptions) {
@@ -158,3 +297,183 @@ func WithPerPage(perPage int) ListTasksOption {
 		o.perPage = perPage
 	}
 }
+
+// DependencyListOption configures a ListDependencies or ListDependents call.
+type DependencyListOption func(*dependencyListOptions)
+
+// dependencyListOptions holds options for listing dependencies.
+type dependencyListOptions struct {
+	transitive bool
+}
+
+// WithTransitive includes indirect dependencies, recursively.
+func WithTransitive() DependencyListOption {
+	return func(o *dependencyListOptions) {
+		o.transitive = true
+	}
+}
+
+// GraphOption configures which part of the project graph to retrieve.
+type GraphOption func(*graphOptions)
+
+// graphOptions holds options for retrieving the graph.
+type graphOptions struct {
+	specID    string
+	taskID    string
+	direction GraphDirection
+}
+
+// WithGraphSpec limits the graph to a spec and its tasks.
+func WithGraphSpec(specID string) GraphOption {
+	return func(o *graphOptions) {
+		o.specID = specID
+	}
+}
+
+// WithGraphTask limits the graph to a task and its ancestors, descendants or both.
+func WithGraphTask(taskID string, direction GraphDirection) GraphOption {
+	return func(o *graphOptions) {
+		o.taskID = taskID
+		o.direction = direction
+	}
+}
+
+// AnalysisOption configures a GetAnalysis call.
+type AnalysisOption func(*analysisOptions)
+
+// analysisOptions holds options for analyzing the schedule.
+type analysisOptions struct {
+	specID string
+	limit  int
+}
+
+// WithAnalysisSpec limits the analysis to the tasks of a spec.
+func WithAnalysisSpec(specID string) AnalysisOption {
+	return func(o *analysisOptions) {
+		o.specID = specID
+	}
+}
+
+// WithFanOutLimit sets the maximum number of blocking tasks listed.
+func WithFanOutLimit(limit int) AnalysisOption {
+	return func(o *analysisOptions) {
+		o.limit = limit
+	}
+}
+
+// CreateScheduleOption configures a CreateSchedule call.
+type CreateScheduleOption func(*createScheduleOptions)
+
+// createScheduleOptions holds options for creating a schedule.
+type createScheduleOptions struct {
+	catchUp     *CatchUpPolicy
+	description *string
+	priority    *int
+	estimate    *int
+	specID      *string
+}
+
+// WithCatchUp sets what the schedule does with the runs missed while the
+// server was not running.
+func WithCatchUp(policy CatchUpPolicy) CreateScheduleOption {
+	return func(o *createScheduleOptions) {
+		o.catchUp = &policy
+	}
+}
+
+// WithScheduleDescription sets the description of the created tasks.
+func WithScheduleDescription(desc string) CreateScheduleOption {
+	return func(o *createScheduleOptions) {
+		o.description = &desc
+	}
+}
+
+// WithSchedulePriority sets the priority of the created tasks.
+func WithSchedulePriority(priority int) CreateScheduleOption {
+	return func(o *createScheduleOptions) {
+		o.priority = &priority
+	}
+}
+
+// WithScheduleEstimate sets the expected effort of the created tasks in
+// minutes.
+func WithScheduleEstimate(minutes int) CreateScheduleOption {
+	return func(o *createScheduleOptions) {
+		o.estimate = &minutes
+	}
+}
+
+// WithScheduleSpec assigns the created tasks to a spec.
+func WithScheduleSpec(specID string) CreateScheduleOption {
+	return func(o *createScheduleOptions) {
+		o.specID = &specID
+	}
+}
+
+// ListAgentsOption configures a ListAgents call.
+type ListAgentsOption func(*listAgentsOptions)
+
+// listAgentsOptions holds options for listing agents.
+type listAgentsOptions struct {
+	state      AgentState
+	staleAfter time.Duration
+}
+
+// WithAgentState keeps only the agents in a state.
+func WithAgentState(state AgentState) ListAgentsOption {
+	return func(o *listAgentsOptions) {
+		o.state = state
+	}
+}
+
+// WithStaleAfter sets how long an agent may go unseen before it is stale.
+func WithStaleAfter(d time.Duration) ListAgentsOption {
+	return func(o *listAgentsOptions) {
+		o.staleAfter = d
+	}
+}
+
+// RegisterAgentOption configures a RegisterAgent call.
+type RegisterAgentOption func(*registerAgentRequest)
+
+// WithAgentName sets the display name of the agent.
+func WithAgentName(name string) RegisterAgentOption {
+	return func(r *registerAgentRequest) {
+		r.Name = &name
+	}
+}
+
+// WithCapabilities adds capabilities to the agent.
+func WithCapabilities(capabilities ...string) RegisterAgentOption {
+	return func(r *registerAgentRequest) {
+		r.Capabilities = append(r.Capabilities, capabilities...)
+	}
+}
+
+// WithAgentMetadata sets a metadata entry of the agent.
+func WithAgentMetadata(key, value string) RegisterAgentOption {
+	return func(r *registerAgentRequest) {
+		if r.Metadata == nil {
+			r.Metadata = map[string]string{}
+		}
+		r.Metadata[key] = value
+	}
+}
+
+// WithWIPLimit caps the in-progress tasks the agent may hold. The limit can
+// only tighten the project's agent_wip_limit setting; zero removes it.
+func WithWIPLimit(limit int) RegisterAgentOption {
+	return func(r *registerAgentRequest) {
+		r.WIPLimit = &limit
+	}
+}
+
+// formatTime formats a time for a request body. The zero time formats as an
+// empty string, which clears the field.
+func formatTime(due time.Time) *string {
+	s := ""
+	if !due.IsZero() {
+		s = due.UTC().Format(time.RFC3339)
+	}
+	return &s
+}
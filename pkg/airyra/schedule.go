@@ -0,0 +1,161 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CreateSchedule creates a schedule that creates a task titled title each time
+// the cron expression is due, e.g. "0 9 * * mon" or "@daily". Cron
+// expressions are evaluated in the server's local time zone.
+func (c *Client) CreateSchedule(ctx context.Context, cron, title string, opts ...CreateScheduleOption) (*Schedule, error) {
+	options := &createScheduleOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	body := createScheduleRequest{
+		Cron:        cron,
+		CatchUp:     options.catchUp,
+		Title:       title,
+		Description: options.description,
+		Priority:    options.priority,
+		Estimate:    options.estimate,
+		SpecID:      options.specID,
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/schedules"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("create schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var schedule Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule response: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// GetSchedule retrieves a schedule by ID.
+func (c *Client) GetSchedule(ctx context.Context, id string) (*Schedule, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/schedules/"+id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var schedule Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule response: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// ListSchedules lists the schedules of the project, soonest first.
+func (c *Client) ListSchedules(ctx context.Context) ([]Schedule, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/schedules"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list schedules failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var schedules []Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedules); err != nil {
+		return nil, fmt.Errorf("failed to decode schedules response: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// DeleteSchedule removes a schedule. The tasks it created are kept.
+func (c *Client) DeleteSchedule(ctx context.Context, id string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/schedules/"+id), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return ErrServerNotRunning
+		}
+		return fmt.Errorf("delete schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RunSchedule creates the task of a schedule right away. The schedule's next
+// run is not changed.
+func (c *Client) RunSchedule(ctx context.Context, id string) (*Task, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/schedules/"+id+"/run"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("run schedule failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var task Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	}
+
+	return &task, nil
+}
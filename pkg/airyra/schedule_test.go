@@ -0,0 +1,61 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/schedules" {
+			t.Errorf("expected POST /v1/projects/test-project/schedules, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body createScheduleRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Cron != "@daily" || body.Title != "Triage" {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+		if body.CatchUp == nil || *body.CatchUp != CatchUpAll || body.Priority == nil || *body.Priority != 1 {
+			t.Errorf("expected catch-up all and priority 1, got %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Schedule{ID: "sc-1", Cron: body.Cron, CatchUp: *body.CatchUp, Title: body.Title, Priority: 1})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	schedule, err := client.CreateSchedule(context.Background(), "@daily", "Triage",
+		WithCatchUp(CatchUpAll), WithSchedulePriority(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.ID != "sc-1" || schedule.CatchUp != CatchUpAll {
+		t.Errorf("unexpected schedule: %+v", schedule)
+	}
+}
+
+func TestRunSchedule_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "SCHEDULE_NOT_FOUND",
+				"message": "Schedule sc-missing not found",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.RunSchedule(context.Background(), "sc-missing")
+	if !IsScheduleNotFound(err) {
+		t.Errorf("expected schedule not found error, got %v", err)
+	}
+}
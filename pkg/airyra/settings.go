@@ -0,0 +1,51 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetSettings retrieves the project settings.
+func (c *Client) GetSettings(ctx context.Context) (*Settings, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/settings"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doSettingsRequest(req, "get settings")
+}
+
+// UpdateSettings changes the project settings that are set in update.
+func (c *Client) UpdateSettings(ctx context.Context, update SettingsUpdate) (*Settings, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, c.projectPath("/settings"), update)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doSettingsRequest(req, "update settings")
+}
+
+// doSettingsRequest sends a settings request and decodes the returned settings.
+func (c *Client) doSettingsRequest(req *http.Request, action string) (*Settings, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("%s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var settings Settings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("failed to decode settings response: %w", err)
+	}
+
+	return &settings, nil
+}
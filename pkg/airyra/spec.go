@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // CreateSpec creates a new spec.
@@ -22,6 +23,7 @@ func (c *Client) CreateSpec(ctx context.Context, opts ...CreateSpecOption) (*Spe
 
 	body := createSpecRequest{
 		Title: cfg.title,
+		DueAt: cfg.dueAt,
 	}
 	if cfg.description != "" {
 		body.Description = &cfg.description
@@ -53,6 +55,45 @@ func (c *Client) CreateSpec(ctx context.Context, opts ...CreateSpecOption) (*Spe
 	return &spec, nil
 }
 
+// InstantiateSpec creates a spec with its tasks and their dependencies from a
+// template, substituting vars for the template variables. Either everything
+// is created or nothing is.
+func (c *Client) InstantiateSpec(ctx context.Context, template *SpecTemplate, vars map[string]string) (*SpecInstance, error) {
+	if template == nil {
+		return nil, fmt.Errorf("template is required")
+	}
+
+	body := instantiateSpecRequest{
+		Template: template,
+		Vars:     vars,
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/specs/instantiate"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("instantiate spec failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var instance SpecInstance
+	if err := json.NewDecoder(resp.Body).Decode(&instance); err != nil {
+		return nil, fmt.Errorf("failed to decode spec instance response: %w", err)
+	}
+
+	return &instance, nil
+}
+
 // GetSpec retrieves a spec by ID.
 func (c *Client) GetSpec(ctx context.Context, id string) (*Spec, error) {
 	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/specs/"+id), nil)
@@ -81,6 +122,36 @@ func (c *Client) GetSpec(ctx context.Context, id string) (*Spec, error) {
 	return &spec, nil
 }
 
+// GetSpecProgress retrieves the progress report of a spec: its tasks by
+// status, the tasks completed per day, the estimated completion date and a
+// daily burndown.
+func (c *Client) GetSpecProgress(ctx context.Context, id string) (*SpecProgress, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/specs/"+id+"/progress"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get spec progress failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var progress SpecProgress
+	if err := json.NewDecoder(resp.Body).Decode(&progress); err != nil {
+		return nil, fmt.Errorf("failed to decode spec progress response: %w", err)
+	}
+
+	return &progress, nil
+}
+
 // ListSpecs lists specs with optional filtering.
 func (c *Client) ListSpecs(ctx context.Context, opts ...ListSpecsOption) (*SpecList, error) {
 	cfg := &listSpecsConfig{
@@ -96,6 +167,9 @@ func (c *Client) ListSpecs(ctx context.Context, opts ...ListSpecsOption) (*SpecL
 	if cfg.status != "" {
 		params.Set("status", cfg.status)
 	}
+	if cfg.overdue {
+		params.Set("overdue", "true")
+	}
 	params.Set("page", strconv.Itoa(cfg.page))
 	params.Set("per_page", strconv.Itoa(cfg.perPage))
 	path = path + "?" + params.Encode()
@@ -190,6 +264,7 @@ func (c *Client) UpdateSpec(ctx context.Context, id string, opts ...UpdateSpecOp
 	body := updateSpecRequest{
 		Title:       cfg.title,
 		Description: cfg.description,
+		DueAt:       cfg.dueAt,
 	}
 
 	req, err := c.newJSONRequest(ctx, http.MethodPatch, c.projectPath("/specs/"+id), body)
@@ -218,7 +293,7 @@ func (c *Client) UpdateSpec(ctx context.Context, id string, opts ...UpdateSpecOp
 	return &spec, nil
 }
 
-// DeleteSpec deletes a spec.
+// DeleteSpec moves a spec to the trash.
 func (c *Client) DeleteSpec(ctx context.Context, id string) error {
 	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/specs/"+id), nil)
 	if err != nil {
@@ -428,6 +503,7 @@ type CreateSpecOption func(*createSpecConfig)
 type createSpecConfig struct {
 	title       string
 	description string
+	dueAt       *string
 }
 
 // WithSpecTitle sets the spec title.
@@ -444,10 +520,18 @@ func WithSpecDescription(desc string) CreateSpecOption {
 	}
 }
 
+// WithSpecDueAt sets the spec due date.
+func WithSpecDueAt(due time.Time) CreateSpecOption {
+	return func(cfg *createSpecConfig) {
+		cfg.dueAt = formatTime(due)
+	}
+}
+
 // ListSpecs options
 type ListSpecsOption func(*listSpecsConfig)
 type listSpecsConfig struct {
 	status  string
+	overdue bool
 	page    int
 	perPage int
 }
@@ -459,6 +543,14 @@ func WithSpecStatus(status SpecStatus) ListSpecsOption {
 	}
 }
 
+// WithSpecOverdue lists only the unfinished specs past their due date,
+// earliest due first. It applies to ListSpecs.
+func WithSpecOverdue() ListSpecsOption {
+	return func(cfg *listSpecsConfig) {
+		cfg.overdue = true
+	}
+}
+
 // WithSpecPage sets the page number for listing.
 func WithSpecPage(page int) ListSpecsOption {
 	return func(cfg *listSpecsConfig) {
@@ -478,6 +570,7 @@ type UpdateSpecOption func(*updateSpecConfig)
 type updateSpecConfig struct {
 	title       *string
 	description *string
+	dueAt       *string
 }
 
 // WithUpdatedSpecTitle updates the spec title.
@@ -493,3 +586,10 @@ func WithUpdatedSpecDescription(desc string) UpdateSpecOption {
 		cfg.description = &desc
 	}
 }
+
+// WithUpdatedSpecDueAt updates the spec due date. The zero time clears it.
+func WithUpdatedSpecDueAt(due time.Time) UpdateSpecOption {
+	return func(cfg *updateSpecConfig) {
+		cfg.dueAt = formatTime(due)
+	}
+}
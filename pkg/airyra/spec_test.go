@@ -0,0 +1,136 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSpecProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/specs/sp-1/progress" {
+			t.Errorf("expected path /v1/projects/test-project/specs/sp-1/progress, got %s", r.URL.Path)
+		}
+
+		eta := "2026-01-05"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SpecProgress{
+			SpecID:              "sp-1",
+			Total:               4,
+			Done:                2,
+			Remaining:           2,
+			Percent:             50,
+			ByStatus:            map[string]int{"done": 2, "open": 2},
+			CompletedPerDay:     []DailyCount{{Date: "2026-01-01", Count: 2}},
+			Velocity:            1,
+			EstimatedCompletion: &eta,
+			Burndown:            []BurndownPoint{{Date: "2026-01-01", Remaining: 2}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	progress, err := client.GetSpecProgress(context.Background(), "sp-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if progress.Percent != 50 || progress.ByStatus["done"] != 2 {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+	if progress.EstimatedCompletion == nil || *progress.EstimatedCompletion != "2026-01-05" {
+		t.Errorf("expected estimated completion 2026-01-05, got %v", progress.EstimatedCompletion)
+	}
+}
+
+func TestGetSpecProgress_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "SPEC_NOT_FOUND",
+				"message": "Spec sp-missing not found",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.GetSpecProgress(context.Background(), "sp-missing")
+	if !IsSpecNotFound(err) {
+		t.Errorf("expected spec not found error, got %v", err)
+	}
+}
+
+func TestInstantiateSpec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/specs/instantiate" {
+			t.Errorf("expected POST /v1/projects/test-project/specs/instantiate, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body instantiateSpecRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Template == nil || len(body.Template.Tasks) != 2 || body.Vars["name"] != "users" {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(SpecInstance{
+			Spec: &Spec{ID: "sp-1", Title: "Add users endpoint", TaskCount: 2},
+			Tasks: []*Task{
+				{ID: "ar-1", Title: "Design users"},
+				{ID: "ar-2", Title: "Implement users"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	template := &SpecTemplate{
+		Title: "Add {{name}} endpoint",
+		Tasks: []TemplateTask{
+			{Key: "design", Title: "Design {{name}}"},
+			{Key: "implement", Title: "Implement {{name}}", DependsOn: []string{"design"}},
+		},
+	}
+	instance, err := client.InstantiateSpec(context.Background(), template, map[string]string{"name": "users"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if instance.Spec.ID != "sp-1" || len(instance.Tasks) != 2 {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+}
+
+func TestListOverdueSpecs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/specs" {
+			t.Errorf("expected path /v1/projects/test-project/specs, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("overdue") != "true" {
+			t.Errorf("expected overdue=true, got %s", r.URL.Query().Get("overdue"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":       []map[string]interface{}{{"id": "sp-1", "title": "Late", "status": "active", "due_at": "2026-01-01T00:00:00Z"}},
+			"pagination": map[string]interface{}{"page": 1, "per_page": 50, "total": 1, "total_pages": 1},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	specs, err := client.ListSpecs(context.Background(), WithSpecOverdue())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(specs.Specs) != 1 || specs.Specs[0].DueAt == nil {
+		t.Errorf("expected one overdue spec with a due date, got %+v", specs.Specs)
+	}
+}
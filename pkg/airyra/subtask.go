@@ -0,0 +1,64 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ListChildren lists the direct subtasks of a task.
+func (c *Client) ListChildren(ctx context.Context, taskID string) ([]*Task, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/children"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list children failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var children []*Task
+	if err := json.NewDecoder(resp.Body).Decode(&children); err != nil {
+		return nil, fmt.Errorf("failed to decode children response: %w", err)
+	}
+
+	return children, nil
+}
+
+// GetTaskTree retrieves a task together with all of its subtasks, recursively.
+func (c *Client) GetTaskTree(ctx context.Context, taskID string) (*TaskTree, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+taskID+"/tree"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get task tree failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var tree TaskTree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to decode task tree response: %w", err)
+	}
+
+	return &tree, nil
+}
@@ -0,0 +1,67 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTaskTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/tree" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/tree, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"task-123","title":"Parent","status":"open","children":[
+			{"id":"task-456","title":"Child","status":"done","children":[]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	tree, err := client.GetTaskTree(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tree.ID != "task-123" {
+		t.Errorf("expected root task-123, got %s", tree.ID)
+	}
+	if len(tree.Children) != 1 || tree.Children[0].Status != StatusDone {
+		t.Errorf("expected one done child, got %+v", tree.Children)
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["auto_complete_parents"]; ok {
+			t.Error("expected unset settings to be left out")
+		}
+		if body["require_children_done"] != false {
+			t.Errorf("expected require_children_done false, got %v", body["require_children_done"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Settings{AutoCompleteParents: true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	off := false
+	settings, err := client.UpdateSettings(context.Background(), SettingsUpdate{RequireChildrenDone: &off})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.RequireChildrenDone || !settings.AutoCompleteParents {
+		t.Errorf("unexpected settings: %+v", settings)
+	}
+}
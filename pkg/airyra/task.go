@@ -21,6 +21,11 @@ func (c *Client) CreateTask(ctx context.Context, title string, opts ...CreateTas
 		Description: options.description,
 		Priority:    options.priority,
 		ParentID:    options.parentID,
+		Estimate:    options.estimate,
+		DueAt:       options.dueAt,
+		DeferUntil:  options.deferUntil,
+		DeferEvent:  options.deferEvent,
+		Assignee:    options.assignee,
 	}
 
 	req, err := c.newJSONRequest(ctx, http.MethodPost, c.projectPath("/tasks"), body)
@@ -90,6 +95,15 @@ func (c *Client) ListTasks(ctx context.Context, opts ...ListTasksOption) (*TaskL
 	if options.status != "" {
 		params.Set("status", options.status)
 	}
+	if options.overdue {
+		params.Set("overdue", "true")
+	}
+	if options.quarantined {
+		params.Set("quarantined", "true")
+	}
+	if options.claimedBy != "" {
+		params.Set("claimed_by", options.claimedBy)
+	}
 	params.Set("page", strconv.Itoa(options.page))
 	params.Set("per_page", strconv.Itoa(options.perPage))
 
@@ -186,6 +200,11 @@ func (c *Client) UpdateTask(ctx context.Context, id string, opts ...UpdateTaskOp
 		Title:       options.title,
 		Description: options.description,
 		Priority:    options.priority,
+		Estimate:    options.estimate,
+		DueAt:       options.dueAt,
+		DeferUntil:  options.deferUntil,
+		DeferEvent:  options.deferEvent,
+		Assignee:    options.assignee,
 	}
 
 	req, err := c.newJSONRequest(ctx, http.MethodPatch, c.projectPath("/tasks/"+id), body)
@@ -214,7 +233,7 @@ func (c *Client) UpdateTask(ctx context.Context, id string, opts ...UpdateTaskOp
 	return &task, nil
 }
 
-// DeleteTask deletes a task.
+// DeleteTask moves a task and its subtasks to the trash.
 func (c *Client) DeleteTask(ctx context.Context, id string) error {
 	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/tasks/"+id), nil)
 	if err != nil {
@@ -243,10 +262,24 @@ func (c *Client) ClaimTask(ctx context.Context, id string) (*Task, error) {
 }
 
 // CompleteTask marks a task as complete.
+// If the project workflow has a review phase, the task moves to in_review
+// until another agent approves it.
 func (c *Client) CompleteTask(ctx context.Context, id string) (*Task, error) {
 	return c.doTransition(ctx, id, "done")
 }
 
+// ApproveTask approves a task under review, marking it done.
+// The task must be approved by an agent other than the one that completed it.
+func (c *Client) ApproveTask(ctx context.Context, id string) (*Task, error) {
+	return c.doTransition(ctx, id, "approve")
+}
+
+// RejectTask rejects a task under review, returning it to open.
+// The reason is recorded as a comment on the task.
+func (c *Client) RejectTask(ctx context.Context, id, reason string) (*Task, error) {
+	return c.doTransitionWithBody(ctx, id, "reject", rejectTaskRequest{Reason: reason})
+}
+
 // ReleaseTask releases a claimed task.
 func (c *Client) ReleaseTask(ctx context.Context, id string, force bool) (*Task, error) {
 	path := c.projectPath("/tasks/" + id + "/release")
@@ -280,9 +313,49 @@ func (c *Client) ReleaseTask(ctx context.Context, id string, force bool) (*Task,
 	return &task, nil
 }
 
+// HandoffTask hands a claimed task to another agent without releasing it,
+// so no other agent can claim it in between. With force, a project admin can
+// hand off a task claimed by another agent.
+func (c *Client) HandoffTask(ctx context.Context, id, agent string, force bool) (*Task, error) {
+	path := c.projectPath("/tasks/" + id + "/handoff")
+	if force {
+		path = path + "?force=true"
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, handoffTaskRequest{Agent: agent})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("handoff task failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var task Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
+	}
+
+	return &task, nil
+}
+
 // BlockTask marks a task as blocked.
-func (c *Client) BlockTask(ctx context.Context, id string) (*Task, error) {
-	return c.doTransition(ctx, id, "block")
+// Options can record the reason and what the task is waiting on.
+func (c *Client) BlockTask(ctx context.Context, id string, opts ...BlockTaskOption) (*Task, error) {
+	var body blockTaskRequest
+	for _, opt := range opts {
+		opt(&body)
+	}
+	return c.doTransitionWithBody(ctx, id, "block", body)
 }
 
 // UnblockTask unblocks a blocked task.
@@ -290,9 +363,43 @@ func (c *Client) UnblockTask(ctx context.Context, id string) (*Task, error) {
 	return c.doTransition(ctx, id, "unblock")
 }
 
+// CancelTask cancels a task that will not be done.
+// The reason, if not empty, is recorded as a comment on the task.
+func (c *Client) CancelTask(ctx context.Context, id, reason string) (*Task, error) {
+	var body cancelTaskRequest
+	if reason != "" {
+		body.Reason = &reason
+	}
+	return c.doTransitionWithBody(ctx, id, "cancel", body)
+}
+
+// ReopenTask reopens a cancelled task.
+func (c *Client) ReopenTask(ctx context.Context, id string) (*Task, error) {
+	return c.doTransition(ctx, id, "reopen")
+}
+
+// TransitionTask moves a task to a state of the project workflow.
+// The move must be allowed by the workflow's transitions.
+func (c *Client) TransitionTask(ctx context.Context, id string, status TaskStatus) (*Task, error) {
+	return c.doTransitionWithBody(ctx, id, "transition", transitionTaskRequest{Status: string(status)})
+}
+
 // doTransition performs a status transition on a task.
 func (c *Client) doTransition(ctx context.Context, id, action string) (*Task, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/tasks/"+id+"/"+action), nil)
+	return c.doTransitionWithBody(ctx, id, action, nil)
+}
+
+// doTransitionWithBody performs a status transition on a task, sending body as JSON when it is not nil.
+func (c *Client) doTransitionWithBody(ctx context.Context, id, action string, body interface{}) (*Task, error) {
+	path := c.projectPath("/tasks/" + id + "/" + action)
+
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = c.newJSONRequest(ctx, http.MethodPost, path, body)
+	} else {
+		req, err = c.newRequest(ctx, http.MethodPost, path, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
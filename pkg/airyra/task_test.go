@@ -259,6 +259,183 @@ func TestUpdateTask(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskDueAt(t *testing.T) {
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.FixedZone("CET", 3600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req updateTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if req.DueAt == nil || *req.DueAt != "2026-03-01T16:00:00Z" {
+			t.Errorf("expected due_at 2026-03-01T16:00:00Z, got %v", req.DueAt)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Title: "Ship", Status: StatusOpen, DueAt: &due})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.UpdateTask(context.Background(), "task-123", WithUpdateDueAt(due))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.DueAt == nil || !task.DueAt.Equal(due) {
+		t.Errorf("expected due date %v, got %v", due, task.DueAt)
+	}
+}
+
+func TestUpdateTaskClearDueAt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req updateTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if req.DueAt == nil || *req.DueAt != "" {
+			t.Errorf("expected empty due_at to clear it, got %v", req.DueAt)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Title: "Ship", Status: StatusOpen})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	if _, err := client.UpdateTask(context.Background(), "task-123", WithUpdateDueAt(time.Time{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateTaskDeferUntil(t *testing.T) {
+	until := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req updateTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if req.DeferUntil == nil || *req.DeferUntil != "2026-11-01T00:00:00Z" {
+			t.Errorf("expected defer_until 2026-11-01T00:00:00Z, got %v", req.DeferUntil)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Title: "Later", Status: StatusOpen, DeferUntil: &until})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.UpdateTask(context.Background(), "task-123", WithUpdateDeferUntil(until))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.DeferUntil == nil || !task.DeferUntil.Equal(until) {
+		t.Errorf("expected the task deferred until %v, got %v", until, task.DeferUntil)
+	}
+}
+
+func TestUpdateTaskAssignee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req updateTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if req.Assignee == nil || *req.Assignee != "@go" {
+			t.Errorf("expected assignee @go, got %v", req.Assignee)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Title: "Port", Status: StatusOpen, Assignee: req.Assignee})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.UpdateTask(context.Background(), "task-123", WithUpdateAssignee("@go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.Assignee == nil || *task.Assignee != "@go" {
+		t.Errorf("expected the task assigned to @go, got %v", task.Assignee)
+	}
+}
+
+func TestListOverdueTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("overdue") != "true" {
+			t.Errorf("expected overdue=true, got %s", r.URL.Query().Get("overdue"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(paginatedTaskResponse{
+			Data:       []*Task{{ID: "task-1", Title: "Late", Status: StatusOpen}},
+			Pagination: paginationResponse{Page: 1, PerPage: 20, Total: 1, TotalPages: 1},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	tasks, err := client.ListTasks(context.Background(), WithOverdue())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks.Tasks) != 1 {
+		t.Errorf("expected 1 task, got %d", len(tasks.Tasks))
+	}
+}
+
+func TestListQuarantinedTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("quarantined") != "true" {
+			t.Errorf("expected quarantined=true, got %s", r.URL.Query().Get("quarantined"))
+		}
+
+		quarantinedAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(paginatedTaskResponse{
+			Data:       []*Task{{ID: "task-1", Title: "Poison", Status: StatusBlocked, Attempts: 3, QuarantinedAt: &quarantinedAt}},
+			Pagination: paginationResponse{Page: 1, PerPage: 20, Total: 1, TotalPages: 1},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	tasks, err := client.ListTasks(context.Background(), WithQuarantined())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks.Tasks) != 1 || tasks.Tasks[0].Attempts != 3 || tasks.Tasks[0].QuarantinedAt == nil {
+		t.Errorf("expected one quarantined task with 3 attempts, got %+v", tasks.Tasks)
+	}
+}
+
+func TestListClaimedTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("claimed_by") != "me" {
+			t.Errorf("expected claimed_by=me, got %s", r.URL.Query().Get("claimed_by"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(paginatedTaskResponse{
+			Data:       []*Task{{ID: "task-1", Title: "Mine", Status: StatusInProgress}},
+			Pagination: paginationResponse{Page: 1, PerPage: 20, Total: 1, TotalPages: 1},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	tasks, err := client.ListTasks(context.Background(), WithClaimedBy("me"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks.Tasks) != 1 {
+		t.Errorf("expected 1 task, got %d", len(tasks.Tasks))
+	}
+}
+
 func TestDeleteTask(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/v1/projects/test-project/tasks/task-123" {
@@ -409,6 +586,34 @@ func TestReleaseTask(t *testing.T) {
 	}
 }
 
+func TestHandoffTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/test-project/tasks/task-123/handoff" {
+			t.Errorf("expected POST /v1/projects/test-project/tasks/task-123/handoff, got %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("force") != "" {
+			t.Errorf("expected no force parameter, got %s", r.URL.Query().Get("force"))
+		}
+
+		var body handoffTaskRequest
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Status: StatusInProgress, ClaimedBy: &body.Agent})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.HandoffTask(context.Background(), "task-123", "agent-2", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.ClaimedBy == nil || *task.ClaimedBy != "agent-2" {
+		t.Errorf("expected the task claimed by agent-2, got %v", task.ClaimedBy)
+	}
+}
+
 func TestBlockTask(t *testing.T) {
 	now := time.Now()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -439,6 +644,52 @@ func TestBlockTask(t *testing.T) {
 	}
 }
 
+func TestBlockTaskWithOptions(t *testing.T) {
+	now := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body blockTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.Reason == nil || *body.Reason != "waiting on keys" {
+			t.Errorf("expected reason 'waiting on keys', got %v", body.Reason)
+		}
+		if body.BlockedBy == nil || *body.BlockedBy != "task-456" {
+			t.Errorf("expected blocked_by 'task-456', got %v", body.BlockedBy)
+		}
+		if !body.AutoUnblock {
+			t.Error("expected auto_unblock to be true")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{
+			ID:          "task-123",
+			Title:       "Test Task",
+			Status:      StatusBlocked,
+			BlockReason: body.Reason,
+			BlockedBy:   body.BlockedBy,
+			AutoUnblock: body.AutoUnblock,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.BlockTask(context.Background(), "task-123",
+		WithBlockReason("waiting on keys"),
+		WithBlockedBy("task-456"),
+		WithAutoUnblock(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.BlockedBy == nil || *task.BlockedBy != "task-456" {
+		t.Errorf("expected blocked_by task-456, got %v", task.BlockedBy)
+	}
+}
+
 func TestUnblockTask(t *testing.T) {
 	now := time.Now()
 	claimedBy := "test-agent"
@@ -471,5 +722,41 @@ func TestUnblockTask(t *testing.T) {
 	}
 }
 
+func TestCancelTask(t *testing.T) {
+	now := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/cancel" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/cancel, got %s", r.URL.Path)
+		}
+
+		var body cancelTaskRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Reason == nil || *body.Reason != "superseded" {
+			t.Errorf("expected reason to be sent, got %v", body.Reason)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{
+			ID:        "task-123",
+			Title:     "Test Task",
+			Status:    StatusCancelled,
+			Priority:  PriorityNormal,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.CancelTask(context.Background(), "task-123", "superseded")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.Status != StatusCancelled {
+		t.Errorf("expected status %s, got %s", StatusCancelled, task.Status)
+	}
+}
+
 // Silence unused import warning
 var _ = io.Discard
@@ -0,0 +1,65 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetTaskTimeline retrieves how long a task spent in each status, rebuilt
+// from the audit log.
+func (c *Client) GetTaskTimeline(ctx context.Context, id string) (*TaskTimeline, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/tasks/"+id+"/timeline"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get task timeline failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var timeline TaskTimeline
+	if err := json.NewDecoder(resp.Body).Decode(&timeline); err != nil {
+		return nil, fmt.Errorf("failed to decode task timeline response: %w", err)
+	}
+
+	return &timeline, nil
+}
+
+// GetTimeReport retrieves the time spent on tasks per agent and per spec.
+func (c *Client) GetTimeReport(ctx context.Context) (*TimeReport, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/reports/time"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("get time report failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var report TimeReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode time report response: %w", err)
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,62 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTaskTimeline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-1/timeline" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-1/timeline, got %s", r.URL.Path)
+		}
+
+		cycle := int64(3600)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TaskTimeline{
+			TaskID:        "task-1",
+			Periods:       []StatusPeriod{{Status: StatusDone, Seconds: 60}},
+			WorkedSeconds: 1800,
+			CycleSeconds:  &cycle,
+			Claims:        2,
+			Releases:      1,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	timeline, err := client.GetTaskTimeline(context.Background(), "task-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if timeline.WorkedSeconds != 1800 || timeline.Releases != 1 || timeline.CycleSeconds == nil {
+		t.Errorf("unexpected timeline: %+v", timeline)
+	}
+}
+
+func TestGetTimeReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/reports/time" {
+			t.Errorf("expected path /v1/projects/test-project/reports/time, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":{"tasks":1,"done":1,"worked_seconds":600},` +
+			`"agents":[{"agent":"agent-a","tasks":1,"done":1,"worked_seconds":600}],"specs":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	report, err := client.GetTimeReport(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Agents) != 1 || report.Agents[0].Agent != "agent-a" || report.Agents[0].WorkedSeconds != 600 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,123 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ListTrash lists the deleted tasks and specs.
+func (c *Client) ListTrash(ctx context.Context) (*Trash, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/trash"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("list trash failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var trash Trash
+	if err := json.NewDecoder(resp.Body).Decode(&trash); err != nil {
+		return nil, fmt.Errorf("failed to decode trash response: %w", err)
+	}
+
+	return &trash, nil
+}
+
+// RestoreTask restores a deleted task and the subtasks deleted with it.
+// The task's parent must not be in the trash.
+func (c *Client) RestoreTask(ctx context.Context, id string) (*Task, error) {
+	return c.doTransition(ctx, id, "restore")
+}
+
+// RestoreSpec restores a deleted spec.
+func (c *Client) RestoreSpec(ctx context.Context, id string) (*Spec, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.projectPath("/specs/"+id+"/restore"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("restore spec failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var spec Spec
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec response: %w", err)
+	}
+
+	return &spec, nil
+}
+
+// PurgeTrash permanently deletes a task or spec from the trash.
+// Purging a task also purges its subtasks.
+func (c *Client) PurgeTrash(ctx context.Context, id string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/trash/"+id), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return ErrServerNotRunning
+		}
+		return fmt.Errorf("purge trash failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// EmptyTrash permanently deletes everything in the trash and returns the
+// number of tasks and specs removed.
+func (c *Client) EmptyTrash(ctx context.Context) (int, error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.projectPath("/trash"), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return 0, ErrServerNotRunning
+		}
+		return 0, fmt.Errorf("empty trash failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, parseErrorResponse(resp)
+	}
+
+	var result emptyTrashResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode empty trash response: %w", err)
+	}
+
+	return result.Purged, nil
+}
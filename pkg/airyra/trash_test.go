@@ -0,0 +1,86 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListTrash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/trash" {
+			t.Errorf("expected path /v1/projects/test-project/trash, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		deletedAt := time.Now()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Trash{
+			Tasks: []*Task{{ID: "task-123", Title: "Deleted task", DeletedAt: &deletedAt}},
+			Specs: []*Spec{},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	trash, err := client.ListTrash(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(trash.Tasks) != 1 {
+		t.Fatalf("expected 1 deleted task, got %d", len(trash.Tasks))
+	}
+	if trash.Tasks[0].DeletedAt == nil {
+		t.Error("expected deleted task to have deleted_at")
+	}
+}
+
+func TestRestoreTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/restore" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/restore, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Status: StatusOpen})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.RestoreTask(context.Background(), "task-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.DeletedAt != nil {
+		t.Error("expected restored task to have no deleted_at")
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/trash/task-123" {
+			t.Errorf("expected path /v1/projects/test-project/trash/task-123, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	if err := client.PurgeTrash(context.Background(), "task-123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -14,6 +14,11 @@ const (
 	StatusBlocked TaskStatus = "blocked"
 	// StatusDone indicates a task is completed.
 	StatusDone TaskStatus = "done"
+	// StatusInReview indicates a completed task is waiting for approval.
+	// It is only used by projects whose workflow has a review phase.
+	StatusInReview TaskStatus = "in_review"
+	// StatusCancelled indicates a task will not be done.
+	StatusCancelled TaskStatus = "cancelled"
 )
 
 // Priority constants for task priority levels.
@@ -27,17 +32,31 @@ const (
 
 // Task represents a unit of work in the Airyra system.
 type Task struct {
-	ID          string     `json:"id"`
-	ParentID    *string    `json:"parent_id,omitempty"`
-	SpecID      *string    `json:"spec_id,omitempty"`
-	Title       string     `json:"title"`
-	Description *string    `json:"description,omitempty"`
-	Status      TaskStatus `json:"status"`
-	Priority    int        `json:"priority"`
-	ClaimedBy   *string    `json:"claimed_by,omitempty"`
-	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID                string     `json:"id"`
+	ParentID          *string    `json:"parent_id,omitempty"`
+	SpecID            *string    `json:"spec_id,omitempty"`
+	Title             string     `json:"title"`
+	Description       *string    `json:"description,omitempty"`
+	Status            TaskStatus `json:"status"`
+	Priority          int        `json:"priority"`
+	EffectivePriority *int       `json:"effective_priority,omitempty"` // inherited from dependents; ready listings only
+	ClaimedBy         *string    `json:"claimed_by,omitempty"`
+	ClaimedAt         *time.Time `json:"claimed_at,omitempty"`
+	BlockReason       *string    `json:"block_reason,omitempty"`
+	BlockedBy         *string    `json:"blocked_by,omitempty"`
+	AutoUnblock       bool       `json:"auto_unblock,omitempty"`
+	Estimate          *int       `json:"estimate,omitempty"` // expected effort in minutes
+	DueAt             *time.Time `json:"due_at,omitempty"`
+	EffectiveDueAt    *time.Time `json:"effective_due_at,omitempty"` // earliest due date among dependents; ready listings only
+	DeferUntil        *time.Time `json:"defer_until,omitempty"`      // kept out of the ready queue until then
+	DeferEvent        *string    `json:"defer_event,omitempty"`      // kept out of the ready queue until the event is signalled
+	Assignee          *string    `json:"assignee,omitempty"`         // agent or @capability group allowed to claim
+	Attempts          int        `json:"attempts,omitempty"`         // claims released without completing
+	QuarantinedAt     *time.Time `json:"quarantined_at,omitempty"`   // blocked after max_attempts failed attempts
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	WaitingOnSpecs    []string   `json:"waiting_on_specs,omitempty"` // unfinished specs gating readiness; task details only
 }
 
 // TaskList represents a paginated list of tasks.
@@ -54,6 +73,11 @@ type TaskList struct {
 type Dependency struct {
 	ChildID  string `json:"child_id"`
 	ParentID string `json:"parent_id"`
+	// Project is set when the parent task lives in another project.
+	Project string `json:"project,omitempty"`
+	// Depth is set by transitive listings: 1 for an edge of the queried task,
+	// 2 for an edge one task further away, and so on.
+	Depth int `json:"depth,omitempty"`
 }
 
 // AuditAction represents the type of action recorded in an audit entry.
@@ -70,12 +94,17 @@ const (
 	ActionClaim AuditAction = "claim"
 	// ActionRelease indicates a task was released.
 	ActionRelease AuditAction = "release"
+	// ActionHandoff indicates a claimed task was handed to another agent.
+	ActionHandoff AuditAction = "handoff"
+	// ActionQuarantine indicates a task was blocked after too many failed attempts.
+	ActionQuarantine AuditAction = "quarantine"
 )
 
 // AuditEntry represents a single change in the audit log.
 type AuditEntry struct {
 	ID        int64       `json:"id"`
-	TaskID    string      `json:"task_id"`
+	TaskID    string      `json:"task_id,omitempty"`
+	SpecID    *string     `json:"spec_id,omitempty"` // set instead of TaskID on entries about a spec
 	Action    AuditAction `json:"action"`
 	Field     *string     `json:"field,omitempty"`
 	OldValue  *string     `json:"old_value,omitempty"`
@@ -84,6 +113,180 @@ type AuditEntry struct {
 	ChangedBy string      `json:"changed_by"`
 }
 
+// Comment is a work note left on a task by an agent.
+type Comment struct {
+	ID        int64     `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Body      string    `json:"body"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkflowState describes a task status in a project workflow.
+type WorkflowState struct {
+	Name TaskStatus `json:"name"`
+	// Done states satisfy dependencies on the task.
+	Done bool `json:"done,omitempty"`
+	// Claimable states are listed in the ready queue and can be claimed.
+	Claimable bool `json:"claimable,omitempty"`
+}
+
+// WorkflowTransition is an allowed move between two workflow states.
+type WorkflowTransition struct {
+	From TaskStatus `json:"from"`
+	To   TaskStatus `json:"to"`
+}
+
+// Workflow defines the task statuses of a project and how tasks move between them.
+// Every workflow includes the open, in_progress, blocked, done and cancelled states.
+// Cancelled tasks satisfy dependencies only if the cancelled state is marked done.
+type Workflow struct {
+	States      []WorkflowState      `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// TaskTree is a task together with its subtasks, recursively.
+type TaskTree struct {
+	*Task
+	Children []*TaskTree `json:"children"`
+}
+
+// Settings holds the behavior switches of a project.
+type Settings struct {
+	// RequireChildrenDone prevents a task from completing while any of its
+	// subtasks is neither done nor cancelled.
+	RequireChildrenDone bool `json:"require_children_done"`
+	// AutoCompleteParents completes a task once its last unfinished subtask is done.
+	AutoCompleteParents bool `json:"auto_complete_parents"`
+	// SpecDependenciesGateTasks keeps the tasks of a spec out of the ready
+	// queue while any spec it depends on is unfinished.
+	SpecDependenciesGateTasks bool `json:"spec_dependencies_gate_tasks"`
+	// AgentWIPLimit caps the in-progress tasks each agent may hold; zero
+	// means no limit.
+	AgentWIPLimit int `json:"agent_wip_limit"`
+	// ProjectWIPLimit caps the in-progress tasks of the whole project; zero
+	// means no limit.
+	ProjectWIPLimit int `json:"project_wip_limit"`
+	// MaxAttempts quarantines a task once claims of it have ended in a
+	// release this many times; zero means never.
+	MaxAttempts int `json:"max_attempts"`
+	// Admins lists the agents that may hand off tasks claimed by others.
+	Admins []string `json:"admins"`
+}
+
+// SettingsUpdate lists the project settings to change.
+// Nil fields keep their current values.
+type SettingsUpdate struct {
+	RequireChildrenDone       *bool `json:"require_children_done,omitempty"`
+	AutoCompleteParents       *bool `json:"auto_complete_parents,omitempty"`
+	SpecDependenciesGateTasks *bool `json:"spec_dependencies_gate_tasks,omitempty"`
+	AgentWIPLimit             *int  `json:"agent_wip_limit,omitempty"`
+	ProjectWIPLimit           *int  `json:"project_wip_limit,omitempty"`
+	MaxAttempts               *int  `json:"max_attempts,omitempty"`
+	// Admins replaces the list of admins; an empty list removes them all.
+	Admins *[]string `json:"admins,omitempty"`
+}
+
+// GraphDirection selects which side of a task the graph is narrowed to.
+type GraphDirection string
+
+// Graph directions.
+const (
+	GraphAncestors   GraphDirection = "ancestors"
+	GraphDescendants GraphDirection = "descendants"
+	GraphBoth        GraphDirection = "both"
+)
+
+// GraphNode is a task or spec in the project graph. Kind is "task" or "spec".
+type GraphNode struct {
+	ID     string  `json:"id"`
+	Kind   string  `json:"kind"`
+	Title  string  `json:"title"`
+	Status string  `json:"status"`
+	SpecID *string `json:"spec_id,omitempty"`
+}
+
+// GraphEdge is a directed relationship between two graph nodes. Edges point
+// downstream; Kind is "dependency", "spec_dependency" or "subtask".
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// Graph holds the tasks and specs of a project and the relationships between them.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// AnalysisTask is an unfinished task in a schedule analysis.
+type AnalysisTask struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Estimate *int   `json:"estimate,omitempty"`
+}
+
+// FanOut reports how many unfinished tasks depend on a task directly (Direct)
+// and directly or transitively (Downstream).
+type FanOut struct {
+	AnalysisTask
+	Direct     int `json:"direct"`
+	Downstream int `json:"downstream"`
+}
+
+// ScheduleAnalysis describes what gates delivery of the unfinished tasks.
+type ScheduleAnalysis struct {
+	// Remaining counts the unfinished tasks.
+	Remaining int `json:"remaining"`
+	// Parallelism counts the unfinished tasks that wait on no unfinished dependency.
+	Parallelism int `json:"parallelism"`
+	// CriticalPath is the longest chain of unfinished dependencies.
+	CriticalPath []AnalysisTask `json:"critical_path"`
+	// PathEstimate is the effort of the critical path in minutes, or zero
+	// when no task has an estimate.
+	PathEstimate int `json:"path_estimate,omitempty"`
+	// BlockingFanOut lists the tasks that unblock the most downstream work.
+	BlockingFanOut []FanOut `json:"blocking_fan_out"`
+}
+
+// DailyCount is the number of tasks completed on a day (YYYY-MM-DD, UTC).
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// BurndownPoint is the number of unfinished tasks at the end of a day.
+type BurndownPoint struct {
+	Date      string `json:"date"`
+	Remaining int    `json:"remaining"`
+}
+
+// SpecProgress reports how far the tasks of a spec have come.
+// Cancelled tasks are left out of Total, Done and Remaining.
+type SpecProgress struct {
+	SpecID    string `json:"spec_id"`
+	Total     int    `json:"total"`
+	Done      int    `json:"done"`
+	Remaining int    `json:"remaining"`
+	// Percent is the share of done tasks, from 0 to 100.
+	Percent float64 `json:"percent_complete"`
+	// ByStatus counts the tasks of the spec by status, including cancelled tasks.
+	ByStatus map[string]int `json:"by_status"`
+	// CompletedPerDay lists the days on which tasks were completed.
+	CompletedPerDay []DailyCount `json:"completed_per_day"`
+	// Velocity is the average number of tasks completed per day since the
+	// first completion.
+	Velocity float64 `json:"velocity"`
+	// EstimatedCompletion is the expected completion day at the current
+	// velocity, or nil when nothing remains or nothing was completed yet.
+	EstimatedCompletion *string `json:"estimated_completion,omitempty"`
+	// Burndown lists the unfinished tasks at the end of each day since the
+	// spec was created.
+	Burndown []BurndownPoint `json:"burndown"`
+}
+
 // paginatedTaskResponse is the raw JSON structure for paginated task responses.
 type paginatedTaskResponse struct {
 	Data       []*Task            `json:"data"`
@@ -104,6 +307,11 @@ type createTaskRequest struct {
 	Description *string `json:"description,omitempty"`
 	Priority    *int    `json:"priority,omitempty"`
 	ParentID    *string `json:"parent_id,omitempty"`
+	Estimate    *int    `json:"estimate,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
+	DeferUntil  *string `json:"defer_until,omitempty"`
+	DeferEvent  *string `json:"defer_event,omitempty"`
+	Assignee    *string `json:"assignee,omitempty"`
 }
 
 // updateTaskRequest is the JSON request body for updating a task.
@@ -111,6 +319,38 @@ type updateTaskRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Priority    *int    `json:"priority,omitempty"`
+	Estimate    *int    `json:"estimate,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
+	DeferUntil  *string `json:"defer_until,omitempty"`
+	DeferEvent  *string `json:"defer_event,omitempty"`
+	Assignee    *string `json:"assignee,omitempty"`
+}
+
+// blockTaskRequest is the JSON request body for blocking a task.
+type blockTaskRequest struct {
+	Reason      *string `json:"reason,omitempty"`
+	BlockedBy   *string `json:"blocked_by,omitempty"`
+	AutoUnblock bool    `json:"auto_unblock,omitempty"`
+}
+
+// cancelTaskRequest is the JSON request body for cancelling a task.
+type cancelTaskRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// rejectTaskRequest is the JSON request body for rejecting a task under review.
+type rejectTaskRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handoffTaskRequest is the JSON request body for handing a task to another agent.
+type handoffTaskRequest struct {
+	Agent string `json:"agent"`
+}
+
+// transitionTaskRequest is the JSON request body for moving a task to a workflow state.
+type transitionTaskRequest struct {
+	Status string `json:"status"`
 }
 
 // addDependencyRequest is the JSON request body for adding a dependency.
@@ -118,6 +358,11 @@ type addDependencyRequest struct {
 	ParentID string `json:"parent_id"`
 }
 
+// addCommentRequest is the JSON request body for commenting on a task.
+type addCommentRequest struct {
+	Body string `json:"body"`
+}
+
 // SpecStatus represents the current state of a spec.
 type SpecStatus string
 
@@ -140,8 +385,22 @@ type Spec struct {
 	Status      SpecStatus `json:"status"`
 	TaskCount   int        `json:"task_count"`
 	DoneCount   int        `json:"done_count"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Trash lists the deleted tasks and specs of a project.
+// Deleted items can be restored until they are purged.
+type Trash struct {
+	Tasks []*Task `json:"tasks"`
+	Specs []*Spec `json:"specs"`
+}
+
+// emptyTrashResponse is the JSON response returned when emptying the trash.
+type emptyTrashResponse struct {
+	Purged int `json:"purged"`
 }
 
 // SpecList represents a paginated list of specs.
@@ -165,16 +424,54 @@ type paginatedSpecResponse struct {
 	Pagination paginationResponse `json:"pagination"`
 }
 
+// SpecTemplate describes a spec together with its tasks and their
+// dependencies, to be created in one go. Titles and descriptions may
+// reference variables as {{name}}.
+type SpecTemplate struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	// Vars holds the default values of variables.
+	Vars  map[string]string `json:"vars,omitempty"`
+	Tasks []TemplateTask    `json:"tasks"`
+}
+
+// TemplateTask is a task of a spec template. DependsOn lists the keys of the
+// template tasks it depends on. Estimate is in minutes.
+type TemplateTask struct {
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	Description *string  `json:"description,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Estimate    *int     `json:"estimate,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty"`
+}
+
+// SpecInstance is a spec created from a template, with its tasks in template
+// order.
+type SpecInstance struct {
+	Spec  *Spec   `json:"spec"`
+	Tasks []*Task `json:"tasks"`
+}
+
+// instantiateSpecRequest is the JSON request body for creating a spec from a
+// template.
+type instantiateSpecRequest struct {
+	Template *SpecTemplate     `json:"template"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
+
 // createSpecRequest is the JSON request body for creating a spec.
 type createSpecRequest struct {
 	Title       string  `json:"title"`
 	Description *string `json:"description,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
 }
 
 // updateSpecRequest is the JSON request body for updating a spec.
 type updateSpecRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
+	DueAt       *string `json:"due_at,omitempty"`
 }
 
 // addSpecDependencyRequest is the JSON request body for adding a spec dependency.
@@ -182,3 +479,151 @@ type addSpecDependencyRequest struct {
 	ParentID string `json:"parent_id"`
 }
 
+// CatchUpPolicy decides which runs a schedule makes up for when the server
+// was not running at their time.
+type CatchUpPolicy string
+
+const (
+	CatchUpOnce CatchUpPolicy = "once" // a single task for all the missed runs (default)
+	CatchUpAll  CatchUpPolicy = "all"  // a task for each missed run, up to the last 10
+	CatchUpSkip CatchUpPolicy = "skip" // no task for missed runs
+)
+
+// Schedule creates a task each time its cron expression is due. The title and
+// description of the tasks may reference the day of the run as {{date}}.
+type Schedule struct {
+	ID          string        `json:"id"`
+	Cron        string        `json:"cron"`
+	CatchUp     CatchUpPolicy `json:"catch_up"`
+	Title       string        `json:"title"`
+	Description *string       `json:"description,omitempty"`
+	Priority    int           `json:"priority"`
+	Estimate    *int          `json:"estimate,omitempty"` // expected effort in minutes
+	SpecID      *string       `json:"spec_id,omitempty"`
+	NextRunAt   time.Time     `json:"next_run_at"`
+	LastRunAt   *time.Time    `json:"last_run_at,omitempty"`
+	LastTaskID  *string       `json:"last_task_id,omitempty"`
+	CreatedBy   string        `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// createScheduleRequest is the JSON request body for creating a schedule.
+type createScheduleRequest struct {
+	Cron        string         `json:"cron"`
+	CatchUp     *CatchUpPolicy `json:"catch_up,omitempty"`
+	Title       string         `json:"title"`
+	Description *string        `json:"description,omitempty"`
+	Priority    *int           `json:"priority,omitempty"`
+	Estimate    *int           `json:"estimate,omitempty"`
+	SpecID      *string        `json:"spec_id,omitempty"`
+}
+
+// StatusPeriod is a stretch of time a task spent in one status.
+type StatusPeriod struct {
+	Status TaskStatus `json:"status"`
+	Start  time.Time  `json:"start"`
+	// End is nil for the period of the task's current status.
+	End     *time.Time `json:"end,omitempty"`
+	Seconds int64      `json:"seconds"`
+	// Action and Agent tell how and by whom the task entered the status; for
+	// an in_progress period the agent is the one working on the task.
+	Action string `json:"action,omitempty"`
+	Agent  string `json:"agent,omitempty"`
+}
+
+// TaskTimeline reports how long a task spent in each status.
+type TaskTimeline struct {
+	TaskID string `json:"task_id"`
+	// Estimate is the expected effort in minutes.
+	Estimate *int           `json:"estimate,omitempty"`
+	Periods  []StatusPeriod `json:"periods"`
+	// TimeInStatus totals the seconds spent in each status.
+	TimeInStatus   map[string]int64 `json:"time_in_status"`
+	WorkedSeconds  int64            `json:"worked_seconds"`
+	BlockedSeconds int64            `json:"blocked_seconds"`
+	// CycleSeconds is the time from the first claim until the task was last
+	// done, or nil while the task is not done.
+	CycleSeconds *int64 `json:"cycle_seconds,omitempty"`
+	Claims       int    `json:"claims"`
+	Releases     int    `json:"releases"`
+	Handoffs     int    `json:"handoffs"`
+}
+
+// Effort totals the tracked time of a group of tasks.
+type Effort struct {
+	// Tasks counts the tasks that were worked on and Done the done ones.
+	Tasks          int   `json:"tasks"`
+	Done           int   `json:"done"`
+	WorkedSeconds  int64 `json:"worked_seconds"`
+	BlockedSeconds int64 `json:"blocked_seconds"`
+	Releases       int   `json:"releases"`
+	// EstimateMinutes and EstimatedWorkedSeconds total the estimates and the
+	// worked time of the done tasks with an estimate.
+	EstimateMinutes        int   `json:"estimate_minutes"`
+	EstimatedWorkedSeconds int64 `json:"estimated_worked_seconds"`
+}
+
+// AgentEffort is the effort of one agent.
+type AgentEffort struct {
+	Agent string `json:"agent"`
+	Effort
+}
+
+// SpecEffort is the effort spent on the tasks of one spec.
+type SpecEffort struct {
+	SpecID string `json:"spec_id"`
+	Effort
+}
+
+// TimeReport aggregates task timelines per agent and per spec. Agents are
+// listed by worked time, most first.
+type TimeReport struct {
+	Total  Effort        `json:"total"`
+	Agents []AgentEffort `json:"agents"`
+	Specs  []SpecEffort  `json:"specs"`
+}
+
+// AgentState tells whether an agent is working, waiting for work or gone.
+type AgentState string
+
+const (
+	AgentActive AgentState = "active" // seen recently and holding in-progress tasks
+	AgentIdle   AgentState = "idle"   // seen recently and holding nothing
+	AgentStale  AgentState = "stale"  // not seen within the stale window (default 15 minutes)
+)
+
+// Agent is a worker known to a project. Every request marks its agent as
+// seen; registering adds a name, capabilities and metadata.
+type Agent struct {
+	ID           string            `json:"id"`
+	Name         *string           `json:"name,omitempty"`
+	Capabilities []string          `json:"capabilities"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	// WIPLimit is the agent's own cap on in-progress tasks, nil when only
+	// the project's limits apply.
+	WIPLimit *int `json:"wip_limit,omitempty"`
+	// RegisteredAt is nil for agents that were seen but never registered.
+	RegisteredAt *time.Time `json:"registered_at,omitempty"`
+	FirstSeenAt  time.Time  `json:"first_seen_at"`
+	LastSeenAt   time.Time  `json:"last_seen_at"`
+	State        AgentState `json:"state"`
+	// Claims lists the in-progress tasks the agent holds, oldest claim first.
+	Claims []AgentClaim `json:"claims"`
+}
+
+// AgentClaim is a task held by an agent.
+type AgentClaim struct {
+	TaskID    string     `json:"task_id"`
+	Title     string     `json:"title"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+}
+
+// registerAgentRequest is the JSON request body for registering an agent.
+type registerAgentRequest struct {
+	Name         *string           `json:"name,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	WIPLimit     *int              `json:"wip_limit,omitempty"`
+}
+
@@ -0,0 +1,52 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetWorkflow retrieves the project workflow.
+func (c *Client) GetWorkflow(ctx context.Context) (*Workflow, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.projectPath("/workflow"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doWorkflowRequest(req, "get workflow")
+}
+
+// SetWorkflow replaces the project workflow.
+// States that still have tasks cannot be removed.
+func (c *Client) SetWorkflow(ctx context.Context, workflow *Workflow) (*Workflow, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPut, c.projectPath("/workflow"), workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doWorkflowRequest(req, "set workflow")
+}
+
+// doWorkflowRequest sends a workflow request and decodes the returned workflow.
+func (c *Client) doWorkflowRequest(req *http.Request, action string) (*Workflow, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if isConnectionRefused(err) {
+			return nil, ErrServerNotRunning
+		}
+		return nil, fmt.Errorf("%s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var workflow Workflow
+	if err := json.NewDecoder(resp.Body).Decode(&workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow response: %w", err)
+	}
+
+	return &workflow, nil
+}
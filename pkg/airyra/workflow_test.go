@@ -0,0 +1,120 @@
+package airyra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/workflow" {
+			t.Errorf("expected path /v1/projects/test-project/workflow, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Workflow{
+			States: []WorkflowState{
+				{Name: StatusOpen, Claimable: true},
+				{Name: StatusInProgress},
+				{Name: "review"},
+				{Name: StatusBlocked},
+				{Name: StatusDone, Done: true},
+			},
+			Transitions: []WorkflowTransition{{From: StatusInProgress, To: "review"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	workflow, err := client.GetWorkflow(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(workflow.States) != 5 {
+		t.Errorf("expected 5 states, got %d", len(workflow.States))
+	}
+	if !workflow.States[4].Done {
+		t.Error("expected done state to be marked done")
+	}
+}
+
+func TestTransitionTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/transition" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/transition, got %s", r.URL.Path)
+		}
+
+		var body transitionTaskRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Status != "review" {
+			t.Errorf("expected status review to be sent, got %q", body.Status)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Status: TaskStatus(body.Status)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.TransitionTask(context.Background(), "task-123", "review")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != "review" {
+		t.Errorf("expected status review, got %s", task.Status)
+	}
+}
+
+func TestRejectTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/tasks/task-123/reject" {
+			t.Errorf("expected path /v1/projects/test-project/tasks/task-123/reject, got %s", r.URL.Path)
+		}
+
+		var body rejectTaskRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Reason != "missing tests" {
+			t.Errorf("expected reason to be sent, got %q", body.Reason)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Task{ID: "task-123", Status: StatusOpen})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	task, err := client.RejectTask(context.Background(), "task-123", "missing tests")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != StatusOpen {
+		t.Errorf("expected status open, got %s", task.Status)
+	}
+}
+
+func TestApproveTaskSelfReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "SELF_REVIEW",
+				"message": "Task must be reviewed by a different agent",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.ApproveTask(context.Background(), "task-123")
+	if !IsSelfReview(err) {
+		t.Errorf("expected self review error, got %v", err)
+	}
+}
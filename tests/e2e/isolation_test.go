@@ -139,7 +139,7 @@ func TestE2E_ProjectIsolation_Dependencies(t *testing.T) {
 		t.Logf("stdout: %s", stdout)
 
 		// Try to list dependencies
-		deps, err := suite.getClient(projectB, "test").ListDependencies(t.Context(), taskB)
+		deps, err := suite.getClient(projectB, "test").ListDependencies(t.Context(), taskB, false)
 		if err != nil {
 			t.Logf("Could not list dependencies: %v", err)
 		} else {
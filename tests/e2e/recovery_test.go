@@ -233,7 +233,7 @@ func TestE2E_ServerRestart_Dependencies(t *testing.T) {
 		}
 
 		// Verify dependencies via API
-		deps, err := c.ListDependencies(context.Background(), taskB)
+		deps, err := c.ListDependencies(context.Background(), taskB, false)
 		if err != nil {
 			t.Fatalf("Failed to list dependencies: %v", err)
 		}
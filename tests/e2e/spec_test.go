@@ -320,6 +320,58 @@ func TestE2E_Spec_ComputedStatus_Done(t *testing.T) {
 	}
 }
 
+func TestE2E_Spec_ComputedStatus_ExcludesCancelledTasks(t *testing.T) {
+	suite := setupE2E(t)
+	defer suite.cleanup()
+
+	projectName := "spec-status-cancelled-tasks"
+	suite.createProject(projectName)
+
+	c := suite.getClient(projectName, "test-agent")
+	ctx := context.Background()
+
+	spec, err := c.CreateSpec(ctx, "Partly Dropped Spec", "")
+	if err != nil {
+		t.Fatalf("Failed to create spec: %v", err)
+	}
+
+	task1, err := c.CreateTask(ctx, "Task 1", "", 2, "", spec.ID)
+	if err != nil {
+		t.Fatalf("Failed to create task 1: %v", err)
+	}
+	task2, err := c.CreateTask(ctx, "Task 2", "", 2, "", spec.ID)
+	if err != nil {
+		t.Fatalf("Failed to create task 2: %v", err)
+	}
+
+	// Complete one task and cancel the other
+	if _, err := c.ClaimTask(ctx, task1.ID); err != nil {
+		t.Fatalf("Failed to claim task 1: %v", err)
+	}
+	if _, err := c.CompleteTask(ctx, task1.ID); err != nil {
+		t.Fatalf("Failed to complete task 1: %v", err)
+	}
+	if _, err := c.CancelTask(ctx, task2.ID, "out of scope"); err != nil {
+		t.Fatalf("Failed to cancel task 2: %v", err)
+	}
+
+	spec, err = c.GetSpec(ctx, spec.ID)
+	if err != nil {
+		t.Fatalf("Failed to get spec: %v", err)
+	}
+
+	// The cancelled task no longer counts towards the spec
+	if spec.Status != "done" {
+		t.Errorf("Expected status 'done', got %q", spec.Status)
+	}
+	if spec.TaskCount != 1 {
+		t.Errorf("Expected task_count 1, got %d", spec.TaskCount)
+	}
+	if spec.DoneCount != 1 {
+		t.Errorf("Expected done_count 1, got %d", spec.DoneCount)
+	}
+}
+
 func TestE2E_Spec_ComputedStatus_Cancelled(t *testing.T) {
 	suite := setupE2E(t)
 	defer suite.cleanup()
@@ -713,11 +765,14 @@ func TestE2E_Spec_DeleteCascadesTasks(t *testing.T) {
 	task1, _ := c.CreateTask(ctx, "Task 1", "", 2, "", spec.ID)
 	task2, _ := c.CreateTask(ctx, "Task 2", "", 2, "", spec.ID)
 
-	// Delete the spec
+	// Delete the spec and purge it from the trash
 	err := c.DeleteSpec(ctx, spec.ID)
 	if err != nil {
 		t.Fatalf("Failed to delete spec: %v", err)
 	}
+	if err := c.PurgeTrash(ctx, spec.ID); err != nil {
+		t.Fatalf("Failed to purge spec: %v", err)
+	}
 
 	// Verify tasks still exist but have no spec_id (ON DELETE SET NULL)
 	t1, err := c.GetTask(ctx, task1.ID)
@@ -737,6 +792,55 @@ func TestE2E_Spec_DeleteCascadesTasks(t *testing.T) {
 	}
 }
 
+func TestE2E_Spec_DeleteAndRestore(t *testing.T) {
+	suite := setupE2E(t)
+	defer suite.cleanup()
+
+	projectName := "spec-restore"
+	suite.createProject(projectName)
+
+	c := suite.getClient(projectName, "test-agent")
+	ctx := context.Background()
+
+	spec, _ := c.CreateSpec(ctx, "Restorable Spec", "")
+	task, _ := c.CreateTask(ctx, "Task 1", "", 2, "", spec.ID)
+
+	if err := c.DeleteSpec(ctx, spec.ID); err != nil {
+		t.Fatalf("Failed to delete spec: %v", err)
+	}
+
+	// The spec is hidden but its tasks keep their spec_id while it is in the trash
+	if _, err := c.GetSpec(ctx, spec.ID); err == nil {
+		t.Error("Deleted spec should not be found")
+	}
+	got, err := c.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Task should still exist: %v", err)
+	}
+	if got.SpecID == nil || *got.SpecID != spec.ID {
+		t.Errorf("Task spec_id should be kept while the spec is in the trash, got %v", got.SpecID)
+	}
+
+	trash, err := c.ListTrash(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list trash: %v", err)
+	}
+	if len(trash.Specs) != 1 || trash.Specs[0].ID != spec.ID {
+		t.Fatalf("Trash should contain the spec, got %+v", trash.Specs)
+	}
+
+	restored, err := c.RestoreSpec(ctx, spec.ID)
+	if err != nil {
+		t.Fatalf("Failed to restore spec: %v", err)
+	}
+	if restored.TaskCount != 1 {
+		t.Errorf("Restored spec should have 1 task, got %d", restored.TaskCount)
+	}
+	if restored.DeletedAt != nil {
+		t.Errorf("Restored spec should not have deleted_at, got %v", *restored.DeletedAt)
+	}
+}
+
 func TestE2E_Spec_ListByStatus(t *testing.T) {
 	suite := setupE2E(t)
 	defer suite.cleanup()